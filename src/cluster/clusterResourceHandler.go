@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
 	"github.com/accuknox/auto-policy-discovery/src/types"
 )
 
@@ -70,12 +71,12 @@ func ExtractPodSvcInfoFromIP(ip, clustername string, pods []types.Pod, services
 	podSvcName := ip
 
 	for _, pod := range pods {
-		if pod.PodIP == ip {
+		if libs.IsSameIP(pod.PodIP, ip) || libs.ContainsIP(pod.PodIPs, ip) {
 			return "pod/" + pod.PodName, strings.Join(sort.StringSlice(pod.Labels), ","), pod.Namespace
 		}
 	}
 	for _, svc := range services {
-		if svc.ClusterIP == ip {
+		if libs.IsSameIP(svc.ClusterIP, ip) || libs.ContainsIP(svc.ClusterIPs, ip) {
 			return "svc/" + svc.ServiceName, strings.Join(svc.Labels, ","), svc.Namespace
 		}
 	}
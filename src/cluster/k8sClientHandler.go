@@ -13,7 +13,12 @@ import (
 	"github.com/accuknox/auto-policy-discovery/src/libs"
 	"github.com/accuknox/auto-policy-discovery/src/types"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	rest "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -41,6 +46,22 @@ func ConnectK8sClient() *kubernetes.Clientset {
 }
 
 func ConnectLocalAPIClient() *kubernetes.Clientset {
+	config := getLocalRestConfig()
+	if config == nil {
+		return nil
+	}
+
+	// creates the clientset
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return nil
+	}
+
+	return clientset
+}
+
+func getLocalRestConfig() *rest.Config {
 	if !parsed {
 		homeDir := ""
 		if h := os.Getenv("HOME"); h != "" {
@@ -71,17 +92,24 @@ func ConnectLocalAPIClient() *kubernetes.Clientset {
 		return nil
 	}
 
-	// creates the clientset
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		log.Error().Msg(err.Error())
+	return config
+}
+
+func ConnectInClusterAPIClient() *kubernetes.Clientset {
+	kubeConfig := getInClusterRestConfig()
+	if kubeConfig == nil {
 		return nil
 	}
 
-	return clientset
+	if client, err := kubernetes.NewForConfig(kubeConfig); err != nil {
+		log.Error().Msg(err.Error())
+		return nil
+	} else {
+		return client
+	}
 }
 
-func ConnectInClusterAPIClient() *kubernetes.Clientset {
+func getInClusterRestConfig() *rest.Config {
 	host := ""
 	port := ""
 	token := ""
@@ -107,20 +135,34 @@ func ConnectInClusterAPIClient() *kubernetes.Clientset {
 	token = string(read)
 
 	// create the configuration by token
-	kubeConfig := &rest.Config{
+	return &rest.Config{
 		Host:        "https://" + host + ":" + port,
 		BearerToken: token,
 		TLSClientConfig: rest.TLSClientConfig{
 			Insecure: true,
 		},
 	}
+}
 
-	if client, err := kubernetes.NewForConfig(kubeConfig); err != nil {
+// ConnectK8sDynamicClient returns the dynamic client, used for the custom resources
+func ConnectK8sDynamicClient() dynamic.Interface {
+	var config *rest.Config
+	if isInCluster() {
+		config = getInClusterRestConfig()
+	} else {
+		config = getLocalRestConfig()
+	}
+	if config == nil {
+		return nil
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
 		log.Error().Msg(err.Error())
 		return nil
-	} else {
-		return client
 	}
+
+	return client
 }
 
 // =============== //
@@ -183,6 +225,12 @@ func GetPodsFromK8sClient() []types.Pod {
 			PodName:   pod.Name,
 			Labels:    []string{},
 			PodIP:     pod.Status.PodIP,
+
+			HostNetwork: pod.Spec.HostNetwork,
+		}
+
+		for _, podIP := range pod.Status.PodIPs {
+			group.PodIPs = append(group.PodIPs, podIP.IP)
 		}
 
 		for k, v := range pod.Labels {
@@ -291,12 +339,14 @@ func GetServicesFromK8sClient() []types.Service {
 		k8sService.ServiceName = svc.Name
 		k8sService.Labels = []string{}
 		k8sService.Type = string(svc.Spec.Type)
+		k8sService.ExternalName = svc.Spec.ExternalName
 
 		for k, v := range svc.Labels {
 			k8sService.Labels = append(k8sService.Labels, k+"="+v)
 		}
 
 		k8sService.ExternalIPs = append(k8sService.ExternalIPs, svc.Spec.ExternalIPs...)
+		k8sService.ClusterIPs = append(k8sService.ClusterIPs, svc.Spec.ClusterIPs...)
 
 		for _, port := range svc.Spec.Ports {
 			k8sService.ClusterIP = string(svc.Spec.ClusterIP)
@@ -312,6 +362,11 @@ func GetServicesFromK8sClient() []types.Service {
 
 			results = append(results, k8sService)
 		}
+
+		// the ExternalName service may have no ports
+		if len(svc.Spec.Ports) == 0 && svc.Spec.Type == v1.ServiceTypeExternalName {
+			results = append(results, k8sService)
+		}
 	}
 
 	return results
@@ -476,6 +531,22 @@ func GetNodesFromK8sClient() (*v1.NodeList, error) {
 	return nodeList, nil
 }
 
+// GetNodeLabelsFromK8sClient returns the labels of all the nodes, key: node name
+func GetNodeLabelsFromK8sClient() map[string]map[string]string {
+	results := map[string]map[string]string{}
+
+	nodeList, err := GetNodesFromK8sClient()
+	if err != nil {
+		return results
+	}
+
+	for _, node := range nodeList.Items {
+		results[node.Name] = node.Labels
+	}
+
+	return results
+}
+
 func GetKubearmorRelayURL() string {
 	var namespace string
 	client := ConnectK8sClient()
@@ -499,3 +570,98 @@ func GetKubearmorRelayURL() string {
 	url := "kubearmor." + namespace + ".svc.cluster.local"
 	return url
 }
+
+// ======================= //
+// == Applied Policies == //
+// ======================= //
+
+type policyResource struct {
+	gvr      schema.GroupVersionResource
+	selector []string
+}
+
+// policyResources are the policy kinds reconciled against the discovered policies
+var policyResources = map[string]policyResource{
+	"CiliumNetworkPolicy": {
+		gvr:      schema.GroupVersionResource{Group: "cilium.io", Version: "v2", Resource: "ciliumnetworkpolicies"},
+		selector: []string{"spec", "endpointSelector", "matchLabels"},
+	},
+	"CiliumClusterwideNetworkPolicy": {
+		gvr:      schema.GroupVersionResource{Group: "cilium.io", Version: "v2", Resource: "ciliumclusterwidenetworkpolicies"},
+		selector: []string{"spec", "nodeSelector", "matchLabels"},
+	},
+	"NetworkPolicy": {
+		gvr:      schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+		selector: []string{"spec", "podSelector", "matchLabels"},
+	},
+	"KubeArmorPolicy": {
+		gvr:      schema.GroupVersionResource{Group: "security.kubearmor.com", Version: "v1", Resource: "kubearmorpolicies"},
+		selector: []string{"spec", "selector", "matchLabels"},
+	},
+	"KubeArmorHostPolicy": {
+		gvr:      schema.GroupVersionResource{Group: "security.kubearmor.com", Version: "v1", Resource: "kubearmorhostpolicies"},
+		selector: []string{"spec", "nodeSelector", "matchLabels"},
+	},
+	"Policy": {
+		gvr: schema.GroupVersionResource{Group: "kyverno.io", Version: "v1", Resource: "policies"},
+	},
+}
+
+// GetAppliedPoliciesFromK8sClient returns the policy objects applied in the cluster,
+// the kinds not installed in the cluster are skipped
+func GetAppliedPoliciesFromK8sClient() ([]types.AppliedPolicy, error) {
+	client := ConnectK8sDynamicClient()
+	if client == nil {
+		return nil, errors.New("failed to connect to the k8s api server")
+	}
+
+	results := []types.AppliedPolicy{}
+
+	for kind, res := range policyResources {
+		objs, err := client.Resource(res.gvr).Namespace(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			// the CRD is not installed in the cluster
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				log.Debug().Msgf("unable to list %s: %s", kind, err.Error())
+				continue
+			}
+			return nil, err
+		}
+
+		for _, obj := range objs.Items {
+			results = append(results, toAppliedPolicy(kind, res, obj))
+		}
+	}
+
+	return results, nil
+}
+
+func toAppliedPolicy(kind string, res policyResource, obj unstructured.Unstructured) types.AppliedPolicy {
+	policy := types.AppliedPolicy{
+		Kind:      kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Labels:    types.LabelMap{},
+	}
+
+	if len(res.selector) > 0 {
+		if labels, found, err := unstructured.NestedStringMap(obj.Object, res.selector...); err == nil && found {
+			policy.Labels = labels
+		}
+	}
+
+	if spec, found, err := unstructured.NestedMap(obj.Object, "spec"); err == nil && found {
+		policy.Spec = spec
+	}
+
+	return policy
+}
+
+// GetPoliciesToImport reads the existing policies from the cluster, or from the
+// yaml files in the directory
+func GetPoliciesToImport(from, dir string) ([]types.AppliedPolicy, error) {
+	if from == "dir" {
+		return libs.ReadPolicyYamlFiles(dir)
+	}
+	return GetAppliedPoliciesFromK8sClient()
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// FQDNWildcard is the Cilium matchPattern wildcard, matching a single DNS label
+const FQDNWildcard = "*"
+
+// IsFQDNPattern returns true if the name is a wildcard pattern (matchPattern)
+func IsFQDNPattern(name string) bool {
+	return strings.Contains(name, FQDNWildcard)
+}
+
+func normalizeFQDN(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// MatchFQDNPattern checks if the name is matched by the pattern label by label,
+// e.g. *.s3.amazonaws.com matches bucket.s3.amazonaws.com
+func MatchFQDNPattern(pattern, name string) bool {
+	patternLabels := strings.Split(normalizeFQDN(pattern), ".")
+	nameLabels := strings.Split(normalizeFQDN(name), ".")
+	if len(patternLabels) != len(nameLabels) {
+		return false
+	}
+
+	for i := range patternLabels {
+		if ok, _ := path.Match(patternLabels[i], nameLabels[i]); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parentDomain returns the domain without the first label, e.g. a.example.com --> example.com
+func parentDomain(name string) string {
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// isProtectedSuffix checks if the domain should not be aggregated; the top level
+// domains (e.g. com) are always protected
+func isProtectedSuffix(domain string, protectedSuffixes []string) bool {
+	if !strings.Contains(domain, ".") {
+		return true
+	}
+
+	for _, suffix := range protectedSuffixes {
+		if domain == normalizeFQDN(suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AggregateFQDNs collapses the sibling domain names into the wildcard patterns,
+// e.g. a.s3.amazonaws.com, b.s3.amazonaws.com, ... --> *.s3.amazonaws.com,
+// if #siblings > threshold. The patterns in the input are kept as is, and the
+// names already covered by those are dropped. The threshold 0 disables it.
+func AggregateFQDNs(names []string, threshold int, protectedSuffixes []string) ([]string, []string) {
+	matchNames := []string{}
+	patterns := map[string]bool{}
+
+	if threshold <= 0 {
+		for _, name := range names {
+			if IsFQDNPattern(name) {
+				patterns[name] = true
+			} else {
+				matchNames = append(matchNames, name)
+			}
+		}
+		return matchNames, sortedKeys(patterns)
+	}
+
+	// step 1: keep the existing patterns
+	for _, name := range names {
+		if IsFQDNPattern(name) {
+			patterns[normalizeFQDN(name)] = true
+		}
+	}
+
+	// step 2: group the names by the parent domain
+	groups := map[string][]string{}
+	for _, name := range names {
+		name = normalizeFQDN(name)
+		if IsFQDNPattern(name) || matchAnyFQDNPattern(name, patterns) {
+			continue
+		}
+		groups[parentDomain(name)] = append(groups[parentDomain(name)], name)
+	}
+
+	// step 3: #siblings > threshold --> aggregate it
+	for parent, members := range groups {
+		members = StringDeDuplication(members)
+		if len(members) > threshold && !isProtectedSuffix(parent, protectedSuffixes) {
+			patterns[FQDNWildcard+"."+parent] = true
+		} else {
+			matchNames = append(matchNames, members...)
+		}
+	}
+	sort.Strings(matchNames)
+
+	return matchNames, sortedKeys(patterns)
+}
+
+func matchAnyFQDNPattern(name string, patterns map[string]bool) bool {
+	for pattern := range patterns {
+		if MatchFQDNPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
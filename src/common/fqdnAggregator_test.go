@@ -0,0 +1,53 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFQDNPattern(t *testing.T) {
+	assert.True(t, MatchFQDNPattern("*.s3.amazonaws.com", "bucket-1.s3.amazonaws.com"))
+	assert.True(t, MatchFQDNPattern("*.s3.amazonaws.com", "Bucket-1.s3.amazonaws.com."))
+	assert.False(t, MatchFQDNPattern("*.s3.amazonaws.com", "a.b.s3.amazonaws.com"))
+	assert.False(t, MatchFQDNPattern("*.s3.amazonaws.com", "s3.amazonaws.com"))
+}
+
+func TestAggregateFQDNs_1(t *testing.T) {
+	names := []string{
+		"a.s3.amazonaws.com", "b.s3.amazonaws.com", "c.s3.amazonaws.com", "d.s3.amazonaws.com",
+		"api.example.com",
+	}
+
+	matchNames, matchPatterns := AggregateFQDNs(names, 3, nil)
+
+	assert.Equal(t, []string{"api.example.com"}, matchNames)
+	assert.Equal(t, []string{"*.s3.amazonaws.com"}, matchPatterns)
+}
+
+func TestAggregateFQDNs_2(t *testing.T) {
+	// the top level domains and the protected suffixes are never aggregated
+	names := []string{
+		"a.com", "b.com", "c.com", "d.com",
+		"w.co.uk", "x.co.uk", "y.co.uk", "z.co.uk",
+	}
+
+	matchNames, matchPatterns := AggregateFQDNs(names, 3, []string{"co.uk"})
+
+	assert.Equal(t, []string{"a.com", "b.com", "c.com", "d.com", "w.co.uk", "x.co.uk", "y.co.uk", "z.co.uk"}, matchNames)
+	assert.Equal(t, []string{}, matchPatterns)
+}
+
+func TestAggregateFQDNs_3(t *testing.T) {
+	// the names covered by the existing patterns are dropped
+	names := []string{"*.cdn.example.com", "img.cdn.example.com", "api.example.com"}
+
+	matchNames, matchPatterns := AggregateFQDNs(names, 3, nil)
+
+	assert.Equal(t, []string{"api.example.com"}, matchNames)
+	assert.Equal(t, []string{"*.cdn.example.com"}, matchPatterns)
+
+	// disabled
+	matchNames, _ = AggregateFQDNs([]string{"a.example.com", "b.example.com"}, 0, nil)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, matchNames)
+}
@@ -55,8 +55,10 @@ type HTTPDst struct {
 
 // SysPath Structure
 type SysPath struct {
-	Path  string
-	IsDir bool
+	Path      string
+	IsDir     bool
+	IsPattern bool
+	Stats     types.RuleStats
 }
 
 func (n *Node) generatePaths(results map[string]bool, parentPath string) {
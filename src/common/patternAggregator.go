@@ -0,0 +1,193 @@
+package common
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Pattern strictness levels
+const (
+	// PatternStrict only generalises path segments that are entirely
+	// variable (PIDs, UUIDs, hashes, timestamps)
+	PatternStrict = "strict"
+	// PatternNormal additionally generalises variable parts embedded in a
+	// segment (e.g. session-1234.log) and files sharing an extension
+	PatternNormal = "normal"
+	// PatternRelaxed additionally collapses any sibling files into a wildcard
+	PatternRelaxed = "relaxed"
+)
+
+// PatternDigit is the glob used in place of numeric path segments
+const PatternDigit = "[0-9]*"
+
+var (
+	segDigits    = regexp.MustCompile(`^[0-9]+$`)
+	segUUID      = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	segHash      = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+	segTimestamp = regexp.MustCompile(`^[0-9]{4}-?[0-9]{2}-?[0-9]{2}([T_ -]?[0-9]{2}:?[0-9]{2}(:?[0-9]{2})?(\.[0-9]+)?Z?)?$`)
+	embeddedVar  = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,}|[0-9]{2,}`)
+)
+
+// IsPathPattern returns true if the path is a glob pattern (matchPatterns)
+func IsPathPattern(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// PatternBaseDir returns the directory of the pattern up to its first variable
+// segment, e.g. /proc/[0-9]*/stat --> /proc/
+func PatternBaseDir(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		if IsPathPattern(seg) {
+			return strings.Join(segs[:i], "/") + "/"
+		}
+	}
+	return p
+}
+
+func isHashSegment(seg string) bool {
+	return segHash.MatchString(seg) && strings.ContainsAny(seg, "0123456789")
+}
+
+func generaliseSegment(seg string, strictness string) string {
+	switch {
+	case segDigits.MatchString(seg):
+		return PatternDigit
+	case segUUID.MatchString(seg), segTimestamp.MatchString(seg), isHashSegment(seg):
+		return "*"
+	}
+
+	if strictness == PatternStrict {
+		return seg
+	}
+
+	return embeddedVar.ReplaceAllStringFunc(seg, func(m string) string {
+		if segDigits.MatchString(m) {
+			return PatternDigit
+		}
+		return "*"
+	})
+}
+
+// generalisePath replaces the variable segments of the path,
+// e.g. /proc/1234/stat --> /proc/[0-9]*/stat
+func generalisePath(p string, strictness string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		if seg == "" {
+			continue
+		}
+		segs[i] = generaliseSegment(seg, strictness)
+	}
+	return strings.Join(segs, "/")
+}
+
+// extensionPattern returns the pattern matching all the files having the same
+// extension in the same directory, e.g. /tmp/x.sock --> /tmp/*.sock
+func extensionPattern(p string) string {
+	base := path.Base(p)
+	ext := path.Ext(base)
+	if ext == "" || ext == base {
+		return ""
+	}
+	return path.Join(path.Dir(p), "*"+ext)
+}
+
+// siblingPattern returns the pattern matching all the files in the same
+// directory, e.g. /var/run/x --> /var/run/*
+func siblingPattern(p string) string {
+	dir := path.Dir(p)
+	if dir == "/" { // never wildcard the root directory
+		return ""
+	}
+	return path.Join(dir, "*")
+}
+
+func matchAnyPattern(p string, patterns map[string]bool) bool {
+	for pattern := range patterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// groupPaths groups the paths by the generated pattern, and if #paths in a
+// group > threshold, the group is replaced by the pattern
+func groupPaths(paths []string, patterns map[string]bool, threshold int, gen func(string) string) []string {
+	groups := map[string][]string{}
+	rest := []string{}
+
+	for _, p := range paths {
+		pattern := gen(p)
+		if pattern == "" || !IsPathPattern(pattern) {
+			rest = append(rest, p)
+			continue
+		}
+		groups[pattern] = append(groups[pattern], p)
+	}
+
+	for pattern, members := range groups {
+		if len(members) > threshold {
+			patterns[pattern] = true
+		} else {
+			rest = append(rest, members...)
+		}
+	}
+
+	return rest
+}
+
+// AggregatePathsToPatterns is an alternative to AggregatePathsExt. Instead of
+// collapsing the sibling paths into matchDirectories, it detects the variable
+// path segments (PIDs, UUIDs, hashes, timestamps) and generalises them into
+// glob patterns which could be used as KubeArmor matchPatterns.
+// The directories and the previously generated patterns in the input are kept
+// as is, and the paths already covered by those are dropped.
+func AggregatePathsToPatterns(paths []string, threshold int, strictness string) []string {
+	dirlist, filelist := mergeFileInDir(paths)
+
+	// step 1: keep the existing patterns
+	patterns := map[string]bool{}
+	for _, p := range filelist {
+		if IsPathPattern(p) {
+			patterns[p] = true
+		}
+	}
+
+	rest := []string{}
+	for _, p := range filelist {
+		if patterns[p] || matchAnyPattern(p, patterns) {
+			continue
+		}
+		rest = append(rest, p)
+	}
+
+	// step 2: generalise the variable path segments
+	rest = groupPaths(rest, patterns, threshold, func(p string) string {
+		return generalisePath(p, strictness)
+	})
+
+	// step 3: generalise the files having the same extension
+	if strictness == PatternNormal || strictness == PatternRelaxed {
+		rest = groupPaths(rest, patterns, threshold, extensionPattern)
+	}
+
+	// step 4: generalise any sibling files
+	if strictness == PatternRelaxed {
+		rest = groupPaths(rest, patterns, threshold, siblingPattern)
+	}
+
+	results := rest
+	for pattern := range patterns {
+		results = append(results, pattern)
+	}
+	for dir := range dirlist {
+		results = append(results, dir)
+	}
+	sort.Strings(results)
+
+	return results
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneralisePath(t *testing.T) {
+	var arr = []struct {
+		path       string
+		strictness string
+		exp        string
+	}{
+		{"/proc/1234/stat", PatternStrict, "/proc/[0-9]*/stat"},
+		{"/run/containerd/3f2504e0-4f89-11d3-9a0c-0305e82c3301/log", PatternStrict, "/run/containerd/*/log"},
+		{"/var/lib/docker/a3b1c2d4e5f60718293a4b5c6d7e8f90/config", PatternStrict, "/var/lib/docker/*/config"},
+		{"/var/log/app/2023-01-02T10:11:12Z/out", PatternStrict, "/var/log/app/*/out"},
+		{"/tmp/session-1234.log", PatternStrict, "/tmp/session-1234.log"},
+		{"/tmp/session-1234.log", PatternNormal, "/tmp/session-[0-9]*.log"},
+		{"/usr/lib/python2.7/UserDict.py", PatternNormal, "/usr/lib/python2.7/UserDict.py"},
+	}
+
+	for _, test := range arr {
+		assert.Equal(t, test.exp, generalisePath(test.path, test.strictness))
+	}
+}
+
+func TestAggregatePathsToPatterns_1(t *testing.T) {
+	paths := []string{
+		"/proc/1/stat", "/proc/22/stat", "/proc/333/stat", "/proc/4444/stat",
+		"/etc/passwd",
+	}
+
+	results := AggregatePathsToPatterns(paths, 3, PatternStrict)
+
+	assert.Equal(t, []string{"/etc/passwd", "/proc/[0-9]*/stat"}, results)
+}
+
+func TestAggregatePathsToPatterns_2(t *testing.T) {
+	paths := []string{"/proc/1/stat", "/proc/22/stat", "/proc/333/stat"}
+
+	// #paths does not exceed the threshold
+	results := AggregatePathsToPatterns(paths, 3, PatternStrict)
+
+	assert.Equal(t, paths, results)
+}
+
+func TestAggregatePathsToPatterns_3(t *testing.T) {
+	paths := []string{"/tmp/a.sock", "/tmp/b.sock", "/tmp/c.sock", "/tmp/d.sock", "/tmp/e.pid"}
+
+	results := AggregatePathsToPatterns(paths, 3, PatternStrict)
+	assert.Equal(t, len(results), 5)
+
+	results = AggregatePathsToPatterns(paths, 3, PatternNormal)
+	assert.Equal(t, []string{"/tmp/*.sock", "/tmp/e.pid"}, results)
+}
+
+func TestAggregatePathsToPatterns_4(t *testing.T) {
+	paths := []string{"/var/run/a", "/var/run/b", "/var/run/c", "/var/run/d", "/a", "/b", "/c", "/d"}
+
+	results := AggregatePathsToPatterns(paths, 3, PatternRelaxed)
+
+	// root directory is never wildcarded
+	assert.Equal(t, []string{"/a", "/b", "/c", "/d", "/var/run/*"}, results)
+}
+
+func TestAggregatePathsToPatterns_5(t *testing.T) {
+	// existing patterns and directories are kept, covered paths are dropped
+	paths := []string{"/proc/[0-9]*/stat", "/proc/55/stat", "/usr/lib/", "/usr/lib/xyz", "/etc/hosts"}
+
+	results := AggregatePathsToPatterns(paths, 3, PatternNormal)
+
+	assert.Equal(t, []string{"/etc/hosts", "/proc/[0-9]*/stat", "/usr/lib/"}, results)
+}
+
+func TestAggregatePathsToPatterns_6(t *testing.T) {
+	paths := []string{"/proc/1/stat", "/proc/22/stat", "/proc/333/stat", "/proc/4444/stat"}
+
+	results := AggregatePathsToPatterns(paths, 5, PatternStrict)
+
+	assert.Equal(t, len(results), 4)
+}
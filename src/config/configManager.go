@@ -141,9 +141,11 @@ func LoadConfigFromFile() {
 		NetworkPolicyDir: viper.GetString("application.network.network-policy-dir"),
 
 		NetPolicyTypes:     3,
-		NetPolicyRuleTypes: 1023,
+		NetPolicyRuleTypes: 2047,
 		NetPolicyCIDRBits:  32,
 
+		NetPolicyCIDRBitsV6: viper.GetInt("application.network.cidr-bits-v6"),
+
 		NetLogFilters: []types.NetworkLogFilter{},
 
 		NetPolicyL3Level: 1,
@@ -151,8 +153,58 @@ func LoadConfigFromFile() {
 		NetPolicyL7Level: 1,
 
 		NetSkipCertVerification: viper.GetBool("application.network.skip-cert-verification"),
+
+		PolicyNameTemplate: viper.GetString("application.network.policy-name-template"),
+		MigratePolicyNames: viper.GetBool("application.network.migrate-policy-names"),
+
+		BaselinePolicy:   viper.GetBool("application.network.baseline-policy.enable"),
+		BaselineDNSAllow: viper.GetBool("application.network.baseline-policy.dns-allow"),
+
+		StaleRuleWindow: viper.GetString("application.network.stale-rule.window"),
+		StaleRuleAction: viper.GetString("application.network.stale-rule.action"),
+
+		RuleMinCount: viper.GetInt("application.network.rule-threshold.min-count"),
+		RuleMinPods:  viper.GetInt("application.network.rule-threshold.min-pods"),
+		RuleMinSpan:  viper.GetString("application.network.rule-threshold.min-span"),
+
+		RuleStagingTTL: viper.GetString("application.network.rule-threshold.staging-ttl"),
+
+		GapAnalysis: viper.GetBool("application.network.gap-analysis"),
+
+		ImportPoliciesFrom: viper.GetString("application.network.import-policies.from"),
+		ImportPoliciesDir:  viper.GetString("application.network.import-policies.dir"),
+
+		FQDNThreshold:         viper.GetInt("application.network.fqdn-aggregation.threshold"),
+		FQDNProtectedSuffixes: viper.GetStringSlice("application.network.fqdn-aggregation.protected-suffixes"),
+
+		HTTPHost:            viper.GetBool("application.network.http-host"),
+		HTTPHeaderAllowlist: viper.GetStringSlice("application.network.http-header-allowlist"),
+
+		PortRangeDensity:      viper.GetFloat64("application.network.port-range.density"),
+		PortRangeMinPorts:     viper.GetInt("application.network.port-range.min-ports"),
+		PortRangeWellKnownMax: viper.GetInt("application.network.port-range.well-known-max"),
+
+		IngressWorldFrom:        viper.GetString("application.network.ingress-world.from"),
+		IngressWorldCIDRBits:    viper.GetInt("application.network.ingress-world.cidr-bits"),
+		IngressWorldCIDRBitsV6:  viper.GetInt("application.network.ingress-world.cidr-bits-v6"),
+		IngressControllerLabels: viper.GetStringSlice("application.network.ingress-world.controller-labels"),
+
+		ClusterwideMinNamespaces: viper.GetInt("application.network.clusterwide.min-namespaces"),
+		ClusterwideLabelKeys:     viper.GetStringSlice("application.network.clusterwide.label-keys"),
+
+		HostPolicyDiscovery:  viper.GetBool("application.network.host-policy.enable"),
+		HostPolicyNodeLabels: viper.GetStringSlice("application.network.host-policy.node-labels"),
+
+		ServiceStrategy: viper.GetString("application.network.service-strategy.default"),
+	}
+
+	CurrentCfg.ConfigNetPolicy.NsServiceStrategies = map[string]string{}
+	for ns := range viper.GetStringMap("application.network.service-strategy.namespaces") {
+		CurrentCfg.ConfigNetPolicy.NsServiceStrategies[ns] = viper.GetString("application.network.service-strategy.namespaces." + ns)
 	}
 
+	CurrentCfg.ConfigNetPolicy.RuleTypeThresholds = getConfigRuleTypeThresholds("application.network.rule-threshold")
+
 	CurrentCfg.ConfigNetPolicy.NsFilter, CurrentCfg.ConfigNetPolicy.NsNotFilter = getConfigNsFilter("application.network.namespace-filter")
 
 	// load system policy discovery
@@ -174,11 +226,48 @@ func LoadConfigFromFile() {
 
 		ProcessFromSource: true,
 		FileFromSource:    true,
+
+		PolicyNameTemplate: viper.GetString("application.system.policy-name-template"),
+		MigratePolicyNames: viper.GetBool("application.system.migrate-policy-names"),
 	}
 
 	CurrentCfg.ConfigSysPolicy.NsFilter, CurrentCfg.ConfigSysPolicy.NsNotFilter = getConfigNsFilter("application.system.namespace-filter")
 	CurrentCfg.ConfigSysPolicy.FromSourceFilter = viper.GetStringSlice("application.system.fromsource-filter")
 
+	CurrentCfg.ConfigSysPolicy.PathAggregation = viper.GetString("application.system.path-aggregation.mode")
+	CurrentCfg.ConfigSysPolicy.PatternThreshold = viper.GetInt("application.system.path-aggregation.threshold")
+	CurrentCfg.ConfigSysPolicy.PatternStrictness = viper.GetString("application.system.path-aggregation.strictness")
+	CurrentCfg.ConfigSysPolicy.NsPatternThresholds = map[string]int{}
+	for ns := range viper.GetStringMap("application.system.path-aggregation.namespace-threshold") {
+		CurrentCfg.ConfigSysPolicy.NsPatternThresholds[ns] = viper.GetInt("application.system.path-aggregation.namespace-threshold." + ns)
+	}
+
+	CurrentCfg.ConfigSysPolicy.HostPolicyDiscovery = viper.GetBool("application.system.host-policy.enable")
+	CurrentCfg.ConfigSysPolicy.HostPolicyNodeLabels = viper.GetStringSlice("application.system.host-policy.node-labels")
+
+	CurrentCfg.ConfigSysPolicy.StaleRuleWindow = viper.GetString("application.system.stale-rule.window")
+	CurrentCfg.ConfigSysPolicy.StaleRuleAction = viper.GetString("application.system.stale-rule.action")
+
+	CurrentCfg.ConfigSysPolicy.ExecSessionMode = viper.GetString("application.system.exec-session.mode")
+	CurrentCfg.ConfigSysPolicy.NsExecSessionModes = map[string]string{}
+	for ns := range viper.GetStringMap("application.system.exec-session.namespace-mode") {
+		CurrentCfg.ConfigSysPolicy.NsExecSessionModes[ns] = viper.GetString("application.system.exec-session.namespace-mode." + ns)
+	}
+
+	CurrentCfg.ConfigSysPolicy.RuleMinCount = viper.GetInt("application.system.rule-threshold.min-count")
+	CurrentCfg.ConfigSysPolicy.RuleMinPods = viper.GetInt("application.system.rule-threshold.min-pods")
+	CurrentCfg.ConfigSysPolicy.RuleMinSpan = viper.GetString("application.system.rule-threshold.min-span")
+	CurrentCfg.ConfigSysPolicy.RuleTypeThresholds = getConfigRuleTypeThresholds("application.system.rule-threshold")
+	CurrentCfg.ConfigSysPolicy.RuleStagingTTL = viper.GetString("application.system.rule-threshold.staging-ttl")
+
+	CurrentCfg.ConfigSysPolicy.GapAnalysis = viper.GetBool("application.system.gap-analysis")
+
+	CurrentCfg.ConfigSysPolicy.ImportPoliciesFrom = viper.GetString("application.system.import-policies.from")
+	CurrentCfg.ConfigSysPolicy.ImportPoliciesDir = viper.GetString("application.system.import-policies.dir")
+
+	CurrentCfg.ConfigSysPolicy.ContainerScoped = viper.GetBool("application.system.container-scoped-policy")
+	CurrentCfg.ConfigSysPolicy.ExcludeContainers = viper.GetStringSlice("application.system.exclude-containers")
+
 	CurrentCfg.ConfigAdmissionControllerPolicy.NsFilter, CurrentCfg.ConfigAdmissionControllerPolicy.NsNotFilter = getConfigNsFilter("application.admission-controller.namespace-filter")
 
 	// load cluster resource info
@@ -187,6 +276,14 @@ func LoadConfigFromFile() {
 		ClusterMgmtURL:  viper.GetString("application.cluster.cluster-mgmt-url"),
 	}
 
+	// load the learning states of the workloads
+	CurrentCfg.ConfigWorkloadState = types.ConfigWorkloadState{
+		Window:            viper.GetString("application.workload-state.window"),
+		StableAfter:       viper.GetString("application.workload-state.stable-after"),
+		EnforceReadyAfter: viper.GetString("application.workload-state.enforce-ready-after"),
+		AnomalyMode:       viper.GetBool("application.workload-state.anomaly-mode"),
+	}
+
 	CurrentCfg.ConfigObservability = types.ConfigObservability{
 		Enable:              viper.GetBool("observability.enable"),
 		CronJobTimeInterval: "@every " + viper.GetString("observability.cron-job-time-interval"),
@@ -217,6 +314,13 @@ func LoadConfigFromFile() {
 		RecommendAdmissionControllerPolicy: viper.GetBool("recommend.admission-controller-policy"),
 	}
 
+	// drift detection configurations
+	CurrentCfg.ConfigDriftDetection = types.ConfigDriftDetection{
+		Enable:              viper.GetBool("drift-detection.enable"),
+		CronJobTimeInterval: "@every " + viper.GetString("drift-detection.cron-job-time-interval"),
+		MetricsPort:         viper.GetString("drift-detection.metrics-port"),
+	}
+
 	// load database
 	CurrentCfg.ConfigDB = LoadConfigDB()
 
@@ -317,6 +421,10 @@ func GetCfgCIDRBits() int {
 	return CurrentCfg.ConfigNetPolicy.NetPolicyCIDRBits
 }
 
+func GetCfgCIDRBitsV6() int {
+	return CurrentCfg.ConfigNetPolicy.NetPolicyCIDRBitsV6
+}
+
 func GetCfgNetworkPolicyTypes() int {
 	return CurrentCfg.ConfigNetPolicy.NetPolicyTypes
 }
@@ -353,6 +461,126 @@ func GetCfgNetworkSkipCertVerification() bool {
 	return CurrentCfg.ConfigNetPolicy.NetSkipCertVerification
 }
 
+func GetCfgNetworkPolicyNameTemplate() string {
+	return CurrentCfg.ConfigNetPolicy.PolicyNameTemplate
+}
+
+func GetCfgNetworkMigratePolicyNames() bool {
+	return CurrentCfg.ConfigNetPolicy.MigratePolicyNames
+}
+
+func GetCfgNetworkBaselinePolicy() bool {
+	return CurrentCfg.ConfigNetPolicy.BaselinePolicy
+}
+
+func GetCfgNetworkBaselineDNSAllow() bool {
+	return CurrentCfg.ConfigNetPolicy.BaselineDNSAllow
+}
+
+func GetCfgNetworkStaleRuleWindow() string {
+	return CurrentCfg.ConfigNetPolicy.StaleRuleWindow
+}
+
+func GetCfgNetworkStaleRuleAction() string {
+	return CurrentCfg.ConfigNetPolicy.StaleRuleAction
+}
+
+func GetCfgNetworkRuleMinCount() int {
+	return CurrentCfg.ConfigNetPolicy.RuleMinCount
+}
+
+func GetCfgNetworkRuleMinPods() int {
+	return CurrentCfg.ConfigNetPolicy.RuleMinPods
+}
+
+func GetCfgNetworkRuleMinSpan() string {
+	return CurrentCfg.ConfigNetPolicy.RuleMinSpan
+}
+
+func GetCfgNetworkRuleTypeThresholds() map[string]types.RuleThresholdConfig {
+	return CurrentCfg.ConfigNetPolicy.RuleTypeThresholds
+}
+
+func GetCfgNetworkRuleStagingTTL() string {
+	return CurrentCfg.ConfigNetPolicy.RuleStagingTTL
+}
+
+func GetCfgNetworkGapAnalysis() bool {
+	return CurrentCfg.ConfigNetPolicy.GapAnalysis
+}
+
+func GetCfgNetworkImportPoliciesFrom() string {
+	return CurrentCfg.ConfigNetPolicy.ImportPoliciesFrom
+}
+
+func GetCfgNetworkImportPoliciesDir() string {
+	return CurrentCfg.ConfigNetPolicy.ImportPoliciesDir
+}
+
+func GetCfgNetworkFQDNThreshold() int {
+	return CurrentCfg.ConfigNetPolicy.FQDNThreshold
+}
+
+func GetCfgNetworkFQDNProtectedSuffixes() []string {
+	return CurrentCfg.ConfigNetPolicy.FQDNProtectedSuffixes
+}
+
+func GetCfgNetworkHTTPHost() bool {
+	return CurrentCfg.ConfigNetPolicy.HTTPHost
+}
+
+func GetCfgNetworkHTTPHeaderAllowlist() []string {
+	return CurrentCfg.ConfigNetPolicy.HTTPHeaderAllowlist
+}
+
+func GetCfgNetworkPortRangeDensity() float64 {
+	return CurrentCfg.ConfigNetPolicy.PortRangeDensity
+}
+
+func GetCfgNetworkPortRangeMinPorts() int {
+	return CurrentCfg.ConfigNetPolicy.PortRangeMinPorts
+}
+
+func GetCfgNetworkPortRangeWellKnownMax() int {
+	return CurrentCfg.ConfigNetPolicy.PortRangeWellKnownMax
+}
+
+func GetCfgNetworkIngressWorldFrom() string {
+	return CurrentCfg.ConfigNetPolicy.IngressWorldFrom
+}
+
+func GetCfgNetworkIngressWorldCIDRBits() (int, int) {
+	return CurrentCfg.ConfigNetPolicy.IngressWorldCIDRBits, CurrentCfg.ConfigNetPolicy.IngressWorldCIDRBitsV6
+}
+
+func GetCfgNetworkIngressControllerLabels() []string {
+	return CurrentCfg.ConfigNetPolicy.IngressControllerLabels
+}
+
+func GetCfgNetworkClusterwideMinNamespaces() int {
+	return CurrentCfg.ConfigNetPolicy.ClusterwideMinNamespaces
+}
+
+func GetCfgNetworkClusterwideLabelKeys() []string {
+	return CurrentCfg.ConfigNetPolicy.ClusterwideLabelKeys
+}
+
+func GetCfgNetworkHostPolicyDiscovery() bool {
+	return CurrentCfg.ConfigNetPolicy.HostPolicyDiscovery
+}
+
+func GetCfgNetworkHostPolicyNodeLabels() []string {
+	return CurrentCfg.ConfigNetPolicy.HostPolicyNodeLabels
+}
+
+// GetCfgNetworkServiceStrategy returns how the egress to the pods of the namespace selects the dst
+func GetCfgNetworkServiceStrategy(namespace string) string {
+	if strategy, ok := CurrentCfg.ConfigNetPolicy.NsServiceStrategies[namespace]; ok {
+		return strategy
+	}
+	return CurrentCfg.ConfigNetPolicy.ServiceStrategy
+}
+
 // ============================ //
 // == Get System Config Info == //
 // ============================ //
@@ -415,6 +643,95 @@ func GetCfgSystemFileFromSource() bool {
 	return CurrentCfg.ConfigSysPolicy.FileFromSource
 }
 
+func GetCfgSystemPathAggregation() string {
+	return CurrentCfg.ConfigSysPolicy.PathAggregation
+}
+
+func GetCfgSystemPolicyNameTemplate() string {
+	return CurrentCfg.ConfigSysPolicy.PolicyNameTemplate
+}
+
+func GetCfgSystemMigratePolicyNames() bool {
+	return CurrentCfg.ConfigSysPolicy.MigratePolicyNames
+}
+
+func GetCfgSystemContainerScoped() bool {
+	return CurrentCfg.ConfigSysPolicy.ContainerScoped
+}
+
+func GetCfgSystemExcludeContainers() []string {
+	return CurrentCfg.ConfigSysPolicy.ExcludeContainers
+}
+
+func GetCfgSystemStaleRuleWindow() string {
+	return CurrentCfg.ConfigSysPolicy.StaleRuleWindow
+}
+
+func GetCfgSystemStaleRuleAction() string {
+	return CurrentCfg.ConfigSysPolicy.StaleRuleAction
+}
+
+func GetCfgSystemRuleMinCount() int {
+	return CurrentCfg.ConfigSysPolicy.RuleMinCount
+}
+
+func GetCfgSystemRuleMinPods() int {
+	return CurrentCfg.ConfigSysPolicy.RuleMinPods
+}
+
+func GetCfgSystemRuleMinSpan() string {
+	return CurrentCfg.ConfigSysPolicy.RuleMinSpan
+}
+
+func GetCfgSystemRuleTypeThresholds() map[string]types.RuleThresholdConfig {
+	return CurrentCfg.ConfigSysPolicy.RuleTypeThresholds
+}
+
+func GetCfgSystemRuleStagingTTL() string {
+	return CurrentCfg.ConfigSysPolicy.RuleStagingTTL
+}
+
+func GetCfgSystemGapAnalysis() bool {
+	return CurrentCfg.ConfigSysPolicy.GapAnalysis
+}
+
+func GetCfgSystemImportPoliciesFrom() string {
+	return CurrentCfg.ConfigSysPolicy.ImportPoliciesFrom
+}
+
+func GetCfgSystemImportPoliciesDir() string {
+	return CurrentCfg.ConfigSysPolicy.ImportPoliciesDir
+}
+
+func GetCfgSystemHostPolicyDiscovery() bool {
+	return CurrentCfg.ConfigSysPolicy.HostPolicyDiscovery
+}
+
+func GetCfgSystemHostPolicyNodeLabels() []string {
+	return CurrentCfg.ConfigSysPolicy.HostPolicyNodeLabels
+}
+
+func GetCfgSystemPatternStrictness() string {
+	return CurrentCfg.ConfigSysPolicy.PatternStrictness
+}
+
+// GetCfgSystemPatternThreshold returns the pattern threshold for the namespace,
+// falling back to the default threshold if not configured for the namespace
+func GetCfgSystemPatternThreshold(namespace string) int {
+	if threshold, ok := CurrentCfg.ConfigSysPolicy.NsPatternThresholds[namespace]; ok {
+		return threshold
+	}
+	return CurrentCfg.ConfigSysPolicy.PatternThreshold
+}
+
+// GetCfgSystemExecSessionMode returns how the exec session activity of the namespace is discovered
+func GetCfgSystemExecSessionMode(namespace string) string {
+	if mode, ok := CurrentCfg.ConfigSysPolicy.NsExecSessionModes[namespace]; ok {
+		return mode
+	}
+	return CurrentCfg.ConfigSysPolicy.ExecSessionMode
+}
+
 // ============================= //
 // == Get Cluster Config Info == //
 // ============================= //
@@ -427,6 +744,26 @@ func GetCfgClusterMgmtURL() string {
 	return CurrentCfg.ConfigClusterMgmt.ClusterMgmtURL
 }
 
+// ==================================== //
+// == Get Workload State Config Info == //
+// ==================================== //
+
+func GetCfgWorkloadStateWindow() string {
+	return CurrentCfg.ConfigWorkloadState.Window
+}
+
+func GetCfgWorkloadStateStableAfter() string {
+	return CurrentCfg.ConfigWorkloadState.StableAfter
+}
+
+func GetCfgWorkloadStateEnforceReadyAfter() string {
+	return CurrentCfg.ConfigWorkloadState.EnforceReadyAfter
+}
+
+func GetCfgWorkloadStateAnomalyMode() bool {
+	return CurrentCfg.ConfigWorkloadState.AnomalyMode
+}
+
 // ============================ //
 // == Get Observability Info == //
 // ============================ //
@@ -472,6 +809,33 @@ func getConfigNsFilter(config string) ([]string, []string) {
 	return ns, notNs
 }
 
+// getConfigRuleTypeThresholds returns the thresholds of the rule types under
+// <config>.rule-type, the thresholds not set fall back to the default ones
+func getConfigRuleTypeThresholds(config string) map[string]types.RuleThresholdConfig {
+	thresholds := map[string]types.RuleThresholdConfig{}
+	for ruleType := range viper.GetStringMap(config + ".rule-type") {
+		threshold := types.RuleThresholdConfig{
+			MinCount: viper.GetInt(config + ".min-count"),
+			MinPods:  viper.GetInt(config + ".min-pods"),
+			MinSpan:  viper.GetString(config + ".min-span"),
+		}
+
+		key := config + ".rule-type." + ruleType
+		if viper.IsSet(key + ".min-count") {
+			threshold.MinCount = viper.GetInt(key + ".min-count")
+		}
+		if viper.IsSet(key + ".min-pods") {
+			threshold.MinPods = viper.GetInt(key + ".min-pods")
+		}
+		if viper.IsSet(key + ".min-span") {
+			threshold.MinSpan = viper.GetString(key + ".min-span")
+		}
+
+		thresholds[ruleType] = threshold
+	}
+	return thresholds
+}
+
 // ========================== //
 // == Get Publisher Config == //
 // ========================== //
@@ -523,3 +887,19 @@ func GetCfgRecommendHostPolicy() bool {
 func GetCfgRecommendAdmissionControllerPolicy() bool {
 	return CurrentCfg.ConfigRecommendPolicy.RecommendAdmissionControllerPolicy
 }
+
+// ================================== //
+// == Get Drift Detection Config Info == //
+// ================================== //
+
+func GetCfgDriftDetectionEnable() bool {
+	return CurrentCfg.ConfigDriftDetection.Enable
+}
+
+func GetCfgDriftDetectionCronJobTime() string {
+	return CurrentCfg.ConfigDriftDetection.CronJobTimeInterval
+}
+
+func GetCfgDriftDetectionMetricsPort() string {
+	return CurrentCfg.ConfigDriftDetection.MetricsPort
+}
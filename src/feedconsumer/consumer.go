@@ -410,16 +410,22 @@ func (cfc *KnoxFeedConsumer) processSystemLogMessage(message []byte) error {
 		if len(cfc.syslogEvents) > 0 {
 			for _, syslog := range cfc.syslogEvents {
 				log := pb.Alert{
-					ClusterName:   syslog.ClusterName,
-					HostName:      syslog.HostName,
-					NamespaceName: syslog.NamespaceName,
-					ContainerName: syslog.ContainerName,
-					PodName:       syslog.PodName,
-					Source:        syslog.Source,
-					Operation:     syslog.Operation,
-					Resource:      syslog.Resource,
-					Data:          syslog.Data,
-					Result:        syslog.Result,
+					ClusterName:       syslog.ClusterName,
+					HostName:          syslog.HostName,
+					NamespaceName:     syslog.NamespaceName,
+					ContainerName:     syslog.ContainerName,
+					PodName:           syslog.PodName,
+					HostPPID:          int32(syslog.HostPPID),
+					HostPID:           int32(syslog.HostPID),
+					PPID:              int32(syslog.PPID),
+					PID:               int32(syslog.PID),
+					ParentProcessName: syslog.ParentProcessName,
+					ProcessName:       syslog.ProcessName,
+					Source:            syslog.Source,
+					Operation:         syslog.Operation,
+					Resource:          syslog.Resource,
+					Data:              syslog.Data,
+					Result:            syslog.Result,
 				}
 
 				knoxLog, err := plugin.ConvertKubeArmorLogToKnoxSystemLog(&log)
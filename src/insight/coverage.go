@@ -0,0 +1,610 @@
+package insight
+
+import (
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/cluster"
+	"github.com/accuknox/auto-policy-discovery/src/common"
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	network "github.com/accuknox/auto-policy-discovery/src/networkpolicy"
+	"github.com/accuknox/auto-policy-discovery/src/plugin"
+	ipb "github.com/accuknox/auto-policy-discovery/src/protobuf/v1/insight"
+	sys "github.com/accuknox/auto-policy-discovery/src/systempolicy"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// defaultCoverageWindow is the window of the observed flows/events if not requested
+const defaultCoverageWindow = 24 * time.Hour
+
+// coveragePolicies are the network and system policies of a source, e.g. discovered
+type coveragePolicies struct {
+	network []types.KnoxNetworkPolicy
+	system  []types.KnoxSystemPolicy
+}
+
+// coverageWorkload is a workload along with its coverage
+type coverageWorkload struct {
+	labels   map[string]string
+	coverage *types.WorkloadCoverage
+}
+
+func labelsToMap(labels []string) map[string]string {
+	res := map[string]string{}
+	for _, label := range labels {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) == 2 {
+			res[kv[0]] = kv[1]
+		}
+	}
+	return res
+}
+
+// selectsLabels checks if the selector is included in the labels, the container
+// scoped selector is regarded as the selector of the pod
+func selectsLabels(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if k == types.KubeArmorContainerNameLabel {
+			continue
+		}
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ============================ //
+// == Policy Aspect Coverage == //
+// ============================ //
+
+func isDefaultDenyPolicy(policy types.KnoxNetworkPolicy) bool {
+	return policy.Metadata["rule"] == types.NetworkRuleDefaultDeny
+}
+
+func networkPoliciesOf(policies []types.KnoxNetworkPolicy, namespace string, labels map[string]string) []types.KnoxNetworkPolicy {
+	results := []types.KnoxNetworkPolicy{}
+	for _, policy := range policies {
+		if policy.Kind == types.KindKnoxHostNetworkPolicy {
+			continue
+		}
+		// the clusterwide policies select the endpoints in all the namespaces
+		if policy.Kind != types.KindKnoxClusterwideNetworkPolicy && policy.Metadata["namespace"] != namespace {
+			continue
+		}
+		if selectsLabels(policy.Spec.Selector.MatchLabels, labels) {
+			results = append(results, policy)
+		}
+	}
+	return results
+}
+
+func systemPoliciesOf(policies []types.KnoxSystemPolicy, namespace string, labels map[string]string) []types.KnoxSystemPolicy {
+	results := []types.KnoxSystemPolicy{}
+	for _, policy := range policies {
+		if policy.Metadata["namespace"] != namespace {
+			continue
+		}
+		if selectsLabels(policy.Spec.Selector.MatchLabels, labels) {
+			results = append(results, policy)
+		}
+	}
+	return results
+}
+
+func hasNetworkDirection(policies []types.KnoxNetworkPolicy, direction string) bool {
+	for _, policy := range policies {
+		if isDefaultDenyPolicy(policy) {
+			return true
+		}
+		if direction == "ingress" && len(policy.Spec.Ingress) > 0 {
+			return true
+		}
+		if direction == "egress" && len(policy.Spec.Egress) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyKnoxSys(sysRule types.KnoxSys) bool {
+	return len(sysRule.MatchPaths) == 0 && len(sysRule.MatchDirectories) == 0 && len(sysRule.MatchPatterns) == 0
+}
+
+func hasSystemOperation(policies []types.KnoxSystemPolicy, operation string) bool {
+	for _, policy := range policies {
+		if operation == "Process" && !isEmptyKnoxSys(policy.Spec.Process) {
+			return true
+		}
+		if operation == "File" && !isEmptyKnoxSys(policy.Spec.File) {
+			return true
+		}
+	}
+	return false
+}
+
+func coverageStatus(applied, discovered bool) string {
+	if applied {
+		return types.CoverageApplied
+	} else if discovered {
+		return types.CoverageDiscovered
+	}
+	return types.CoverageNone
+}
+
+// ======================= //
+// == Observed Coverage == //
+// ======================= //
+
+func portCovered(toPorts []types.SpecPort, port, protocol string) bool {
+	if len(toPorts) == 0 {
+		return true
+	}
+
+	for _, toPort := range toPorts {
+		if (toPort.Port == "" || toPort.Port == port) &&
+			(toPort.Protocol == "" || strings.EqualFold(toPort.Protocol, protocol)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// labelsMatched checks the label-based rule with the peer of the flow
+func labelsMatched(matchLabels map[string]string, peerNamespace string, peerLabels map[string]string) bool {
+	for k, v := range matchLabels {
+		if k == types.CiliumNamespaceLabel {
+			if v != peerNamespace {
+				return false
+			}
+		} else if peerLabels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// cidrsMatched checks if the ip of the peer is in the cidrs but the exceptions
+func cidrsMatched(specCIDRs []types.SpecCIDR, peerIP string) bool {
+	ip := net.ParseIP(peerIP)
+	if ip == nil {
+		return false
+	}
+
+	contains := func(cidrs []string) bool {
+		for _, cidr := range cidrs {
+			if _, ipNet, err := net.ParseCIDR(cidr); err == nil && ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, specCIDR := range specCIDRs {
+		if contains(specCIDR.CIDRs) && !contains(specCIDR.Except) {
+			return true
+		}
+	}
+	return false
+}
+
+// entitiesMatched checks the entities with the reserved labels of the peer
+func entitiesMatched(entities []string, peerLabels string) bool {
+	for _, entity := range entities {
+		switch entity {
+		case "all":
+			return true
+		case "cluster":
+			if !strings.Contains(peerLabels, plugin.CiliumReserved+"world") {
+				return true
+			}
+		default:
+			if libs.ContainsElement(strings.Split(peerLabels, ","), plugin.CiliumReserved+entity) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fqdnsMatched checks if the ip of the peer is resolved from the names
+func fqdnsMatched(fqdns []types.SpecFQDN, peerIP string, domainToIPs map[string][]string) bool {
+	for domain, ips := range domainToIPs {
+		if !libs.ContainsElement(ips, peerIP) {
+			continue
+		}
+
+		for _, fqdn := range fqdns {
+			if libs.ContainsElement(fqdn.MatchNames, domain) {
+				return true
+			}
+			for _, pattern := range fqdn.MatchPatterns {
+				if common.MatchFQDNPattern(pattern, domain) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// servicesMatched checks the services with the destination service of the flow
+func servicesMatched(services []types.SpecService, flow types.CiliumLog) bool {
+	for _, service := range services {
+		if service.ServiceName == flow.DestinationServiceName && service.Namespace == flow.DestinationServiceNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+// egressPeerMatched checks the peer of the egress rule with the destination of the flow
+func egressPeerMatched(egress types.Egress, flow types.CiliumLog, domainToIPs map[string][]string) bool {
+	if len(egress.MatchLabels) > 0 {
+		return labelsMatched(egress.MatchLabels, flow.DestinationNamespace, labelsToMap(strings.Split(flow.DestinationLabels, ",")))
+	} else if len(egress.ToCIDRs) > 0 {
+		return cidrsMatched(egress.ToCIDRs, flow.IpDestination)
+	} else if len(egress.ToEntities) > 0 {
+		return entitiesMatched(egress.ToEntities, flow.DestinationLabels)
+	} else if len(egress.ToFQDNs) > 0 {
+		return fqdnsMatched(egress.ToFQDNs, flow.IpDestination, domainToIPs)
+	} else if len(egress.ToServices) > 0 {
+		return servicesMatched(egress.ToServices, flow)
+	}
+
+	// the rule of the ports only
+	return true
+}
+
+// ingressPeerMatched checks the peer of the ingress rule with the source of the flow
+func ingressPeerMatched(ingress types.Ingress, flow types.CiliumLog) bool {
+	if len(ingress.MatchLabels) > 0 {
+		return labelsMatched(ingress.MatchLabels, flow.SourceNamespace, labelsToMap(strings.Split(flow.SourceLabels, ",")))
+	} else if len(ingress.FromCIDRs) > 0 {
+		return cidrsMatched(ingress.FromCIDRs, flow.IpSource)
+	} else if len(ingress.FromEntities) > 0 {
+		return entitiesMatched(ingress.FromEntities, flow.SourceLabels)
+	}
+
+	// the rule of the ports only
+	return true
+}
+
+func flowCovered(policies []types.KnoxNetworkPolicy, flow types.CiliumLog, domainToIPs map[string][]string) bool {
+	port, protocol := "", ""
+	if flow.L4TCPDestinationPort != 0 {
+		port, protocol = strconv.Itoa(int(flow.L4TCPDestinationPort)), "TCP"
+	} else if flow.L4UDPDestinationPort != 0 {
+		port, protocol = strconv.Itoa(int(flow.L4UDPDestinationPort)), "UDP"
+	}
+
+	for _, policy := range policies {
+		// the default-deny allows nothing
+		if isDefaultDenyPolicy(policy) {
+			continue
+		}
+
+		if flow.TrafficDirection == "EGRESS" {
+			for _, egress := range policy.Spec.Egress {
+				if !egress.Pruned && portCovered(egress.ToPorts, port, protocol) &&
+					egressPeerMatched(egress, flow, domainToIPs) {
+					return true
+				}
+			}
+		} else {
+			for _, ingress := range policy.Spec.Ingress {
+				if !ingress.Pruned && portCovered(ingress.ToPorts, port, protocol) &&
+					ingressPeerMatched(ingress, flow) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func resourceCovered(sysRule types.KnoxSys, resource string) bool {
+	for _, matchPath := range sysRule.MatchPaths {
+		if !matchPath.Pruned && matchPath.Path == resource {
+			return true
+		}
+	}
+	for _, matchDir := range sysRule.MatchDirectories {
+		if !matchDir.Pruned && strings.HasPrefix(resource, matchDir.Dir) {
+			return true
+		}
+	}
+	for _, matchPattern := range sysRule.MatchPatterns {
+		if matched, _ := filepath.Match(matchPattern.Pattern, resource); !matchPattern.Pruned && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func eventCovered(policies []types.KnoxSystemPolicy, event types.KubeArmorLog) bool {
+	// the process resource has the arguments along with the path
+	resource := event.Resource
+	if fields := strings.Fields(resource); event.Operation == "Process" && len(fields) > 0 {
+		resource = fields[0]
+	}
+
+	for _, policy := range policies {
+		if event.Operation == "Process" && resourceCovered(policy.Spec.Process, resource) {
+			return true
+		}
+		if event.Operation == "File" && resourceCovered(policy.Spec.File, resource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ===================== //
+// == Coverage Report == //
+// ===================== //
+
+// getAppliedCoveragePolicies returns the policies applied in the cluster, converted
+// into the knox policies
+func getAppliedCoveragePolicies() (coveragePolicies, error) {
+	res := coveragePolicies{}
+
+	applied, err := cluster.GetAppliedPoliciesFromK8sClient()
+	if err != nil {
+		return res, err
+	}
+
+	for _, policy := range applied {
+		if netPolicies, err := network.ConvertAppliedNetworkPolicy(policy); err == nil {
+			res.network = append(res.network, netPolicies...)
+		}
+		if sysPolicies, err := sys.ConvertAppliedSystemPolicy(policy); err == nil {
+			res.system = append(res.system, sysPolicies...)
+		}
+	}
+
+	return res, nil
+}
+
+// findWorkload returns the workload of the namespace selected by the labels
+func findWorkload(workloads map[string][]coverageWorkload, namespace, labels string) *types.WorkloadCoverage {
+	logLabels := labelsToMap(strings.Split(labels, ","))
+	for _, workload := range workloads[namespace] {
+		if selectsLabels(workload.labels, logLabels) {
+			return workload.coverage
+		}
+	}
+	return nil
+}
+
+// GetCoverageReport analyses whether the workloads are covered by the discovered
+// or the applied policies, and the fraction of the flows/events under some rule
+func GetCoverageReport(clusterName, namespace string, window time.Duration) (types.CoverageReport, error) {
+	if clusterName == "" {
+		clusterName = cfg.GetCfgClusterName()
+	}
+
+	report := types.CoverageReport{
+		ClusterName: clusterName,
+		Window:      int64(window.Seconds()),
+		Namespaces:  []types.NamespaceCoverage{},
+		Workloads:   []types.WorkloadCoverage{},
+	}
+
+	discovered := coveragePolicies{
+		network: libs.GetNetworkPolicies(cfg.GetCfgDB(), clusterName, namespace, "latest", "", ""),
+		system:  libs.GetSystemPolicies(cfg.GetCfgDB(), namespace, "latest"),
+	}
+	applied, err := getAppliedCoveragePolicies()
+	if err != nil {
+		return report, err
+	}
+
+	// the workloads, key: namespace
+	workloads := map[string][]coverageWorkload{}
+	seen := map[string]bool{}
+	for _, pod := range cluster.GetPods(clusterName) {
+		if namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+
+		labels := libs.WorkloadLabels(pod.Labels)
+		if seen[pod.Namespace+"/"+labels] {
+			continue
+		}
+		seen[pod.Namespace+"/"+labels] = true
+
+		workloads[pod.Namespace] = append(workloads[pod.Namespace], coverageWorkload{
+			labels: labelsToMap(pod.Labels),
+			coverage: &types.WorkloadCoverage{
+				ClusterName: clusterName,
+				Namespace:   pod.Namespace,
+				Labels:      labels,
+			},
+		})
+	}
+
+	// step 1: the aspects covered by the policies
+	for ns, nsWorkloads := range workloads {
+		for _, workload := range nsWorkloads {
+			discoveredNet := networkPoliciesOf(discovered.network, ns, workload.labels)
+			appliedNet := networkPoliciesOf(applied.network, ns, workload.labels)
+			discoveredSys := systemPoliciesOf(discovered.system, ns, workload.labels)
+			appliedSys := systemPoliciesOf(applied.system, ns, workload.labels)
+
+			workload.coverage.NetworkIngress = coverageStatus(hasNetworkDirection(appliedNet, "ingress"), hasNetworkDirection(discoveredNet, "ingress"))
+			workload.coverage.NetworkEgress = coverageStatus(hasNetworkDirection(appliedNet, "egress"), hasNetworkDirection(discoveredNet, "egress"))
+			workload.coverage.SystemProcess = coverageStatus(hasSystemOperation(appliedSys, "Process"), hasSystemOperation(discoveredSys, "Process"))
+			workload.coverage.SystemFile = coverageStatus(hasSystemOperation(appliedSys, "File"), hasSystemOperation(discoveredSys, "File"))
+		}
+	}
+
+	since := time.Now().Add(-window).Unix()
+	all := coveragePolicies{
+		network: append(append([]types.KnoxNetworkPolicy{}, discovered.network...), applied.network...),
+		system:  append(append([]types.KnoxSystemPolicy{}, discovered.system...), applied.system...),
+	}
+
+	// step 2: the observed flows under some rule
+	flows, flowTotals, err := libs.GetCiliumLogsSince(cfg.GetCfgDB(), types.CiliumLog{}, namespace, since)
+	if err != nil {
+		return report, err
+	}
+
+	domainToIPs := network.GetDomainToIPs(clusterName)
+	for i, flow := range flows {
+		if flow.IsReply {
+			continue
+		}
+
+		ns, labels := flow.DestinationNamespace, flow.DestinationLabels
+		if flow.TrafficDirection == "EGRESS" {
+			ns, labels = flow.SourceNamespace, flow.SourceLabels
+		}
+
+		workload := findWorkload(workloads, ns, labels)
+		if workload == nil {
+			continue
+		}
+
+		count := int64(flowTotals[i])
+		workload.ObservedFlows += count
+		if flowCovered(networkPoliciesOf(all.network, ns, labelsToMap(strings.Split(labels, ","))), flow, domainToIPs) {
+			workload.CoveredFlows += count
+		}
+	}
+
+	// step 3: the observed events under some rule
+	events, eventTotals, err := libs.GetKubearmorLogsSince(cfg.GetCfgDB(),
+		types.KubeArmorLog{ClusterName: clusterName, NamespaceName: namespace}, since)
+	if err != nil {
+		return report, err
+	}
+
+	for i, event := range events {
+		if event.Operation != "Process" && event.Operation != "File" {
+			continue
+		}
+
+		workload := findWorkload(workloads, event.NamespaceName, event.Labels)
+		if workload == nil {
+			continue
+		}
+
+		count := int64(eventTotals[i])
+		workload.ObservedEvents += count
+		if eventCovered(systemPoliciesOf(all.system, event.NamespaceName, labelsToMap(strings.Split(event.Labels, ","))), event) {
+			workload.CoveredEvents += count
+		}
+	}
+
+	// step 4: summarize per namespace and cluster
+	namespaces := []string{}
+	for ns := range workloads {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, ns := range namespaces {
+		nsCoverage := types.NamespaceCoverage{ClusterName: clusterName, Namespace: ns}
+
+		sort.Slice(workloads[ns], func(i, j int) bool {
+			return workloads[ns][i].coverage.Labels < workloads[ns][j].coverage.Labels
+		})
+
+		for _, workload := range workloads[ns] {
+			wc := *workload.coverage
+			report.Workloads = append(report.Workloads, wc)
+
+			nsCoverage.Workloads++
+			if wc.NetworkIngress != types.CoverageNone && wc.NetworkEgress != types.CoverageNone &&
+				wc.SystemProcess != types.CoverageNone && wc.SystemFile != types.CoverageNone {
+				nsCoverage.CoveredWorkloads++
+			}
+			nsCoverage.CoverageCount.Add(wc.CoverageCount)
+		}
+
+		report.Namespaces = append(report.Namespaces, nsCoverage)
+		report.CoverageCount.Add(nsCoverage.CoverageCount)
+	}
+
+	return report, nil
+}
+
+// ======================= //
+// == Coverage Response == //
+// ======================= //
+
+func convertCoverageCountToPb(count types.CoverageCount) *ipb.CoverageCount {
+	return &ipb.CoverageCount{
+		ObservedFlows:  count.ObservedFlows,
+		CoveredFlows:   count.CoveredFlows,
+		FlowCoverage:   count.FlowCoverage(),
+		ObservedEvents: count.ObservedEvents,
+		CoveredEvents:  count.CoveredEvents,
+		EventCoverage:  count.EventCoverage(),
+	}
+}
+
+// GetCoverage returns the policy coverage report, and exports it to the file if requested
+func GetCoverage(req *ipb.CoverageRequest) (*ipb.CoverageResponse, error) {
+	window := defaultCoverageWindow
+	if req.GetWindow() != "" {
+		d, err := time.ParseDuration(req.GetWindow())
+		if err != nil {
+			return nil, err
+		}
+		window = d
+	}
+
+	report, err := GetCoverageReport(req.GetClusterName(), req.GetNamespace(), window)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GetExport() {
+	case "json":
+		libs.WriteCoverageReportToJsonFile(report)
+	case "csv":
+		libs.WriteCoverageReportToCsvFile(report)
+	}
+
+	resp := &ipb.CoverageResponse{
+		ClusterName: report.ClusterName,
+		Window:      report.Window,
+		Count:       convertCoverageCountToPb(report.CoverageCount),
+	}
+
+	for _, ns := range report.Namespaces {
+		resp.Namespaces = append(resp.Namespaces, &ipb.NamespaceCoverage{
+			ClusterName:      ns.ClusterName,
+			Namespace:        ns.Namespace,
+			Workloads:        int32(ns.Workloads),
+			CoveredWorkloads: int32(ns.CoveredWorkloads),
+			Count:            convertCoverageCountToPb(ns.CoverageCount),
+		})
+	}
+
+	for _, workload := range report.Workloads {
+		resp.Workloads = append(resp.Workloads, &ipb.WorkloadCoverage{
+			ClusterName:    workload.ClusterName,
+			Namespace:      workload.Namespace,
+			Labels:         workload.Labels,
+			NetworkIngress: workload.NetworkIngress,
+			NetworkEgress:  workload.NetworkEgress,
+			SystemProcess:  workload.SystemProcess,
+			SystemFile:     workload.SystemFile,
+			Count:          convertCoverageCountToPb(workload.CoverageCount),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package insight
+
+import (
+	"testing"
+
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowCovered(t *testing.T) {
+	policy := types.KnoxNetworkPolicy{
+		Metadata: map[string]string{"namespace": "default", "type": "egress"},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "web"}},
+			Egress: []types.Egress{
+				{
+					MatchLabels: map[string]string{"app": "db", types.CiliumNamespaceLabel: "default"},
+					ToPorts:     []types.SpecPort{{Port: "3306", Protocol: "TCP"}},
+				},
+			},
+		},
+	}
+
+	flow := types.CiliumLog{
+		TrafficDirection:     "EGRESS",
+		SourceNamespace:      "default",
+		SourceLabels:         "app=web",
+		DestinationNamespace: "default",
+		DestinationLabels:    "app=db",
+		L4TCPDestinationPort: 3306,
+	}
+	assert.True(t, flowCovered([]types.KnoxNetworkPolicy{policy}, flow, nil))
+
+	flow.L4TCPDestinationPort = 80
+	assert.False(t, flowCovered([]types.KnoxNetworkPolicy{policy}, flow, nil))
+
+	flow.L4TCPDestinationPort = 3306
+	flow.DestinationNamespace = "prod"
+	assert.False(t, flowCovered([]types.KnoxNetworkPolicy{policy}, flow, nil))
+
+	policy.Metadata["rule"] = types.NetworkRuleDefaultDeny
+	assert.True(t, hasNetworkDirection([]types.KnoxNetworkPolicy{policy}, "ingress"))
+}
+
+func TestFlowCoveredPeers(t *testing.T) {
+	policy := types.KnoxNetworkPolicy{
+		Metadata: map[string]string{"namespace": "default", "type": "egress"},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "web"}},
+			Egress: []types.Egress{
+				{ToCIDRs: []types.SpecCIDR{{CIDRs: []string{"10.0.0.0/8"}, Except: []string{"10.1.0.0/16"}}}},
+				{ToEntities: []string{"kube-apiserver"}},
+				{ToFQDNs: []types.SpecFQDN{{MatchPatterns: []string{"*.example.com"}}}},
+				{ToServices: []types.SpecService{{ServiceName: "db", Namespace: "prod"}}},
+			},
+		},
+	}
+	policies := []types.KnoxNetworkPolicy{policy}
+	domainToIPs := map[string][]string{"api.example.com": {"1.2.3.4"}}
+
+	flow := types.CiliumLog{TrafficDirection: "EGRESS", SourceNamespace: "default", SourceLabels: "app=web"}
+
+	flow.IpDestination = "10.2.0.1"
+	assert.True(t, flowCovered(policies, flow, domainToIPs))
+	flow.IpDestination = "10.1.0.1"
+	assert.False(t, flowCovered(policies, flow, domainToIPs))
+
+	flow.DestinationLabels = "reserved:kube-apiserver"
+	assert.True(t, flowCovered(policies, flow, domainToIPs))
+
+	flow.DestinationLabels = "reserved:world"
+	flow.IpDestination = "1.2.3.4"
+	assert.True(t, flowCovered(policies, flow, domainToIPs))
+	flow.IpDestination = "5.6.7.8"
+	assert.False(t, flowCovered(policies, flow, domainToIPs))
+
+	// the wildcard matches a single label
+	domainToIPs["a.b.example.com"] = []string{"5.6.7.8"}
+	assert.False(t, flowCovered(policies, flow, domainToIPs))
+
+	flow.DestinationServiceName, flow.DestinationServiceNamespace = "db", "prod"
+	assert.True(t, flowCovered(policies, flow, domainToIPs))
+}
+
+func TestEventCovered(t *testing.T) {
+	policy := types.KnoxSystemPolicy{
+		Spec: types.KnoxSystemSpec{
+			Process: types.KnoxSys{MatchPaths: []types.KnoxMatchPaths{{Path: "/usr/bin/curl"}}},
+			File:    types.KnoxSys{MatchDirectories: []types.KnoxMatchDirectories{{Dir: "/etc/"}}},
+		},
+	}
+	policies := []types.KnoxSystemPolicy{policy}
+
+	assert.True(t, eventCovered(policies, types.KubeArmorLog{Operation: "Process", Resource: "/usr/bin/curl -s example.com"}))
+	assert.False(t, eventCovered(policies, types.KubeArmorLog{Operation: "Process", Resource: "/bin/sh"}))
+	assert.True(t, eventCovered(policies, types.KubeArmorLog{Operation: "File", Resource: "/etc/passwd"}))
+	assert.False(t, eventCovered(policies, types.KubeArmorLog{Operation: "File", Resource: "/var/log/app.log"}))
+}
+
+func TestCoverageStatus(t *testing.T) {
+	assert.Equal(t, types.CoverageApplied, coverageStatus(true, true))
+	assert.Equal(t, types.CoverageDiscovered, coverageStatus(false, true))
+	assert.Equal(t, types.CoverageNone, coverageStatus(false, false))
+}
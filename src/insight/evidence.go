@@ -0,0 +1,121 @@
+package insight
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/clarketm/json"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	network "github.com/accuknox/auto-policy-discovery/src/networkpolicy"
+	ipb "github.com/accuknox/auto-policy-discovery/src/protobuf/v1/insight"
+	sys "github.com/accuknox/auto-policy-discovery/src/systempolicy"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// policyRule is a rule of the policy along with its statistics and evidence
+type policyRule struct {
+	rule  interface{}
+	stats types.RuleStats
+}
+
+// networkPolicyRules returns the egress rules followed by the ingress rules
+func networkPolicyRules(policy types.KnoxNetworkPolicy) []policyRule {
+	rules := []policyRule{}
+
+	for _, egress := range policy.Spec.Egress {
+		stats := egress.RuleStats
+		egress.RuleStats = types.RuleStats{}
+		rules = append(rules, policyRule{rule: egress, stats: stats})
+	}
+	for _, ingress := range policy.Spec.Ingress {
+		stats := ingress.RuleStats
+		ingress.RuleStats = types.RuleStats{}
+		rules = append(rules, policyRule{rule: ingress, stats: stats})
+	}
+
+	return rules
+}
+
+func knoxSysRules(sysRule types.KnoxSys) []policyRule {
+	rules := []policyRule{}
+
+	for _, mp := range sysRule.MatchPaths {
+		stats := mp.RuleStats
+		mp.RuleStats = types.RuleStats{}
+		rules = append(rules, policyRule{rule: mp, stats: stats})
+	}
+	for _, md := range sysRule.MatchDirectories {
+		stats := md.RuleStats
+		md.RuleStats = types.RuleStats{}
+		rules = append(rules, policyRule{rule: md, stats: stats})
+	}
+	for _, mp := range sysRule.MatchPatterns {
+		stats := mp.RuleStats
+		mp.RuleStats = types.RuleStats{}
+		rules = append(rules, policyRule{rule: mp, stats: stats})
+	}
+
+	return rules
+}
+
+// systemPolicyRules returns the process rules followed by the file rules
+func systemPolicyRules(policy types.KnoxSystemPolicy) []policyRule {
+	return append(knoxSysRules(policy.Spec.Process), knoxSysRules(policy.Spec.File)...)
+}
+
+func findNetworkPolicy(policyName string) (types.KnoxNetworkPolicy, bool) {
+	return libs.GetNetworkPolicyByName(network.CfgDB, policyName, "latest")
+}
+
+func convertRuleEvidenceToPb(evidence []types.RuleEvidence) []*ipb.Evidence {
+	pbEvidence := []*ipb.Evidence{}
+
+	for _, e := range evidence {
+		pbEvidence = append(pbEvidence, &ipb.Evidence{
+			FlowId:    int64(e.FlowID),
+			Timestamp: e.Timestamp,
+			Namespace: e.Namespace,
+			PodName:   e.PodName,
+			Fields:    e.Fields,
+		})
+	}
+
+	return pbEvidence
+}
+
+// GetRuleEvidence returns the rule of the policy, and the samples of the logs
+// which produced the rule
+func GetRuleEvidence(policyName string, ruleIndex int) (*ipb.EvidenceResponse, error) {
+	var rules []policyRule
+	var policyType string
+
+	if policy, ok := findNetworkPolicy(policyName); ok {
+		rules = networkPolicyRules(policy)
+		policyType = types.PolicyTypeNetwork
+	} else if policy, ok := sys.GetSysPolicyByName(policyName); ok {
+		rules = systemPolicyRules(policy)
+		policyType = types.PolicyTypeSystem
+	} else {
+		return nil, errors.New("policy not found: " + policyName)
+	}
+
+	if ruleIndex < 0 || ruleIndex >= len(rules) {
+		return nil, fmt.Errorf("rule index %d out of range, policy %s has %d rules", ruleIndex, policyName, len(rules))
+	}
+
+	rule := rules[ruleIndex]
+	ruleBytes, err := json.Marshal(rule.rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ipb.EvidenceResponse{
+		PolicyName: policyName,
+		PolicyType: policyType,
+		RuleIndex:  int32(ruleIndex),
+		Rule:       string(ruleBytes),
+		RuleStats:  convertRuleStatsToPb("", rule.stats),
+		Evidence:   convertRuleEvidenceToPb(rule.stats.Evidence),
+	}, nil
+}
@@ -74,9 +74,17 @@ func GetInsightData(req types.InsightRequest) (ipb.Response, error) {
 
 	if req.Source == "system" {
 		resp, err := GetSystemInsightData(req)
+		if err != nil || req.Request != "observe" {
+			return resp, err
+		}
+		err = addWorkloadStates(&resp, req)
 		return resp, err
 	} else if req.Source == "network" {
 		resp, err := GetNetworkInsightData(req)
+		if err != nil || req.Request != "observe" {
+			return resp, err
+		}
+		err = addWorkloadStates(&resp, req)
 		return resp, err
 	} else if req.Source == "all" {
 		resp, err := getAllInsightData(req)
@@ -75,6 +75,43 @@ func aggregateNetInsightData(networkData []ipb.NetworkInsightData) []ipb.Network
 	return net
 }
 
+func convertRuleStatsToPb(rule string, stats types.RuleStats) *ipb.RuleStats {
+	return &ipb.RuleStats{
+		Rule:      rule,
+		FirstSeen: stats.FirstSeen,
+		LastSeen:  stats.LastSeen,
+		HitCount:  stats.HitCount,
+		Stale:     stats.Stale,
+		Pruned:    stats.Pruned,
+	}
+}
+
+// convertGRPCRulesToPb returns the gRPC service/method pairs of the HTTP rules
+func convertGRPCRulesToPb(httpRules []types.SpecHTTP) []*ipb.SpecGRPC {
+	pbGRPCs := []*ipb.SpecGRPC{}
+	for _, http := range httpRules {
+		if !http.GRPC {
+			continue
+		}
+		service, method := libs.SplitGRPCPath(http.Path)
+		pbGRPCs = append(pbGRPCs, &ipb.SpecGRPC{Service: service, Method: method})
+	}
+	return pbGRPCs
+}
+
+func convertKafkaRulesToPb(kafkaRules []types.SpecKafka) []*ipb.SpecKafka {
+	pbKafkas := []*ipb.SpecKafka{}
+	for _, kafka := range kafkaRules {
+		pbKafkas = append(pbKafkas, &ipb.SpecKafka{
+			Role:     kafka.Role,
+			APIKey:   kafka.APIKey,
+			ClientID: kafka.ClientID,
+			Topic:    kafka.Topic,
+		})
+	}
+	return pbKafkas
+}
+
 func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 
 	pbEgresses := []*ipb.Egress{}
@@ -100,6 +137,7 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 			pbToPort := ipb.SpecPort{}
 			pbToPort.Port = toPort.Port
 			pbToPort.Protocol = toPort.Protocol
+			pbToPort.EndPort = int32(toPort.EndPort)
 			pbToPorts = append(pbToPorts, &pbToPort)
 		}
 
@@ -122,6 +160,7 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 		for _, toFQDN := range egress.ToFQDNs {
 			pbToFQDN := ipb.SpecFQDN{}
 			pbToFQDN.MatchNames = append(pbToFQDN.MatchNames, toFQDN.MatchNames...)
+			pbToFQDN.MatchPatterns = append(pbToFQDN.MatchPatterns, toFQDN.MatchPatterns...)
 			pbToFQDNs = append(pbToFQDNs, &pbToFQDN)
 		}
 
@@ -130,6 +169,8 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 			pbToHttp.Path = toHTTP.Path
 			pbToHttp.Method = toHTTP.Method
 			pbToHttp.Aggregated = toHTTP.Aggregated
+			pbToHttp.Host = toHTTP.Host
+			pbToHttp.Headers = append(pbToHttp.Headers, toHTTP.Headers...)
 			pbToHTTPs = append(pbToHTTPs, &pbToHttp)
 		}
 
@@ -138,6 +179,9 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 		pbEgress.ToServices = pbToServices
 		pbEgress.ToFQDNs = pbToFQDNs
 		pbEgress.ToHTTPs = pbToHTTPs
+		pbEgress.ToGRPCs = convertGRPCRulesToPb(egress.ToHTTPs)
+		pbEgress.ToKafkas = convertKafkaRulesToPb(egress.ToKafkas)
+		pbEgress.RuleStats = convertRuleStatsToPb("", egress.RuleStats)
 		pbEgresses = append(pbEgresses, &pbEgress)
 	}
 
@@ -155,6 +199,7 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 			pbToPort := ipb.SpecPort{}
 			pbToPort.Port = toPort.Port
 			pbToPort.Protocol = toPort.Protocol
+			pbToPort.EndPort = int32(toPort.EndPort)
 			pbToPorts = append(pbToPorts, &pbToPort)
 		}
 
@@ -163,6 +208,8 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 			pbToHttp.Path = toHTTP.Path
 			pbToHttp.Method = toHTTP.Method
 			pbToHttp.Aggregated = toHTTP.Aggregated
+			pbToHttp.Host = toHTTP.Host
+			pbToHttp.Headers = append(pbToHttp.Headers, toHTTP.Headers...)
 			pbToHTTPs = append(pbToHTTPs, &pbToHttp)
 		}
 
@@ -177,7 +224,10 @@ func populateNwInsightData(policy types.KnoxNetworkPolicy) ipb.NetworkData {
 
 		pbIngress.ToPorts = pbToPorts
 		pbIngress.ToHTTPs = pbToHTTPs
+		pbIngress.ToGRPCs = convertGRPCRulesToPb(ingress.ToHTTPs)
+		pbIngress.ToKafkas = convertKafkaRulesToPb(ingress.ToKafkas)
 		pbIngress.FromCIDRs = pbFromCIDRs
+		pbIngress.RuleStats = convertRuleStatsToPb("", ingress.RuleStats)
 		pbIngressess = append(pbIngressess, &pbIngress)
 	}
 
@@ -0,0 +1,38 @@
+package insight
+
+import (
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/policydrift"
+	ipb "github.com/accuknox/auto-policy-discovery/src/protobuf/v1/insight"
+)
+
+// GetPolicyDrift returns the policies drifted between the discovered and the
+// applied ones, grouped by workload
+func GetPolicyDrift(req *ipb.PolicyDriftRequest) (*ipb.PolicyDriftResponse, error) {
+	drifts := policydrift.GetPolicyDrifts(req.GetNamespace(), req.GetLabels())
+
+	resp := &ipb.PolicyDriftResponse{}
+	workloads := map[string]*ipb.WorkloadPolicyDrift{}
+
+	for _, drift := range drifts {
+		key := strings.Join([]string{drift.Cluster, drift.Namespace, drift.Labels}, "/")
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &ipb.WorkloadPolicyDrift{
+				ClusterName: drift.Cluster,
+				Namespace:   drift.Namespace,
+				Labels:      drift.Labels,
+			}
+			workloads[key] = workload
+			resp.Workloads = append(resp.Workloads, workload)
+		}
+		workload.Policies = append(workload.Policies, &ipb.DriftedPolicy{
+			Kind:   drift.Kind,
+			Name:   drift.Name,
+			Status: drift.Status,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package insight
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	network "github.com/accuknox/auto-policy-discovery/src/networkpolicy"
+	ipb "github.com/accuknox/auto-policy-discovery/src/protobuf/v1/insight"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// convertPolicyGapToPb converts the policy gap into the protobuf message
+func convertPolicyGapToPb(gap types.PolicyGap) (*ipb.PolicyGap, error) {
+	event, err := json.Marshal(gap.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ipb.PolicyGap{
+		GapId:     gap.RuleKey,
+		Rule:      gap.Candidate,
+		Event:     string(event),
+		FirstSeen: gap.RuleStats.FirstSeen,
+		LastSeen:  gap.RuleStats.LastSeen,
+		HitCount:  gap.RuleStats.HitCount,
+		Pods:      gap.RuleStats.Pods,
+	}, nil
+}
+
+// GetPolicyGaps returns the candidate rules of the dropped or blocked traffic
+// matching the request, grouped by workload
+func GetPolicyGaps(req *ipb.PolicyGapRequest) (*ipb.PolicyGapResponse, error) {
+	filter := types.PolicyGap{
+		ClusterName: req.GetClusterName(),
+		Namespace:   req.GetNamespace(),
+		PolicyType:  req.GetPolicyType(),
+	}
+	if req.GetLabels() != "" {
+		filter.Labels = libs.WorkloadLabels(strings.Split(req.GetLabels(), ","))
+	}
+
+	gaps, err := libs.GetPolicyGaps(network.CfgDB, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return gaps[i].RuleStats.LastSeen > gaps[j].RuleStats.LastSeen
+	})
+
+	resp := &ipb.PolicyGapResponse{}
+	workloads := map[string]*ipb.WorkloadPolicyGaps{}
+
+	for _, gap := range gaps {
+		pbGap, err := convertPolicyGapToPb(gap)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.Join([]string{gap.ClusterName, gap.Namespace, gap.Labels, gap.PolicyType}, "/")
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &ipb.WorkloadPolicyGaps{
+				ClusterName: gap.ClusterName,
+				Namespace:   gap.Namespace,
+				Labels:      gap.Labels,
+				PolicyType:  gap.PolicyType,
+			}
+			workloads[key] = workload
+			resp.Workloads = append(resp.Workloads, workload)
+		}
+		workload.Gaps = append(workload.Gaps, pbGap)
+	}
+
+	return resp, nil
+}
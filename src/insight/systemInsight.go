@@ -2,6 +2,7 @@ package insight
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/accuknox/auto-policy-discovery/src/libs"
@@ -30,7 +31,7 @@ func ConvertSysInsDataToInsResponse(sysdata ipb.SystemInsightData) ipb.InsightRe
 	}
 }
 
-func convertWPFSToInsightData(wpfsSet types.ResourceSetMap) types.SysInsightResponseData {
+func convertWPFSToInsightData(wpfsSet types.ResourceSetMap, statsMap types.ResourceStatsMap) types.SysInsightResponseData {
 	var resData types.SysInsightResponseData
 
 	for wpfs, fsset := range wpfsSet {
@@ -39,6 +40,7 @@ func convertWPFSToInsightData(wpfsSet types.ResourceSetMap) types.SysInsightResp
 
 		// Populate Fileset data(fromsource, process paths and file paths)
 		locFsData.FromSource = wpfs.FromSource
+		locFsData.RuleStats = statsMap[wpfs]
 		if wpfs.SetType == sys.SYS_OP_FILE {
 			locFsData.FilePaths = append(locFsData.FilePaths, fsset...)
 		}
@@ -101,6 +103,15 @@ func convertSysInsDataToResponse(resData types.SysInsightResponseData) []ipb.Sys
 			locfsset.ProcessPaths = append(locfsset.ProcessPaths, fsset.ProcessPaths...)
 			locfsset.NetworkProtocol = append(locfsset.NetworkProtocol, fsset.NetworkPaths...)
 
+			rules := []string{}
+			for rule := range fsset.RuleStats {
+				rules = append(rules, rule)
+			}
+			sort.Strings(rules)
+			for _, rule := range rules {
+				locfsset.RuleStats = append(locfsset.RuleStats, convertRuleStatsToPb(rule, fsset.RuleStats[rule]))
+			}
+
 			locInsData.SysResource = append(locInsData.SysResource, &locfsset)
 		}
 
@@ -115,8 +126,9 @@ func getSysInsightData(wpfs types.WorkloadProcessFileSet) ([]ipb.SystemInsightDa
 	systemData := types.SysInsightResponseData{}
 
 	res, _, _ := libs.GetWorkloadProcessFileSet(sys.CfgDB, wpfs)
+	statsMap, _ := libs.GetWorkloadProcessRuleStats(sys.CfgDB, wpfs)
 
-	systemData = convertWPFSToInsightData(res)
+	systemData = convertWPFSToInsightData(res, statsMap)
 
 	// Write Observability data to json file
 	//libs.WriteSysObsDataToJsonFile(sysObsResData)
@@ -0,0 +1,89 @@
+package insight
+
+import (
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	network "github.com/accuknox/auto-policy-discovery/src/networkpolicy"
+	ipb "github.com/accuknox/auto-policy-discovery/src/protobuf/v1/insight"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ConvertWorkloadStateRequest converts the request into the filter of the workload states
+func ConvertWorkloadStateRequest(req *ipb.WorkloadStateRequest) types.WorkloadState {
+	filter := types.WorkloadState{
+		ClusterName: req.GetClusterName(),
+		Namespace:   req.GetNamespace(),
+		PolicyType:  req.GetPolicyType(),
+		State:       req.GetState(),
+	}
+	if req.GetLabels() != "" {
+		filter.Labels = libs.WorkloadLabels(strings.Split(req.GetLabels(), ","))
+	}
+
+	return filter
+}
+
+// ConvertWorkloadStateToPb converts the workload state into the protobuf message
+func ConvertWorkloadStateToPb(state types.WorkloadState) *ipb.WorkloadState {
+	return &ipb.WorkloadState{
+		ClusterName:     state.ClusterName,
+		Namespace:       state.Namespace,
+		Labels:          state.Labels,
+		PolicyType:      state.PolicyType,
+		State:           state.State,
+		StateSince:      state.StateSince,
+		FirstSeen:       state.FirstSeen,
+		LastChanged:     state.LastChanged,
+		ChangesInWindow: int32(len(state.Changes)),
+	}
+}
+
+// GetWorkloadStates returns the learning states of the workloads matching the request
+func GetWorkloadStates(req *ipb.WorkloadStateRequest) (*ipb.WorkloadStateResponse, error) {
+	states, err := libs.GetWorkloadStates(network.CfgDB, ConvertWorkloadStateRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ipb.WorkloadStateResponse{}
+	for _, state := range states {
+		resp.States = append(resp.States, ConvertWorkloadStateToPb(state))
+	}
+
+	return resp, nil
+}
+
+// addWorkloadStates adds the learning states of the workloads to the insight responses
+func addWorkloadStates(resp *ipb.Response, req types.InsightRequest) error {
+	policyType := ""
+	if req.Source == types.PolicyTypeNetwork || req.Source == types.PolicyTypeSystem {
+		policyType = req.Source
+	}
+
+	for _, res := range resp.Res {
+		filter := &ipb.WorkloadStateRequest{
+			ClusterName: res.ClusterName,
+			Namespace:   res.NameSpace,
+			Labels:      res.Labels,
+			PolicyType:  policyType,
+		}
+		if filter.ClusterName == "" {
+			filter.ClusterName = req.ClusterName
+		}
+		if filter.Namespace == "" {
+			filter.Namespace = req.Namespace
+		}
+		if filter.Labels == "" {
+			filter.Labels = req.Labels
+		}
+
+		states, err := GetWorkloadStates(filter)
+		if err != nil {
+			return err
+		}
+		res.WorkloadStates = states.States
+	}
+
+	return nil
+}
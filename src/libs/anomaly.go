@@ -0,0 +1,157 @@
+package libs
+
+import (
+	"sync"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// =============== //
+// == Anomalies == //
+// =============== //
+
+// AnomalyConsumer receives the anomalies of the stable workloads
+type AnomalyConsumer struct {
+	Filter types.Anomaly // the empty fields match any
+	Events chan *types.Anomaly
+}
+
+// AnomalyStore is used for support v1.Publisher.GetAnomalies RPC requests
+type AnomalyStore struct {
+	Consumers map[*AnomalyConsumer]struct{}
+	Mutex     sync.Mutex
+}
+
+// AnomalyEvents publishes the anomalies of both the network and the system policies
+var AnomalyEvents = AnomalyStore{
+	Consumers: make(map[*AnomalyConsumer]struct{}),
+	Mutex:     sync.Mutex{},
+}
+
+// NewAnomalyConsumer returns a consumer of the anomalies matching the filter
+func NewAnomalyConsumer(filter types.Anomaly) *AnomalyConsumer {
+	return &AnomalyConsumer{
+		Filter: filter,
+		Events: make(chan *types.Anomaly, 64),
+	}
+}
+
+// AddConsumer adds a new AnomalyConsumer to the store
+func (as *AnomalyStore) AddConsumer(c *AnomalyConsumer) {
+	as.Mutex.Lock()
+	defer as.Mutex.Unlock()
+
+	as.Consumers[c] = struct{}{}
+}
+
+// RemoveConsumer removes an AnomalyConsumer from the store
+func (as *AnomalyStore) RemoveConsumer(c *AnomalyConsumer) {
+	as.Mutex.Lock()
+	defer as.Mutex.Unlock()
+
+	delete(as.Consumers, c)
+}
+
+// Publish pushes the anomaly to the consumer's channels, the slow consumers
+// miss the anomaly rather than blocking the discovery
+func (as *AnomalyStore) Publish(anomaly *types.Anomaly) {
+	as.Mutex.Lock()
+	defer as.Mutex.Unlock()
+
+	for consumer := range as.Consumers {
+		if !MatchAnomaly(*anomaly, consumer.Filter) {
+			continue
+		}
+
+		select {
+		case consumer.Events <- anomaly:
+		default:
+			log.Warn().Msgf("dropped the anomaly event of %s/%s", anomaly.Namespace, anomaly.Labels)
+		}
+	}
+}
+
+// MatchAnomaly returns true if the anomaly matches the filter, the empty fields
+// of the filter match any
+func MatchAnomaly(anomaly, filter types.Anomaly) bool {
+	return MatchWorkloadState(
+		types.WorkloadState{
+			ClusterName: anomaly.ClusterName,
+			Namespace:   anomaly.Namespace,
+			Labels:      anomaly.Labels,
+			PolicyType:  anomaly.PolicyType,
+			State:       anomaly.Status,
+		},
+		types.WorkloadState{
+			ClusterName: filter.ClusterName,
+			Namespace:   filter.Namespace,
+			Labels:      filter.Labels,
+			PolicyType:  filter.PolicyType,
+			State:       filter.Status,
+		},
+	)
+}
+
+// IsAnomalyState returns true if the deviations of the workload are anomalies,
+// i.e. the policy of the workload is stable
+func IsAnomalyState(state string) bool {
+	return state == types.WorkloadStateStable || state == types.WorkloadStateEnforceReady
+}
+
+// GetAnomalyWorkloads returns the workloads of the namespace in anomaly mode
+// (key: the labels of the workload), or nil if the anomaly mode is disabled
+func GetAnomalyWorkloads(cfg types.ConfigDB, anomalyMode bool, policyType, clusterName, namespace string) map[string]bool {
+	if !anomalyMode {
+		return nil
+	}
+
+	states, err := GetWorkloadStates(cfg, types.WorkloadState{
+		ClusterName: clusterName,
+		Namespace:   namespace,
+		PolicyType:  policyType,
+	})
+	if err != nil {
+		log.Error().Msgf("could not fetch the workload states err=%s", err.Error())
+		return nil
+	}
+
+	workloads := map[string]bool{}
+	for _, state := range states {
+		if IsAnomalyState(state.State) {
+			workloads[state.Labels] = true
+		}
+	}
+
+	return workloads
+}
+
+// RecordAnomaly records the deviation of the stable workload, the observations
+// of the same rule are merged into the pending anomaly. The new anomalies are
+// published to AnomalyEvents.
+func RecordAnomaly(cfg types.ConfigDB, anomaly types.Anomaly) {
+	exists, err := GetAnomalies(cfg, types.Anomaly{PolicyType: anomaly.PolicyType, RuleKey: anomaly.RuleKey})
+	if err != nil {
+		log.Error().Msgf("could not fetch the anomalies err=%s", err.Error())
+		return
+	}
+
+	isNew := true
+	if len(exists) > 0 && exists[0].Status == types.AnomalyStatusPending {
+		anomaly.RuleStats = MergeRuleStats(exists[0].RuleStats, anomaly.RuleStats)
+		isNew = false
+	}
+	if len(anomaly.RuleStats.Evidence) > 0 {
+		anomaly.Event = anomaly.RuleStats.Evidence[len(anomaly.RuleStats.Evidence)-1]
+	}
+	anomaly.Status = types.AnomalyStatusPending
+
+	if err := UpdateAnomaly(cfg, anomaly); err != nil {
+		log.Error().Msgf("could not record the anomaly err=%s", err.Error())
+		return
+	}
+
+	if isNew {
+		log.Info().Msgf("anomaly of the stable workload %s/%s: %s", anomaly.Namespace, anomaly.Labels, anomaly.Candidate)
+		AnomalyEvents.Publish(&anomaly)
+	}
+}
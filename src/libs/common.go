@@ -3,6 +3,7 @@ package libs
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/csv"
 	"flag"
 	"fmt"
 	"math/big"
@@ -12,7 +13,9 @@ import (
 	"os/signal"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -50,10 +53,42 @@ const (
 )
 
 const (
-	L7ProtocolDNS  = "dns"
-	L7ProtocolHTTP = "http"
+	EtherTypeIPv4 = 0x0800
+	EtherTypeIPv6 = 0x86DD
 )
 
+const (
+	L7ProtocolDNS   = "dns"
+	L7ProtocolHTTP  = "http"
+	L7ProtocolGRPC  = "grpc"
+	L7ProtocolKafka = "kafka"
+)
+
+// kafkaRoles are the cilium kafka roles of the api keys; the api keys used by
+// both roles, e.g. metadata, are not in the map
+var kafkaRoles = map[string]string{
+	"produce":         "produce",
+	"fetch":           "consume",
+	"offsets":         "consume",
+	"offsetcommit":    "consume",
+	"offsetfetch":     "consume",
+	"findcoordinator": "consume",
+	"joingroup":       "consume",
+	"heartbeat":       "consume",
+	"leavegroup":      "consume",
+	"syncgroup":       "consume",
+}
+
+// kafkaRoleAPIKeys are the api keys allowed by the cilium kafka roles
+var kafkaRoleAPIKeys = map[string][]string{
+	"produce": {"produce", "metadata", "apiversions"},
+	"consume": {"fetch", "offsets", "metadata", "offsetcommit", "offsetfetch", "findcoordinator",
+		"joingroup", "heartbeat", "leavegroup", "syncgroup", "apiversions"},
+}
+
+// grpcPath is the path of the gRPC request, i.e. /package.Service/Method
+var grpcPath = regexp.MustCompile(`^/[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)+/[A-Za-z_][A-Za-z0-9_]*$`)
+
 var protocolMap = map[int]string{
 	IPProtoUnknown:   "Unknown",
 	IPProtocolICMP:   "ICMP",
@@ -69,6 +104,11 @@ var ICMPReplyType = []int{
 	0, // EchoReply
 }
 
+// Array for ICMPv6 type which can be considered as ICMPv6 reply packets.
+var ICMPv6ReplyType = []int{
+	129, // EchoReply
+}
+
 func printBuildDetails() {
 	if GitCommit == "" {
 		return
@@ -100,6 +140,36 @@ func SetDefaultConfig() {
 	viper.SetDefault("application.network.network-policy-to", "db|file")
 	viper.SetDefault("application.network.network-policy-dir", "./")
 	viper.SetDefault("application.network.skip-cert-verification", true)
+	viper.SetDefault("application.network.policy-name-template", DefaultPolicyNameTemplate)
+	viper.SetDefault("application.network.migrate-policy-names", false)
+	viper.SetDefault("application.network.baseline-policy.enable", false)
+	viper.SetDefault("application.network.baseline-policy.dns-allow", true)
+	viper.SetDefault("application.network.stale-rule.window", "0")
+	viper.SetDefault("application.network.stale-rule.action", types.StaleRuleActionFlag)
+	viper.SetDefault("application.network.rule-threshold.min-count", 0)
+	viper.SetDefault("application.network.rule-threshold.min-pods", 0)
+	viper.SetDefault("application.network.rule-threshold.min-span", "0")
+	viper.SetDefault("application.network.rule-threshold.staging-ttl", "168h")
+	viper.SetDefault("application.network.gap-analysis", false)
+	viper.SetDefault("application.network.import-policies.from", "")
+	viper.SetDefault("application.network.import-policies.dir", "./policies")
+	viper.SetDefault("application.network.fqdn-aggregation.threshold", 0)
+	viper.SetDefault("application.network.fqdn-aggregation.protected-suffixes", []string{"co.uk", "co.jp", "com.au", "com.cn", "amazonaws.com", "cloudfront.net"})
+	viper.SetDefault("application.network.http-host", false)
+	viper.SetDefault("application.network.http-header-allowlist", []string{})
+	viper.SetDefault("application.network.cidr-bits-v6", 128)
+	viper.SetDefault("application.network.port-range.density", 0)
+	viper.SetDefault("application.network.port-range.min-ports", 3)
+	viper.SetDefault("application.network.port-range.well-known-max", 1023)
+	viper.SetDefault("application.network.ingress-world.from", "")
+	viper.SetDefault("application.network.ingress-world.cidr-bits", 24)
+	viper.SetDefault("application.network.ingress-world.cidr-bits-v6", 64)
+	viper.SetDefault("application.network.ingress-world.controller-labels", []string{})
+	viper.SetDefault("application.network.clusterwide.min-namespaces", 0)
+	viper.SetDefault("application.network.clusterwide.label-keys", []string{})
+	viper.SetDefault("application.network.host-policy.enable", false)
+	viper.SetDefault("application.network.host-policy.node-labels", []string{})
+	viper.SetDefault("application.network.service-strategy.default", types.ServiceStrategyEndpoint)
 
 	// Application->System config
 	viper.SetDefault("application.system.operation-mode", 1)
@@ -111,10 +181,33 @@ func SetDefaultConfig() {
 	viper.SetDefault("application.system.system-policy-dir", "./")
 	viper.SetDefault("application.system.system-policy-types", 7)
 	viper.SetDefault("application.system.deprecate-old-mode", false)
+	viper.SetDefault("application.system.path-aggregation.mode", "directory")
+	viper.SetDefault("application.system.path-aggregation.threshold", 3)
+	viper.SetDefault("application.system.path-aggregation.strictness", "normal")
+	viper.SetDefault("application.system.host-policy.enable", false)
+	viper.SetDefault("application.system.container-scoped-policy", false)
+	viper.SetDefault("application.system.policy-name-template", DefaultPolicyNameTemplate)
+	viper.SetDefault("application.system.migrate-policy-names", false)
+	viper.SetDefault("application.system.stale-rule.window", "0")
+	viper.SetDefault("application.system.stale-rule.action", types.StaleRuleActionFlag)
+	viper.SetDefault("application.system.exec-session.mode", types.ExecSessionModeInclude)
+	viper.SetDefault("application.system.rule-threshold.min-count", 0)
+	viper.SetDefault("application.system.rule-threshold.min-pods", 0)
+	viper.SetDefault("application.system.rule-threshold.min-span", "0")
+	viper.SetDefault("application.system.rule-threshold.staging-ttl", "168h")
+	viper.SetDefault("application.system.gap-analysis", false)
+	viper.SetDefault("application.system.import-policies.from", "")
+	viper.SetDefault("application.system.import-policies.dir", "./policies")
 
 	// Application->cluster config
 	viper.SetDefault("application.cluster.cluster-info-from", "k8sclient")
 
+	// Application->workload state config
+	viper.SetDefault("application.workload-state.window", "24h")
+	viper.SetDefault("application.workload-state.stable-after", "24h")
+	viper.SetDefault("application.workload-state.enforce-ready-after", "72h")
+	viper.SetDefault("application.workload-state.anomaly-mode", false)
+
 	// Database config
 	viper.SetDefault("database.driver", "mysql")
 	viper.SetDefault("database.user", "root")
@@ -152,6 +245,11 @@ func SetDefaultConfig() {
 	viper.SetDefault("recommend.operation-mode", 1)
 	viper.SetDefault("recommend.host-policy", true)
 	viper.SetDefault("recommend.admission-controller-policy", true)
+
+	// drift detection config
+	viper.SetDefault("drift-detection.enable", false)
+	viper.SetDefault("drift-detection.cron-job-time-interval", "0h10m00s")
+	viper.SetDefault("drift-detection.metrics-port", "9090")
 }
 
 type cfgArray []string
@@ -317,9 +415,120 @@ func IsICMP(protocol int) bool {
 	return false
 }
 
-func IsReplyICMP(icmpType int) bool {
+func IsReplyICMP(protocol, icmpType int) bool {
+	if protocol == IPProtocolICMPv6 {
+		return ContainsElement(ICMPv6ReplyType, icmpType)
+	}
 	return ContainsElement(ICMPReplyType, icmpType)
+}
+
+// GetICMPFamily returns the family of the icmp rule, i.e. IPv4 or IPv6
+func GetICMPFamily(protocol int) string {
+	if protocol == IPProtocolICMPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// IsIPv6 returns true if the ip address is the ipv6 address
+func IsIPv6(ip string) bool {
+	addr := net.ParseIP(ip)
+	return addr != nil && addr.To4() == nil
+}
+
+// GetEtherType returns the ether type of the ip address
+func GetEtherType(ip string) int {
+	if IsIPv6(ip) {
+		return EtherTypeIPv6
+	}
+	return EtherTypeIPv4
+}
+
+// GetCIDR returns the network of the ip address, the prefix length is
+// ipv4Bits for the ipv4 address and ipv6Bits for the ipv6 address
+func GetCIDR(ip string, ipv4Bits, ipv6Bits int) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ""
+	}
+
+	bits, prefix := 32, ipv4Bits
+	if addr.To4() == nil {
+		bits, prefix = 128, ipv6Bits
+	} else {
+		addr = addr.To4()
+	}
+
+	if prefix <= 0 || prefix > bits {
+		prefix = bits
+	}
+
+	network := net.IPNet{IP: addr.Mask(net.CIDRMask(prefix, bits)), Mask: net.CIDRMask(prefix, bits)}
+	return network.String()
+}
+
+// IsSameIP returns true if both addresses are the same ip, e.g. 2001:db8::1
+// and 2001:0db8:0:0:0:0:0:1
+func IsSameIP(ip1, ip2 string) bool {
+	if ip1 == ip2 {
+		return true
+	}
+	addr := net.ParseIP(ip1)
+	return addr != nil && addr.Equal(net.ParseIP(ip2))
+}
+
+// ContainsIP returns true if the ip is one of the addresses
+func ContainsIP(ips []string, ip string) bool {
+	for _, addr := range ips {
+		if IsSameIP(addr, ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterDomain is the domain of the services in the cluster
+const ClusterDomain = "svc.cluster.local"
+
+// IsClusterDomain returns true if the domain name (or pattern) is of the service in the cluster
+func IsClusterDomain(domainName string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(domainName, "."), "."+ClusterDomain)
+}
+
+// IsL7HTTP returns true if the L7 protocol is carried over HTTP, i.e. http or grpc
+func IsL7HTTP(l7Protocol string) bool {
+	return l7Protocol == L7ProtocolHTTP || l7Protocol == L7ProtocolGRPC
+}
+
+// IsL7Request returns true if the flow is the L7 request parsed by the proxy,
+// which is not marked with the SYN flag
+func IsL7Request(l7Protocol string) bool {
+	return IsL7HTTP(l7Protocol) || l7Protocol == L7ProtocolKafka
+}
 
+// KafkaRole returns the cilium kafka role of the api key, or empty if not specific
+func KafkaRole(apiKey string) string {
+	return kafkaRoles[strings.ToLower(apiKey)]
+}
+
+// KafkaRoleIncludes returns true if the api key is allowed by the cilium kafka role
+func KafkaRoleIncludes(role, apiKey string) bool {
+	return ContainsElement(kafkaRoleAPIKeys[role], strings.ToLower(apiKey))
+}
+
+// IsGRPCPath returns true if the HTTP path is in the form of /package.Service/Method
+func IsGRPCPath(path string) bool {
+	return grpcPath.MatchString(path)
+}
+
+// SplitGRPCPath splits the gRPC path into the service and the method
+func SplitGRPCPath(path string) (string, string) {
+	if !IsGRPCPath(path) {
+		return "", ""
+	}
+
+	i := strings.LastIndex(path, "/")
+	return path[1:i], path[i+1:]
 }
 
 // ============ //
@@ -606,6 +815,62 @@ func WriteSysObsDataToJsonFile(obsData types.SysInsightResponseData) {
 
 }
 
+func openCoverageFile(ext string) (*os.File, error) {
+	fileName := getPolicyDir(cfg.CurrentCfg.ConfigSysPolicy.SystemPolicyDir)
+	fileName = fileName + "policy_coverage" + ext
+
+	if err := os.Remove(fileName); err != nil {
+		if !strings.Contains(err.Error(), NoSuchFileOrDir) {
+			log.Error().Msg(err.Error())
+		}
+	}
+
+	return os.OpenFile(filepath.Clean(fileName), os.O_CREATE|os.O_WRONLY, 0600)
+}
+
+func WriteCoverageReportToJsonFile(report types.CoverageReport) {
+	f, err := openCoverageFile(".json")
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return
+	}
+
+	b, err := json.Marshal(&report)
+	if err != nil {
+		log.Error().Msg(err.Error())
+	}
+	writeJsonByte(f, b)
+
+	if err := f.Close(); err != nil {
+		log.Error().Msg(err.Error())
+	}
+}
+
+func WriteCoverageReportToCsvFile(report types.CoverageReport) {
+	f, err := openCoverageFile(".csv")
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return
+	}
+
+	w := csv.NewWriter(f)
+	records := [][]string{{"cluster", "namespace", "labels", "network_ingress", "network_egress",
+		"system_process", "system_file", "observed_flows", "covered_flows", "observed_events", "covered_events"}}
+	for _, wc := range report.Workloads {
+		records = append(records, []string{wc.ClusterName, wc.Namespace, wc.Labels,
+			wc.NetworkIngress, wc.NetworkEgress, wc.SystemProcess, wc.SystemFile,
+			strconv.FormatInt(wc.ObservedFlows, 10), strconv.FormatInt(wc.CoveredFlows, 10),
+			strconv.FormatInt(wc.ObservedEvents, 10), strconv.FormatInt(wc.CoveredEvents, 10)})
+	}
+	if err := w.WriteAll(records); err != nil {
+		log.Error().Msg(err.Error())
+	}
+
+	if err := f.Close(); err != nil {
+		log.Error().Msg(err.Error())
+	}
+}
+
 // ========== //
 // == Time == //
 // ========== //
@@ -70,3 +70,24 @@ func TestConvertUnixTSToDateTime(t *testing.T) {
 
 	assert.Equal(t, primitive.NewDateTimeFromTime(time.Unix(100, 0)), actual, ShouldBeEqual)
 }
+
+func TestSplitGRPCPath(t *testing.T) {
+	service, method := SplitGRPCPath("/hipstershop.CartService/GetCart")
+	assert.Equal(t, "hipstershop.CartService", service)
+	assert.Equal(t, "GetCart", method)
+
+	assert.False(t, IsGRPCPath("/api/v1/users"))
+}
+
+func TestGetCIDR(t *testing.T) {
+	assert.Equal(t, "10.0.1.0/24", GetCIDR("10.0.1.31", 24, 64))
+	assert.Equal(t, "2001:db8:1:2::/64", GetCIDR("2001:db8:1:2:3:4:5:6", 24, 64))
+	assert.Equal(t, "2001:db8::1/128", GetCIDR("2001:db8::1", 32, 0))
+	assert.Equal(t, "", GetCIDR("unknown", 32, 128))
+
+	assert.True(t, IsSameIP("2001:db8::1", "2001:0db8:0:0:0:0:0:1"))
+	assert.True(t, ContainsIP([]string{"10.0.1.31", "fd00::1f"}, "fd00:0::1f"))
+	assert.Equal(t, "IPv6", GetICMPFamily(IPProtocolICMPv6))
+	assert.True(t, IsReplyICMP(IPProtocolICMPv6, 129))
+	assert.False(t, IsReplyICMP(IPProtocolICMPv6, 0))
+}
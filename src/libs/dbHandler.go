@@ -2,12 +2,14 @@ package libs
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	cfg "github.com/accuknox/auto-policy-discovery/src/config"
 	logger "github.com/accuknox/auto-policy-discovery/src/logging"
 	"github.com/accuknox/auto-policy-discovery/src/types"
 	"github.com/robfig/cron"
+	"sigs.k8s.io/yaml"
 )
 
 // ================= //
@@ -17,6 +19,17 @@ import (
 // LastFlowID network flow between [ startTime <= time < endTime ]
 var LastFlowID int64 = 0
 
+// SeedLastFlowID continues LastFlowID from the largest flow id referenced by the stored network policies
+func SeedLastFlowID(cfg types.ConfigDB) {
+	for _, policy := range GetNetworkPolicies(cfg, "", "", "", "", "") {
+		for _, id := range policy.FlowIDs {
+			if int64(id) > LastFlowID {
+				LastFlowID = int64(id)
+			}
+		}
+	}
+}
+
 // ==================== //
 // == Network Policy == //
 // ==================== //
@@ -41,6 +54,23 @@ func GetNetworkPolicies(cfg types.ConfigDB, cluster, namespace, status, nwtype,
 	return results
 }
 
+// GetNetworkPolicyByName returns the network policy of the name and the status
+func GetNetworkPolicyByName(cfg types.ConfigDB, name, status string) (types.KnoxNetworkPolicy, bool) {
+	var docs []types.KnoxNetworkPolicy
+	var err error
+
+	if cfg.DBDriver == "mysql" {
+		docs, err = GetNetworkPolicyByNameFromMySQL(cfg, name, status)
+	} else if cfg.DBDriver == "sqlite3" {
+		docs, err = GetNetworkPolicyByNameFromSQLite(cfg, name, status)
+	}
+	if err != nil || len(docs) == 0 {
+		return types.KnoxNetworkPolicy{}, false
+	}
+
+	return docs[0], true
+}
+
 func GetNetworkPoliciesBySelector(cfg types.ConfigDB, cluster, namespace, status string, selector map[string]string) ([]types.KnoxNetworkPolicy, error) {
 	results := []types.KnoxNetworkPolicy{}
 
@@ -197,6 +227,37 @@ func UpdateSystemPolicy(cfg types.ConfigDB, policy types.KnoxSystemPolicy) {
 
 }
 
+// DeleteImportedPolicies removes the policies imported from the cluster, they
+// are replaced as a whole on each import
+func DeleteImportedPolicies(cfg types.ConfigDB, policyType, clusterName string) error {
+	var db *sql.DB
+	var table, clusterColumn string
+
+	if cfg.DBDriver == "mysql" {
+		db = connectMySQL(cfg)
+	} else if cfg.DBDriver == "sqlite3" {
+		db = connectSQLite(cfg, cfg.SQLiteDBPath)
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	if policyType == types.PolicyTypeNetwork {
+		table, clusterColumn = TableNetworkPolicy_TableName, "cluster_name"
+	} else {
+		table, clusterColumn = TableSystemPolicy_TableName, "clusterName"
+	}
+
+	stmt, err := db.Prepare("DELETE FROM " + table + " WHERE status = ? and " + clusterColumn + " = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(types.PolicyStatusImported, clusterName)
+	return err
+}
+
 func GetWorkloadProcessFileSet(cfg types.ConfigDB, wpfs types.WorkloadProcessFileSet) (map[types.WorkloadProcessFileSet][]string, types.PolicyNameMap, error) {
 	if cfg.DBDriver == "mysql" {
 		res, pnMap, err := GetWorkloadProcessFileSetMySQL(cfg, wpfs)
@@ -232,10 +293,613 @@ func ClearWPFSDb(cfg types.ConfigDB, wpfs types.WorkloadProcessFileSet, duration
 	return errors.New("no db driver")
 }
 
+// GetWorkloadProcessRuleStats returns the statistics of the file set rules, the
+// empty fields of wpfs match any
+func GetWorkloadProcessRuleStats(cfg types.ConfigDB, wpfs types.WorkloadProcessFileSet) (types.ResourceStatsMap, error) {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), WorkloadProcessRuleStats_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), WorkloadProcessRuleStatsSQLite_TableName
+	} else {
+		return nil, errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return getWorkloadProcessRuleStatsSQL(db, table, wpfs)
+}
+
+func getWorkloadProcessRuleStatsSQL(db *sql.DB, table string, wpfs types.WorkloadProcessFileSet) (types.ResourceStatsMap, error) {
+	query := "SELECT clusterName,namespace,containerName,labels,fromSource,settype,rulestats FROM " + table
+
+	var whereClause string
+	var args []interface{}
+
+	for _, field := range []struct {
+		column string
+		value  string
+	}{
+		{"clusterName", wpfs.ClusterName},
+		{"namespace", wpfs.Namespace},
+		{"containerName", wpfs.ContainerName},
+		{"labels", wpfs.Labels},
+		{"fromSource", wpfs.FromSource},
+		{"settype", wpfs.SetType},
+	} {
+		if field.value != "" {
+			concatWhereClause(&whereClause, field.column)
+			args = append(args, field.value)
+		}
+	}
+
+	results, err := db.Query(query+whereClause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	res := types.ResourceStatsMap{}
+
+	for results.Next() {
+		var locWpfs types.WorkloadProcessFileSet
+		var statsJSON string
+
+		if err := results.Scan(
+			&locWpfs.ClusterName,
+			&locWpfs.Namespace,
+			&locWpfs.ContainerName,
+			&locWpfs.Labels,
+			&locWpfs.FromSource,
+			&locWpfs.SetType,
+			&statsJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		stats := map[string]types.RuleStats{}
+		if err := json.Unmarshal([]byte(statsJSON), &stats); err != nil {
+			return nil, err
+		}
+		res[locWpfs] = stats
+	}
+
+	return res, results.Err()
+}
+
+// UpdateWorkloadProcessRuleStats replaces the statistics of the file set rules
+func UpdateWorkloadProcessRuleStats(cfg types.ConfigDB, wpfs types.WorkloadProcessFileSet, stats map[string]types.RuleStats) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), WorkloadProcessRuleStats_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), WorkloadProcessRuleStatsSQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return updateWorkloadProcessRuleStatsSQL(db, table, wpfs, stats)
+}
+
+func updateWorkloadProcessRuleStatsSQL(db *sql.DB, table string, wpfs types.WorkloadProcessFileSet, stats map[string]types.RuleStats) error {
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	now := ConvertStrToUnixTime("now")
+
+	// the stats of a file set are kept in a single row
+	deleteStmt, err := db.Prepare("DELETE FROM " + table +
+		" WHERE clusterName = ? and containerName = ? and namespace = ? and labels = ? and fromSource = ? and settype = ?")
+	if err != nil {
+		return err
+	}
+	defer deleteStmt.Close()
+
+	if _, err := deleteStmt.Exec(wpfs.ClusterName, wpfs.ContainerName, wpfs.Namespace, wpfs.Labels,
+		wpfs.FromSource, wpfs.SetType); err != nil {
+		return err
+	}
+
+	insertStmt, err := db.Prepare("INSERT INTO " + table +
+		"(clusterName,namespace,containerName,labels,fromSource,settype,rulestats,updatedTime) values(?,?,?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	_, err = insertStmt.Exec(wpfs.ClusterName, wpfs.Namespace, wpfs.ContainerName, wpfs.Labels,
+		wpfs.FromSource, wpfs.SetType, string(statsJSON), now)
+	return err
+}
+
+// GetStagedRules returns the candidate rules below the thresholds, keyed by the rule key
+func GetStagedRules(cfg types.ConfigDB, policyType, clusterName, namespace string) (map[string]types.StagedRule, error) {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), RuleStaging_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), RuleStagingSQLite_TableName
+	} else {
+		return nil, errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return getStagedRulesSQL(db, table, policyType, clusterName, namespace)
+}
+
+func getStagedRulesSQL(db *sql.DB, table, policyType, clusterName, namespace string) (map[string]types.StagedRule, error) {
+	query := "SELECT clusterName,namespace,policyType,ruleKey,candidate,rulestats FROM " + table +
+		" WHERE policyType = ? and clusterName = ? and namespace = ?"
+
+	results, err := db.Query(query, policyType, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	res := map[string]types.StagedRule{}
+
+	for results.Next() {
+		var staged types.StagedRule
+		var statsJSON string
+
+		if err := results.Scan(
+			&staged.ClusterName,
+			&staged.Namespace,
+			&staged.PolicyType,
+			&staged.RuleKey,
+			&staged.Candidate,
+			&statsJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(statsJSON), &staged.RuleStats); err != nil {
+			return nil, err
+		}
+		res[staged.RuleKey] = staged
+	}
+
+	return res, results.Err()
+}
+
+// UpdateStagedRule inserts or replaces the candidate rule
+func UpdateStagedRule(cfg types.ConfigDB, staged types.StagedRule) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), RuleStaging_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), RuleStagingSQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	if err := deleteStagedRuleSQL(db, table, staged.PolicyType, staged.RuleKey); err != nil {
+		return err
+	}
+
+	statsJSON, err := json.Marshal(staged.RuleStats)
+	if err != nil {
+		return err
+	}
+
+	insertStmt, err := db.Prepare("INSERT INTO " + table +
+		"(clusterName,namespace,policyType,ruleKey,candidate,rulestats,updatedTime) values(?,?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	_, err = insertStmt.Exec(staged.ClusterName, staged.Namespace, staged.PolicyType, staged.RuleKey,
+		staged.Candidate, string(statsJSON), ConvertStrToUnixTime("now"))
+	return err
+}
+
+// DeleteStagedRule removes the candidate rule, e.g. once it is proposed
+func DeleteStagedRule(cfg types.ConfigDB, policyType, ruleKey string) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), RuleStaging_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), RuleStagingSQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return deleteStagedRuleSQL(db, table, policyType, ruleKey)
+}
+
+// DeleteExpiredStagedRules removes the candidate rules not seen within the ttl (seconds)
+func DeleteExpiredStagedRules(cfg types.ConfigDB, policyType string, ttl int64) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), RuleStaging_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), RuleStagingSQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return deleteExpiredStagedRulesSQL(db, table, policyType, ConvertStrToUnixTime("now")-ttl)
+}
+
+func deleteExpiredStagedRulesSQL(db *sql.DB, table, policyType string, before int64) error {
+	stmt, err := db.Prepare("DELETE FROM " + table + " WHERE policyType = ? and updatedTime < ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(policyType, before)
+	return err
+}
+
+func deleteStagedRuleSQL(db *sql.DB, table, policyType, ruleKey string) error {
+	stmt, err := db.Prepare("DELETE FROM " + table + " WHERE policyType = ? and ruleKey = ?")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(policyType, ruleKey)
+	return err
+}
+
+// GetWorkloadStates returns the learning states of the workloads, the empty
+// fields of the filter match any
+func GetWorkloadStates(cfg types.ConfigDB, filter types.WorkloadState) ([]types.WorkloadState, error) {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), WorkloadState_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), WorkloadStateSQLite_TableName
+	} else {
+		return nil, errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return getWorkloadStatesSQL(db, table, filter)
+}
+
+func getWorkloadStatesSQL(db *sql.DB, table string, filter types.WorkloadState) ([]types.WorkloadState, error) {
+	query := "SELECT state FROM " + table
+
+	var whereClause string
+	var args []interface{}
+
+	for _, field := range []struct {
+		column string
+		value  string
+	}{
+		{"clusterName", filter.ClusterName},
+		{"namespace", filter.Namespace},
+		{"labels", filter.Labels},
+		{"policyType", filter.PolicyType},
+	} {
+		if field.value != "" {
+			concatWhereClause(&whereClause, field.column)
+			args = append(args, field.value)
+		}
+	}
+
+	results, err := db.Query(query+whereClause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	states := []types.WorkloadState{}
+
+	for results.Next() {
+		var stateJSON string
+		if err := results.Scan(&stateJSON); err != nil {
+			return nil, err
+		}
+
+		state := types.WorkloadState{}
+		if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
+			return nil, err
+		}
+		if filter.State != "" && filter.State != state.State {
+			continue
+		}
+		states = append(states, state)
+	}
+
+	return states, results.Err()
+}
+
+// UpdateWorkloadStateDB inserts or replaces the learning state of the workload
+func UpdateWorkloadStateDB(cfg types.ConfigDB, state types.WorkloadState) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), WorkloadState_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), WorkloadStateSQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	deleteStmt, err := db.Prepare("DELETE FROM " + table +
+		" WHERE clusterName = ? and namespace = ? and labels = ? and policyType = ?")
+	if err != nil {
+		return err
+	}
+	defer deleteStmt.Close()
+
+	if _, err := deleteStmt.Exec(state.ClusterName, state.Namespace, state.Labels, state.PolicyType); err != nil {
+		return err
+	}
+
+	insertStmt, err := db.Prepare("INSERT INTO " + table +
+		"(clusterName,namespace,labels,policyType,state,updatedTime) values(?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	_, err = insertStmt.Exec(state.ClusterName, state.Namespace, state.Labels, state.PolicyType,
+		string(stateJSON), ConvertStrToUnixTime("now"))
+	return err
+}
+
+// GetAnomalies returns the anomalies of the stable workloads, the empty fields
+// of the filter match any
+func GetAnomalies(cfg types.ConfigDB, filter types.Anomaly) ([]types.Anomaly, error) {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), Anomaly_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), AnomalySQLite_TableName
+	} else {
+		return nil, errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return getAnomaliesSQL(db, table, filter)
+}
+
+func getAnomaliesSQL(db *sql.DB, table string, filter types.Anomaly) ([]types.Anomaly, error) {
+	query := "SELECT anomaly FROM " + table
+
+	var whereClause string
+	var args []interface{}
+
+	for _, field := range []struct {
+		column string
+		value  string
+	}{
+		{"clusterName", filter.ClusterName},
+		{"namespace", filter.Namespace},
+		{"labels", filter.Labels},
+		{"policyType", filter.PolicyType},
+		{"ruleKey", filter.RuleKey},
+		{"status", filter.Status},
+	} {
+		if field.value != "" {
+			concatWhereClause(&whereClause, field.column)
+			args = append(args, field.value)
+		}
+	}
+
+	results, err := db.Query(query+whereClause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	anomalies := []types.Anomaly{}
+
+	for results.Next() {
+		var anomalyJSON string
+		if err := results.Scan(&anomalyJSON); err != nil {
+			return nil, err
+		}
+
+		anomaly := types.Anomaly{}
+		if err := json.Unmarshal([]byte(anomalyJSON), &anomaly); err != nil {
+			return nil, err
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	return anomalies, results.Err()
+}
+
+// UpdateAnomaly inserts or replaces the anomaly
+func UpdateAnomaly(cfg types.ConfigDB, anomaly types.Anomaly) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), Anomaly_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), AnomalySQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	anomalyJSON, err := json.Marshal(anomaly)
+	if err != nil {
+		return err
+	}
+
+	deleteStmt, err := db.Prepare("DELETE FROM " + table + " WHERE policyType = ? and ruleKey = ?")
+	if err != nil {
+		return err
+	}
+	defer deleteStmt.Close()
+
+	if _, err := deleteStmt.Exec(anomaly.PolicyType, anomaly.RuleKey); err != nil {
+		return err
+	}
+
+	insertStmt, err := db.Prepare("INSERT INTO " + table +
+		"(clusterName,namespace,labels,policyType,ruleKey,status,anomaly,updatedTime) values(?,?,?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	_, err = insertStmt.Exec(anomaly.ClusterName, anomaly.Namespace, anomaly.Labels, anomaly.PolicyType,
+		anomaly.RuleKey, anomaly.Status, string(anomalyJSON), ConvertStrToUnixTime("now"))
+	return err
+}
+
+// GetPolicyGaps returns the candidate rules of the dropped or blocked traffic,
+// the empty fields of the filter match any
+func GetPolicyGaps(cfg types.ConfigDB, filter types.PolicyGap) ([]types.PolicyGap, error) {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), PolicyGap_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), PolicyGapSQLite_TableName
+	} else {
+		return nil, errors.New("no db driver")
+	}
+	defer db.Close()
+
+	return getPolicyGapsSQL(db, table, filter)
+}
+
+func getPolicyGapsSQL(db *sql.DB, table string, filter types.PolicyGap) ([]types.PolicyGap, error) {
+	query := "SELECT gap FROM " + table
+
+	var whereClause string
+	var args []interface{}
+
+	for _, field := range []struct {
+		column string
+		value  string
+	}{
+		{"clusterName", filter.ClusterName},
+		{"namespace", filter.Namespace},
+		{"labels", filter.Labels},
+		{"policyType", filter.PolicyType},
+		{"ruleKey", filter.RuleKey},
+	} {
+		if field.value != "" {
+			concatWhereClause(&whereClause, field.column)
+			args = append(args, field.value)
+		}
+	}
+
+	results, err := db.Query(query+whereClause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	gaps := []types.PolicyGap{}
+
+	for results.Next() {
+		var gapJSON string
+		if err := results.Scan(&gapJSON); err != nil {
+			return nil, err
+		}
+
+		gap := types.PolicyGap{}
+		if err := json.Unmarshal([]byte(gapJSON), &gap); err != nil {
+			return nil, err
+		}
+		gaps = append(gaps, gap)
+	}
+
+	return gaps, results.Err()
+}
+
+// UpdatePolicyGap inserts or replaces the candidate rule of the gap
+func UpdatePolicyGap(cfg types.ConfigDB, gap types.PolicyGap) error {
+	var db *sql.DB
+	var table string
+
+	if cfg.DBDriver == "mysql" {
+		db, table = connectMySQL(cfg), PolicyGap_TableName
+	} else if cfg.DBDriver == "sqlite3" {
+		db, table = connectSQLite(cfg, cfg.SQLiteDBPath), PolicyGapSQLite_TableName
+	} else {
+		return errors.New("no db driver")
+	}
+	defer db.Close()
+
+	gapJSON, err := json.Marshal(gap)
+	if err != nil {
+		return err
+	}
+
+	deleteStmt, err := db.Prepare("DELETE FROM " + table + " WHERE policyType = ? and ruleKey = ?")
+	if err != nil {
+		return err
+	}
+	defer deleteStmt.Close()
+
+	if _, err := deleteStmt.Exec(gap.PolicyType, gap.RuleKey); err != nil {
+		return err
+	}
+
+	insertStmt, err := db.Prepare("INSERT INTO " + table +
+		"(clusterName,namespace,labels,policyType,ruleKey,gap,updatedTime) values(?,?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	_, err = insertStmt.Exec(gap.ClusterName, gap.Namespace, gap.Labels, gap.PolicyType,
+		gap.RuleKey, string(gapJSON), ConvertStrToUnixTime("now"))
+	return err
+}
+
 // =========== //
 // == Table == //
 // =========== //
 
+// addColumnsIfNotExist adds the columns (name: definition) missing in the table
+func addColumnsIfNotExist(db *sql.DB, table string, columns map[string]string) error {
+	for column, definition := range columns {
+		rows, err := db.Query("SELECT " + column + " FROM " + table + " LIMIT 1")
+		if err == nil {
+			rows.Close()
+			continue
+		}
+
+		if _, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func ClearDBTables(cfg types.ConfigDB) {
 	if cfg.DBDriver == "mysql" {
 		if err := ClearDBTablesMySQL(cfg); err != nil {
@@ -267,6 +931,21 @@ func CreateTablesIfNotExist(cfg types.ConfigDB) {
 		if err := CreateTableWorkLoadProcessFileSetMySQL(cfg); err != nil {
 			log.Error().Msg(err.Error())
 		}
+		if err := CreateTableWorkLoadProcessRuleStatsMySQL(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTableRuleStagingMySQL(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTableWorkloadStateMySQL(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTableAnomalyMySQL(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTablePolicyGapMySQL(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
 		if err := CreateTableSystemLogsMySQL(cfg); err != nil {
 			log.Error().Msg(err.Error())
 		}
@@ -286,6 +965,21 @@ func CreateTablesIfNotExist(cfg types.ConfigDB) {
 		if err := CreateTableWorkLoadProcessFileSetSQLite(cfg); err != nil {
 			log.Error().Msg(err.Error())
 		}
+		if err := CreateTableWorkLoadProcessRuleStatsSQLite(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTableRuleStagingSQLite(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTableWorkloadStateSQLite(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTableAnomalySQLite(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
+		if err := CreateTablePolicyGapSQLite(cfg); err != nil {
+			log.Error().Msg(err.Error())
+		}
 		if err := CreateTableSystemLogsSQLite(cfg); err != nil {
 			log.Error().Msg(err.Error())
 		}
@@ -315,13 +1009,18 @@ func UpdateOrInsertKubearmorLogs(cfg types.ConfigDB, kubearmorLogMap map[types.K
 }
 
 func GetKubearmorLogs(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]types.KubeArmorLog, []uint32, error) {
+	return GetKubearmorLogsSince(cfg, filterLog, 0)
+}
+
+// GetKubearmorLogsSince returns the logs matching the filter updated since the time
+func GetKubearmorLogsSince(cfg types.ConfigDB, filterLog types.KubeArmorLog, since int64) ([]types.KubeArmorLog, []uint32, error) {
 	kubearmorLog := []types.KubeArmorLog{}
 	totalCount := []uint32{}
 	var err = errors.New("unknown db driver")
 	if cfg.DBDriver == "mysql" {
-		kubearmorLog, totalCount, err = GetSystemLogsMySQL(cfg, filterLog)
+		kubearmorLog, totalCount, err = GetSystemLogsMySQL(cfg, filterLog, since)
 	} else if cfg.DBDriver == "sqlite3" {
-		kubearmorLog, totalCount, err = GetSystemLogsSQLite(cfg, filterLog)
+		kubearmorLog, totalCount, err = GetSystemLogsSQLite(cfg, filterLog, since)
 	}
 	return kubearmorLog, totalCount, err
 }
@@ -337,13 +1036,19 @@ func UpdateOrInsertCiliumLogs(cfg types.ConfigDB, ciliumLogs []types.CiliumLog)
 }
 
 func GetCiliumLogs(cfg types.ConfigDB, ciliumFilter types.CiliumLog) ([]types.CiliumLog, []uint32, error) {
+	return GetCiliumLogsSince(cfg, ciliumFilter, "", 0)
+}
+
+// GetCiliumLogsSince returns the logs matching the filter updated since the time,
+// from or to the namespace if given
+func GetCiliumLogsSince(cfg types.ConfigDB, ciliumFilter types.CiliumLog, namespace string, since int64) ([]types.CiliumLog, []uint32, error) {
 	ciliumLogs := []types.CiliumLog{}
 	ciliumTotalCount := []uint32{}
 	var err = errors.New("unknown db driver")
 	if cfg.DBDriver == "mysql" {
-		ciliumLogs, ciliumTotalCount, err = GetCiliumLogsMySQL(cfg, ciliumFilter)
+		ciliumLogs, ciliumTotalCount, err = GetCiliumLogsMySQL(cfg, ciliumFilter, namespace, since)
 	} else if cfg.DBDriver == "sqlite3" {
-		ciliumLogs, ciliumTotalCount, err = GetCiliumLogsSQLite(cfg, ciliumFilter)
+		ciliumLogs, ciliumTotalCount, err = GetCiliumLogsSQLite(cfg, ciliumFilter, namespace, since)
 	}
 	return ciliumLogs, ciliumTotalCount, err
 }
@@ -400,6 +1105,110 @@ func DeletePolicyBasedOnPolicyName(cfg types.ConfigDB, policyName, namespace, la
 	return err
 }
 
+// RenamePolicy renames the policy in the policy table of the given type and in the
+// policy yaml table. The references to the old name in the outdated column are
+// updated as well so that the history of the policy is preserved.
+func RenamePolicy(cfg types.ConfigDB, policyType, oldName, newName string) error {
+	var db *sql.DB
+	var policyTable, yamlTable string
+
+	if cfg.DBDriver == "mysql" {
+		db = connectMySQL(cfg)
+		policyTable, yamlTable = TableNetworkPolicy_TableName, PolicyYaml_TableName
+		if policyType == types.PolicyTypeSystem {
+			policyTable = TableSystemPolicy_TableName
+		}
+	} else if cfg.DBDriver == "sqlite3" {
+		db = connectSQLite(cfg, cfg.SQLiteDBPath)
+		policyTable, yamlTable = TableNetworkPolicySQLite_TableName, PolicyYamlSQLite_TableName
+		if policyType == types.PolicyTypeSystem {
+			policyTable = TableSystemPolicySQLite_TableName
+		}
+	} else {
+		return errors.New("unknown db driver")
+	}
+	defer db.Close()
+
+	return renamePolicySQL(db, policyTable, yamlTable, oldName, newName)
+}
+
+func renamePolicySQL(db *sql.DB, policyTable, yamlTable, oldName, newName string) error {
+	queries := []struct {
+		query string
+		args  []interface{}
+	}{
+		{"UPDATE " + policyTable + " SET name=? WHERE name=?", []interface{}{newName, oldName}},
+		{"UPDATE " + policyTable + " SET outdated=? WHERE outdated=?", []interface{}{newName, oldName}},
+	}
+
+	for _, q := range queries {
+		stmt, err := db.Prepare(q.query)
+		if err != nil {
+			return err
+		}
+
+		_, err = stmt.Exec(q.args...)
+		stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	// the yaml is rewritten as a whole, the rows are read out first
+	rows, err := db.Query("SELECT id,policy_yaml FROM "+yamlTable+" WHERE policy_name=?", oldName)
+	if err != nil {
+		return err
+	}
+
+	policyYamls := map[int][]byte{}
+	for rows.Next() {
+		var id int
+		var policyYaml []byte
+		if err := rows.Scan(&id, &policyYaml); err != nil {
+			rows.Close()
+			return err
+		}
+		policyYamls[id] = policyYaml
+	}
+	rows.Close()
+
+	for id, policyYaml := range policyYamls {
+		renamed, err := renamePolicyYaml(policyYaml, newName)
+		if err != nil {
+			return err
+		}
+
+		stmt, err := db.Prepare("UPDATE " + yamlTable + " SET policy_name=?, policy_yaml=? WHERE id=?")
+		if err != nil {
+			return err
+		}
+
+		_, err = stmt.Exec(newName, renamed, id)
+		stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renamePolicyYaml sets the metadata.name of the policy yaml
+func renamePolicyYaml(policyYaml []byte, name string) ([]byte, error) {
+	policy := map[string]interface{}{}
+	if err := yaml.Unmarshal(policyYaml, &policy); err != nil {
+		return nil, err
+	}
+
+	metadata, ok := policy["metadata"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("no metadata in the policy yaml")
+	}
+	metadata["name"] = name
+
+	return yaml.Marshal(policy)
+}
+
 // ============= //
 // == Summary == //
 // ============= //
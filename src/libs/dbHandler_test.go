@@ -57,6 +57,46 @@ func TestGetNetworkPolicies(t *testing.T) {
 	}
 }
 
+func TestGetNetworkPolicyByName(t *testing.T) {
+	// prepare mock mysql
+	_, mock := NewMock()
+
+	specPtr := &types.Spec{}
+	spec, _ := json.Marshal(specPtr)
+
+	flowIDsPrt := &[]string{}
+	flowID, _ := json.Marshal(flowIDsPrt)
+
+	rows := mock.NewRows([]string{
+		"apiVersion",    // str
+		"kind",          // str
+		"flow_ids",      // []byte
+		"name",          // str
+		"cluster_name",  // str
+		"namespace",     // str
+		"type",          // str
+		"rule",          // str
+		"status",        // str
+		"outdated",      // str
+		"spec",          // []byte
+		"generatedTime", // uint64
+		"updatedTime",   // uint64
+	}).
+		AddRow("", "test", flowID, "autopol-egress-test", "", "", "", "", "latest", "", spec, 0, 0)
+
+	mock.ExpectQuery("^SELECT (.+) FROM network_policy WHERE name = (.+) and status = (.+)").
+		WithArgs("autopol-egress-test", "latest").
+		WillReturnRows(rows)
+
+	policy, ok := GetNetworkPolicyByName(types.ConfigDB{DBDriver: "mysql"}, "autopol-egress-test", "latest")
+	assert.True(t, ok)
+	assert.Equal(t, "autopol-egress-test", policy.Metadata["name"])
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf(Unmet+"%s", err)
+	}
+}
+
 func TestInsertNetworkPolicies(t *testing.T) {
 	// prepare mock mysql
 	_, mock := NewMock()
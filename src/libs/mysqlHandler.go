@@ -20,6 +20,11 @@ const TableSystemPolicy_TableName = "system_policy"
 const TableSystemLogs_TableName = "system_logs"
 const TableNetworkLogs_TableName = "network_logs"
 const PolicyYaml_TableName = "policy_yaml"
+const WorkloadProcessRuleStats_TableName = "workload_process_rulestats"
+const RuleStaging_TableName = "rule_staging"
+const WorkloadState_TableName = "workload_state"
+const Anomaly_TableName = "anomaly"
+const PolicyGap_TableName = "policy_gap"
 
 // ================ //
 // == Connection == //
@@ -81,7 +86,6 @@ func GetNetworkPoliciesFromMySQL(cfg types.ConfigDB, cluster, namespace, status,
 	db := connectMySQL(cfg)
 	defer db.Close()
 
-	policies := []types.KnoxNetworkPolicy{}
 	var results *sql.Rows
 	var err error
 
@@ -119,6 +123,13 @@ func GetNetworkPoliciesFromMySQL(cfg types.ConfigDB, cluster, namespace, status,
 		return nil, err
 	}
 
+	return scanNetworkPolicies(results)
+}
+
+// scanNetworkPolicies reads the network policies from the rows of the query
+func scanNetworkPolicies(results *sql.Rows) ([]types.KnoxNetworkPolicy, error) {
+	policies := []types.KnoxNetworkPolicy{}
+
 	for results.Next() {
 		policy := types.KnoxNetworkPolicy{}
 
@@ -173,6 +184,24 @@ func GetNetworkPoliciesFromMySQL(cfg types.ConfigDB, cluster, namespace, status,
 	return policies, nil
 }
 
+// GetNetworkPolicyByNameFromMySQL returns the network policies of the name
+func GetNetworkPolicyByNameFromMySQL(cfg types.ConfigDB, name, status string) ([]types.KnoxNetworkPolicy, error) {
+	db := connectMySQL(cfg)
+	defer db.Close()
+
+	query := "SELECT apiVersion,kind,flow_ids,name,cluster_name,namespace,type,rule,status,outdated,spec,generatedTime,updatedTime FROM " + TableNetworkPolicy_TableName +
+		" WHERE name = ? and status = ?"
+
+	results, err := db.Query(query, name, status)
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return nil, err
+	}
+	defer results.Close()
+
+	return scanNetworkPolicies(results)
+}
+
 func UpdateNetworkPolicyToMySQL(cfg types.ConfigDB, policy types.KnoxNetworkPolicy) error {
 	db := connectMySQL(cfg)
 	defer db.Close()
@@ -525,6 +554,31 @@ func ClearDBTablesMySQL(cfg types.ConfigDB) error {
 		return err
 	}
 
+	query = "DELETE FROM " + WorkloadProcessRuleStats_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + RuleStaging_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + WorkloadState_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + Anomaly_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + PolicyGap_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -538,7 +592,7 @@ func CreateTableNetworkPolicyMySQL(cfg types.ConfigDB) error {
 		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
 			"	`id` int NOT NULL AUTO_INCREMENT," +
 			"	`apiVersion` varchar(20) DEFAULT NULL," +
-			"	`kind` varchar(20) DEFAULT NULL," +
+			"	`kind` varchar(50) DEFAULT NULL," +
 			"	`flow_ids` JSON DEFAULT NULL," +
 			"	`name` varchar(50) DEFAULT NULL," +
 			"	`cluster_name` varchar(50) DEFAULT NULL," +
@@ -557,6 +611,11 @@ func CreateTableNetworkPolicyMySQL(cfg types.ConfigDB) error {
 		return err
 	}
 
+	// the tables created before have the kind too short for the clusterwide/host policies
+	if _, err := db.Exec("ALTER TABLE `" + tableName + "` MODIFY `kind` varchar(50) DEFAULT NULL"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -617,6 +676,122 @@ func CreateTableWorkLoadProcessFileSetMySQL(cfg types.ConfigDB) error {
 	return err
 }
 
+func CreateTableWorkLoadProcessRuleStatsMySQL(cfg types.ConfigDB) error {
+	db := connectMySQL(cfg)
+	defer db.Close()
+
+	tableName := WorkloadProcessRuleStats_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` int NOT NULL AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"   `containerName` varchar(100) NOT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`fromSource` varchar(256) DEFAULT NULL," +
+			"	`settype` varchar(16) DEFAULT NULL," +
+			"	`rulestats` text DEFAULT NULL," + // json, key: path|dir|pattern of the fileset
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Query(query)
+	return err
+}
+
+func CreateTableRuleStagingMySQL(cfg types.ConfigDB) error {
+	db := connectMySQL(cfg)
+	defer db.Close()
+
+	tableName := RuleStaging_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` int NOT NULL AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`ruleKey` varchar(64) NOT NULL," +
+			"	`candidate` text DEFAULT NULL," +
+			"	`rulestats` text DEFAULT NULL," +
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Query(query)
+	return err
+}
+
+func CreateTableAnomalyMySQL(cfg types.ConfigDB) error {
+	db := connectMySQL(cfg)
+	defer db.Close()
+
+	tableName := Anomaly_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` int NOT NULL AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`ruleKey` varchar(64) NOT NULL," +
+			"	`status` varchar(16) DEFAULT NULL," + // pending|acknowledged
+			"	`anomaly` text DEFAULT NULL," + // json
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Query(query)
+	return err
+}
+
+func CreateTablePolicyGapMySQL(cfg types.ConfigDB) error {
+	db := connectMySQL(cfg)
+	defer db.Close()
+
+	tableName := PolicyGap_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` int NOT NULL AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`ruleKey` varchar(64) NOT NULL," +
+			"	`gap` text DEFAULT NULL," + // json
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Query(query)
+	return err
+}
+
+func CreateTableWorkloadStateMySQL(cfg types.ConfigDB) error {
+	db := connectMySQL(cfg)
+	defer db.Close()
+
+	tableName := WorkloadState_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` int NOT NULL AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`state` text DEFAULT NULL," + // json
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Query(query)
+	return err
+}
+
 func CreateTableSystemLogsMySQL(cfg types.ConfigDB) error {
 	db := connectMySQL(cfg)
 	defer db.Close()
@@ -644,11 +819,20 @@ func CreateTableSystemLogsMySQL(cfg types.ConfigDB) error {
 			"	`start_time` bigint DEFAULT NULL," +
 			"	`updated_time` bigint DEFAULT NULL," +
 			"	`result` varchar(100) DEFAULT NULL," +
-			"	`total` INTEGER	" +
+			"	`total` INTEGER," +
+			"	`process_name` varchar(250) DEFAULT ''," +
+			"	`parent_process_name` varchar(250) DEFAULT ''" +
 			"  );"
 
-	_, err := db.Query(query)
-	return err
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	// the tables created before keep the process lineage as well
+	return addColumnsIfNotExist(db, tableName, map[string]string{
+		"process_name":        "varchar(250) DEFAULT ''",
+		"parent_process_name": "varchar(250) DEFAULT ''",
+	})
 }
 
 func CreateTableNetworkLogsMySQL(cfg types.ConfigDB) error {
@@ -744,6 +928,26 @@ func concatWhereClause(whereClause *string, field string) {
 	*whereClause = *whereClause + field + " = ?"
 }
 
+// concatWhereClauseSince adds the condition of the field not before the value
+func concatWhereClauseSince(whereClause *string, field string) {
+	if *whereClause == "" {
+		*whereClause = " WHERE "
+	} else {
+		*whereClause = *whereClause + " and "
+	}
+	*whereClause = *whereClause + field + " >= ?"
+}
+
+// concatWhereClauseAnyOf adds the condition of any of the fields equal to the value
+func concatWhereClauseAnyOf(whereClause *string, fields ...string) {
+	if *whereClause == "" {
+		*whereClause = " WHERE "
+	} else {
+		*whereClause = *whereClause + " and "
+	}
+	*whereClause = *whereClause + "(" + strings.Join(fields, " = ? or ") + " = ?)"
+}
+
 func concatWhereClauseIntRange(whereClause *string, field string, start int64, end int64) {
 	if *whereClause == "" {
 		*whereClause = " WHERE "
@@ -831,7 +1035,8 @@ func GetWorkloadProcessFileSetMySQL(cfg types.ConfigDB, wpfs types.WorkloadProce
 func InsertWorkloadProcessFileSetMySQL(cfg types.ConfigDB, wpfs types.WorkloadProcessFileSet, fs []string) error {
 	db := connectMySQL(cfg)
 	defer db.Close()
-	policyName := "autopol-" + strings.ToLower(wpfs.SetType) + "-" + RandSeq(15)
+	policyName := "autopol-" + strings.ToLower(wpfs.SetType) + "-" +
+		PolicyNameHash(wpfs.ClusterName, wpfs.Namespace, wpfs.ContainerName, wpfs.Labels, wpfs.FromSource)
 	time := ConvertStrToUnixTime("now")
 
 	stmt, err := db.Prepare("INSERT INTO " + WorkloadProcessFileSet_TableName +
@@ -955,7 +1160,8 @@ func UpdateOrInsertKubearmorLogsMySQL(cfg types.ConfigDB, kubearmorlogmap map[ty
 
 func updateOrInsertKubearmorLogsMySQL(db *sql.DB, kubearmorlog types.KubeArmorLog, count int) error {
 	queryString := `cluster_name = ? and namespace_name = ? and pod_name = ? and container_name = ? and operation = ? and labels = ? 
-					and data = ? and category = ? and action = ? and result = ? and source = ? and resource = ?`
+					and data = ? and category = ? and action = ? and result = ? and source = ? and resource = ?
+					and process_name = ? and parent_process_name = ?`
 
 	query := "UPDATE " + TableSystemLogs_TableName + " SET total=total+?, updated_time=? WHERE " + queryString + " "
 
@@ -980,6 +1186,8 @@ func updateOrInsertKubearmorLogsMySQL(db *sql.DB, kubearmorlog types.KubeArmorLo
 		kubearmorlog.Result,
 		kubearmorlog.Source,
 		kubearmorlog.Resource,
+		kubearmorlog.ProcessName,
+		kubearmorlog.ParentProcessName,
 	)
 	if err != nil {
 		log.Error().Msg(err.Error())
@@ -991,7 +1199,7 @@ func updateOrInsertKubearmorLogsMySQL(db *sql.DB, kubearmorlog types.KubeArmorLo
 	if err == nil && rowsAffected == 0 {
 
 		updateQueryString := `(cluster_name,namespace_name,pod_name,container_name,operation,labels,data,category,action,
-		updated_time,result,total,source,resource) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+		updated_time,result,total,source,resource,process_name,parent_process_name) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 		updateQuery := "INSERT INTO " + TableSystemLogs_TableName + updateQueryString
 
@@ -1015,7 +1223,9 @@ func updateOrInsertKubearmorLogsMySQL(db *sql.DB, kubearmorlog types.KubeArmorLo
 			kubearmorlog.Result,
 			count,
 			kubearmorlog.Source,
-			kubearmorlog.Resource)
+			kubearmorlog.Resource,
+			kubearmorlog.ProcessName,
+			kubearmorlog.ParentProcessName)
 		if err != nil {
 			log.Error().Msg(err.Error())
 			return err
@@ -1026,7 +1236,7 @@ func updateOrInsertKubearmorLogsMySQL(db *sql.DB, kubearmorlog types.KubeArmorLo
 }
 
 // GetSystemLogsMySQL
-func GetSystemLogsMySQL(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]types.KubeArmorLog, []uint32, error) {
+func GetSystemLogsMySQL(cfg types.ConfigDB, filterLog types.KubeArmorLog, since int64) ([]types.KubeArmorLog, []uint32, error) {
 	db := connectMySQL(cfg)
 	defer db.Close()
 
@@ -1100,6 +1310,11 @@ func GetSystemLogsMySQL(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]typ
 		args = append(args, filterLog.Resource)
 	}
 
+	if since != 0 {
+		concatWhereClauseSince(&whereClause, "updated_time")
+		args = append(args, since)
+	}
+
 	results, err = db.Query(query+whereClause, args...)
 	if err != nil {
 		log.Error().Msg(err.Error())
@@ -1137,7 +1352,7 @@ func GetSystemLogsMySQL(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]typ
 }
 
 // GetNetworkLogsMySQL
-func GetCiliumLogsMySQL(cfg types.ConfigDB, filterLog types.CiliumLog) ([]types.CiliumLog, []uint32, error) {
+func GetCiliumLogsMySQL(cfg types.ConfigDB, filterLog types.CiliumLog, namespace string, since int64) ([]types.CiliumLog, []uint32, error) {
 	db := connectMySQL(cfg)
 	defer db.Close()
 
@@ -1324,6 +1539,15 @@ func GetCiliumLogsMySQL(cfg types.ConfigDB, filterLog types.CiliumLog) ([]types.
 		args = append(args, filterLog.Total)
 	}
 
+	if namespace != "" {
+		concatWhereClauseAnyOf(&whereClause, "source_namespace", "destination_namespace")
+		args = append(args, namespace, namespace)
+	}
+	if since != 0 {
+		concatWhereClauseSince(&whereClause, "updated_time")
+		args = append(args, since)
+	}
+
 	results, err = db.Query(query+whereClause, args...)
 
 	if err != nil {
@@ -0,0 +1,32 @@
+package libs
+
+import (
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ================= //
+// == Policy Gaps == //
+// ================= //
+
+// RecordPolicyGap records the candidate rule of the dropped or blocked traffic,
+// the observations of the same rule are merged
+func RecordPolicyGap(cfg types.ConfigDB, gap types.PolicyGap) {
+	exists, err := GetPolicyGaps(cfg, types.PolicyGap{PolicyType: gap.PolicyType, RuleKey: gap.RuleKey})
+	if err != nil {
+		log.Error().Msgf("could not fetch the policy gaps err=%s", err.Error())
+		return
+	}
+
+	if len(exists) > 0 {
+		gap.RuleStats = MergeRuleStats(exists[0].RuleStats, gap.RuleStats)
+	} else {
+		log.Info().Msgf("policy gap of the workload %s/%s: %s", gap.Namespace, gap.Labels, gap.Candidate)
+	}
+	if len(gap.RuleStats.Evidence) > 0 {
+		gap.Event = gap.RuleStats.Evidence[len(gap.RuleStats.Evidence)-1]
+	}
+
+	if err := UpdatePolicyGap(cfg, gap); err != nil {
+		log.Error().Msgf("could not record the policy gap err=%s", err.Error())
+	}
+}
@@ -0,0 +1,67 @@
+package libs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"sigs.k8s.io/yaml"
+)
+
+var yamlDocSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// ReadPolicyYamlFiles reads the policy objects in the yaml files of the directory
+func ReadPolicyYamlFiles(dir string) ([]types.AppliedPolicy, error) {
+	results := []types.AppliedPolicy{}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		if file.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Clean(filepath.Join(dir, file.Name())))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range yamlDocSeparator.Split(string(data), -1) {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+
+			obj := struct {
+				Kind     string `json:"kind"`
+				Metadata struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+				Spec map[string]interface{} `json:"spec"`
+			}{}
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+				log.Warn().Msgf("failed to parse the policy in %s: %s", file.Name(), err.Error())
+				continue
+			}
+
+			if obj.Kind == "" {
+				continue
+			}
+
+			results = append(results, types.AppliedPolicy{
+				Kind:      obj.Kind,
+				Name:      obj.Metadata.Name,
+				Namespace: obj.Metadata.Namespace,
+				Spec:      obj.Spec,
+			})
+		}
+	}
+
+	return results, nil
+}
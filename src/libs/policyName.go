@@ -0,0 +1,146 @@
+package libs
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// DefaultPolicyNameTemplate is the template used to name the discovered policies.
+// Supported fields: {type}, {workload}, {namespace}, {cluster}, {hash}
+const DefaultPolicyNameTemplate = "autopol-{type}-{workload}-{hash}"
+
+// MaxPolicyNameLength is limited by the name/outdated columns of the policy tables
+const MaxPolicyNameLength = 50
+
+// PolicyNameFields holds the values substituted in the policy name template
+type PolicyNameFields struct {
+	Type      string // direction (ingress|egress) or operation (file|process|network|system)
+	Workload  string
+	Namespace string
+	Cluster   string
+	Hash      string
+}
+
+// well-known labels identifying the workload, in the order of preference
+var workloadLabelKeys = []string{
+	"app.kubernetes.io/name",
+	"app",
+	"k8s-app",
+	"name",
+	"kubernetes.io/hostname",
+}
+
+var invalidPolicyNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+var repeatedDashes = regexp.MustCompile(`-{2,}`)
+
+// GetWorkloadNameFromLabels returns a human-readable workload name from the
+// selector labels, e.g. app=frontend --> frontend
+func GetWorkloadNameFromLabels(labels map[string]string) string {
+	workload := ""
+
+	for _, key := range workloadLabelKeys {
+		if val, ok := labels[key]; ok && val != "" {
+			workload = val
+			break
+		}
+	}
+
+	if workload == "" {
+		keys := []string{}
+		for k, v := range labels {
+			if v != "" && k != types.KubeArmorContainerNameLabel {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		if len(keys) > 0 {
+			workload = labels[keys[0]]
+		} else {
+			workload = "all"
+		}
+	}
+
+	// container-scoped policy
+	if container, ok := labels[types.KubeArmorContainerNameLabel]; ok && container != workload {
+		workload = workload + "-" + container
+	}
+
+	return workload
+}
+
+// PolicyNameHash returns a short hash of the content identifying the policy
+func PolicyNameHash(content ...string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.Join(content, ",")))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+func sanitizePolicyName(name string) string {
+	name = strings.ToLower(name)
+	name = invalidPolicyNameChars.ReplaceAllString(name, "-")
+	name = repeatedDashes.ReplaceAllString(name, "-")
+	return strings.Trim(name, "-.")
+}
+
+// GeneratePolicyNameFromTemplate builds the policy name from the template. If the
+// name is too long, the workload part is shortened so that the hash is kept.
+func GeneratePolicyNameFromTemplate(template string, fields PolicyNameFields) string {
+	if template == "" {
+		template = DefaultPolicyNameTemplate
+	}
+
+	workload := sanitizePolicyName(fields.Workload)
+
+	for {
+		replacer := strings.NewReplacer(
+			"{type}", fields.Type,
+			"{workload}", workload,
+			"{namespace}", fields.Namespace,
+			"{cluster}", fields.Cluster,
+			"{hash}", fields.Hash,
+		)
+		name := sanitizePolicyName(replacer.Replace(template))
+
+		over := len(name) - MaxPolicyNameLength
+		if over <= 0 {
+			return name
+		}
+
+		if !strings.Contains(template, "{workload}") || len(workload) == 0 {
+			return strings.Trim(name[:MaxPolicyNameLength], "-.")
+		}
+
+		if over >= len(workload) {
+			workload = ""
+		} else {
+			workload = strings.Trim(workload[:len(workload)-over], "-.")
+		}
+	}
+}
+
+// ResolvePolicyNameCollision returns the name as is if not used yet, otherwise
+// appends the smallest numeric suffix making it unique
+func ResolvePolicyNameCollision(policyNamesMap map[string]bool, name string) string {
+	if !policyNamesMap[name] {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		suffix := "-" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > MaxPolicyNameLength {
+			base = strings.Trim(base[:MaxPolicyNameLength-len(suffix)], "-.")
+		}
+
+		if !policyNamesMap[base+suffix] {
+			return base + suffix
+		}
+	}
+}
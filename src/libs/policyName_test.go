@@ -0,0 +1,76 @@
+package libs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorkloadNameFromLabels(t *testing.T) {
+	assert.Equal(t, "frontend", GetWorkloadNameFromLabels(map[string]string{"app": "frontend", "tier": "web"}))
+	assert.Equal(t, "cart", GetWorkloadNameFromLabels(map[string]string{"tier": "cart", "zone": "a"}))
+	assert.Equal(t, "all", GetWorkloadNameFromLabels(map[string]string{}))
+	assert.Equal(t, "frontend-nginx", GetWorkloadNameFromLabels(map[string]string{
+		"app": "frontend", types.KubeArmorContainerNameLabel: "nginx"}))
+}
+
+func TestGeneratePolicyNameFromTemplate(t *testing.T) {
+	fields := PolicyNameFields{
+		Type:      "egress",
+		Workload:  "Frontend_App",
+		Namespace: "default",
+		Cluster:   "prod",
+		Hash:      PolicyNameHash("egress", "app=frontend", "default", "prod"),
+	}
+
+	name := GeneratePolicyNameFromTemplate("", fields)
+	assert.Equal(t, "autopol-egress-frontend-app-"+fields.Hash, name)
+
+	// the name is stable
+	assert.Equal(t, name, GeneratePolicyNameFromTemplate(DefaultPolicyNameTemplate, fields))
+
+	name = GeneratePolicyNameFromTemplate("{namespace}-{workload}-{type}", fields)
+	assert.Equal(t, "default-frontend-app-egress", name)
+}
+
+func TestGeneratePolicyNameFromTemplate_Truncate(t *testing.T) {
+	fields := PolicyNameFields{
+		Type:     "ingress",
+		Workload: strings.Repeat("verylongworkload", 5),
+		Hash:     "0123abcd",
+	}
+
+	name := GeneratePolicyNameFromTemplate("", fields)
+	assert.Len(t, name, MaxPolicyNameLength)
+	assert.True(t, strings.HasPrefix(name, "autopol-ingress-verylongworkload"))
+	assert.True(t, strings.HasSuffix(name, "-0123abcd"))
+}
+
+func TestResolvePolicyNameCollision(t *testing.T) {
+	policyNamesMap := map[string]bool{}
+
+	assert.Equal(t, "autopol-egress-web-1", ResolvePolicyNameCollision(policyNamesMap, "autopol-egress-web-1"))
+
+	policyNamesMap["autopol-egress-web-1"] = true
+	policyNamesMap["autopol-egress-web-1-2"] = true
+	assert.Equal(t, "autopol-egress-web-1-3", ResolvePolicyNameCollision(policyNamesMap, "autopol-egress-web-1"))
+
+	long := strings.Repeat("a", MaxPolicyNameLength)
+	policyNamesMap[long] = true
+	resolved := ResolvePolicyNameCollision(policyNamesMap, long)
+	assert.Len(t, resolved, MaxPolicyNameLength)
+	assert.True(t, strings.HasSuffix(resolved, "-2"))
+}
+
+func TestRenamePolicyYaml(t *testing.T) {
+	policyYaml := []byte("apiVersion: cilium.io/v2\nkind: CiliumNetworkPolicy\nmetadata:\n  name: autopol-1\n  namespace: default\nspec:\n  description: follows autopol-12\n")
+
+	renamed, err := renamePolicyYaml(policyYaml, "autopol-egress-web-abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "apiVersion: cilium.io/v2\nkind: CiliumNetworkPolicy\nmetadata:\n  name: autopol-egress-web-abc\n  namespace: default\nspec:\n  description: follows autopol-12\n", string(renamed))
+
+	_, err = renamePolicyYaml([]byte("kind: CiliumNetworkPolicy\n"), "autopol-egress-web-abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,242 @@
+package libs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// MaxRuleEvidence is the number of the log samples kept per rule
+const MaxRuleEvidence = 3
+
+// MaxRulePods is the number of the distinct pods kept per rule, the pods of a
+// widely used rule (e.g. kube-dns) are not tracked beyond it
+const MaxRulePods = 10
+
+// NewRuleStats returns the statistics of a rule observed now
+func NewRuleStats(hits int64) types.RuleStats {
+	now := time.Now().Unix()
+	return types.RuleStats{
+		FirstSeen: now,
+		LastSeen:  now,
+		HitCount:  hits,
+	}
+}
+
+// MergeRuleStats merges the statistics of the same rule. If the rule is seen
+// again, it is not stale anymore.
+func MergeRuleStats(exist, new types.RuleStats) types.RuleStats {
+	merged := exist
+
+	if merged.FirstSeen == 0 || (new.FirstSeen != 0 && new.FirstSeen < merged.FirstSeen) {
+		merged.FirstSeen = new.FirstSeen
+	}
+	if new.LastSeen > merged.LastSeen {
+		merged.LastSeen = new.LastSeen
+	}
+	merged.HitCount += new.HitCount
+	merged.Pods = MergeRulePods(exist.Pods, new.Pods)
+	merged.Evidence = MergeRuleEvidence(exist.Evidence, new.Evidence)
+
+	if new.HitCount > 0 {
+		merged.Stale = false
+		merged.Pruned = false
+	}
+
+	return merged
+}
+
+// UpdateRuleStaleness flags the rule not seen within the window (seconds) as
+// stale, and prunes it as well if the action is drop. The rules discovered
+// before the tracking have no timestamps, those are considered as seen now.
+// It returns true if the rule is changed.
+func UpdateRuleStaleness(stats *types.RuleStats, now, window int64, action string) bool {
+	if window <= 0 {
+		return false
+	}
+
+	if stats.LastSeen == 0 {
+		stats.FirstSeen = now
+		stats.LastSeen = now
+		return true
+	}
+
+	if now-stats.LastSeen <= window {
+		return false
+	}
+
+	pruned := action == types.StaleRuleActionDrop
+	if stats.Stale && stats.Pruned == pruned {
+		return false
+	}
+
+	stats.Stale = true
+	stats.Pruned = pruned
+
+	return true
+}
+
+// ParseStaleRuleWindow converts the window duration (e.g. 720h) into seconds,
+// 0 if the window is not set or invalid
+func ParseStaleRuleWindow(window string) int64 {
+	if window == "" || window == "0" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(window)
+	if err != nil {
+		log.Error().Msgf("invalid stale rule window %s err=%s", window, err.Error())
+		return 0
+	}
+
+	return int64(duration.Seconds())
+}
+
+// CombineRuleStats combines the statistics of the different rules folded into
+// one, e.g. the paths aggregated into a directory. The combined rule is stale
+// only if all the rules are stale.
+func CombineRuleStats(a, b types.RuleStats) types.RuleStats {
+	if isEmptyRuleStats(a) {
+		return b
+	}
+	if isEmptyRuleStats(b) {
+		return a
+	}
+
+	combined := MergeRuleStats(a, types.RuleStats{
+		FirstSeen: b.FirstSeen,
+		LastSeen:  b.LastSeen,
+		HitCount:  b.HitCount,
+		Pods:      b.Pods,
+		Evidence:  b.Evidence,
+	})
+	combined.Stale = a.Stale && b.Stale
+	combined.Pruned = a.Pruned && b.Pruned
+
+	return combined
+}
+
+func isEmptyRuleStats(stats types.RuleStats) bool {
+	return stats.FirstSeen == 0 && stats.LastSeen == 0 && stats.HitCount == 0 &&
+		len(stats.Pods) == 0 && len(stats.Evidence) == 0
+}
+
+// MergeRuleEvidence appends the new samples, and keeps the latest MaxRuleEvidence ones
+func MergeRuleEvidence(exist, new []types.RuleEvidence) []types.RuleEvidence {
+	if len(new) == 0 {
+		return exist
+	}
+
+	merged := append(append([]types.RuleEvidence{}, exist...), new...)
+	if len(merged) > MaxRuleEvidence {
+		merged = merged[len(merged)-MaxRuleEvidence:]
+	}
+
+	return merged
+}
+
+// RuleKey returns the key of the candidate rule built from its identifying parts
+func RuleKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// MergeRulePods returns the sorted union of the pods exhibiting the rule, up
+// to MaxRulePods pods
+func MergeRulePods(exist, new []string) []string {
+	if len(new) == 0 || len(exist) >= MaxRulePods {
+		return exist
+	}
+
+	merged := append([]string{}, exist...)
+	for _, pod := range new {
+		if len(merged) >= MaxRulePods {
+			break
+		}
+		if pod != "" && !ContainsElement(merged, pod) {
+			merged = append(merged, pod)
+		}
+	}
+	sort.Strings(merged)
+
+	return merged
+}
+
+// NewRuleThreshold returns the thresholds of the rule observations, the time
+// span is the duration (e.g. 24h). The pods are not tracked beyond MaxRulePods,
+// so is the threshold.
+func NewRuleThreshold(minCount, minPods int, minSpan string) types.RuleThreshold {
+	if minPods > MaxRulePods {
+		log.Warn().Msgf("rule threshold min-pods %d exceeds %d, lowered to %d", minPods, MaxRulePods, MaxRulePods)
+		minPods = MaxRulePods
+	}
+
+	threshold := types.RuleThreshold{
+		MinCount: int64(minCount),
+		MinPods:  minPods,
+	}
+
+	if minSpan != "" && minSpan != "0" {
+		duration, err := time.ParseDuration(minSpan)
+		if err != nil {
+			log.Error().Msgf("invalid rule threshold span %s err=%s", minSpan, err.Error())
+		} else {
+			threshold.MinSpan = int64(duration.Seconds())
+		}
+	}
+
+	return threshold
+}
+
+// NewRuleTypeThresholds returns the thresholds of the rule types overriding the default ones
+func NewRuleTypeThresholds(cfgThresholds map[string]types.RuleThresholdConfig) map[string]types.RuleThreshold {
+	thresholds := map[string]types.RuleThreshold{}
+	for ruleType, threshold := range cfgThresholds {
+		thresholds[strings.ToLower(ruleType)] = NewRuleThreshold(threshold.MinCount, threshold.MinPods, threshold.MinSpan)
+	}
+	return thresholds
+}
+
+// GetRuleThreshold returns the thresholds of the rule type, the default ones if not overridden
+func GetRuleThreshold(threshold types.RuleThreshold, ruleTypeThresholds map[string]types.RuleThreshold, ruleType string) types.RuleThreshold {
+	if typeThreshold, ok := ruleTypeThresholds[strings.ToLower(ruleType)]; ok {
+		return typeThreshold
+	}
+	return threshold
+}
+
+// IsRuleThresholdEnabled returns true if any threshold is set
+func IsRuleThresholdEnabled(threshold types.RuleThreshold) bool {
+	return threshold.MinCount > 1 || threshold.MinPods > 1 || threshold.MinSpan > 0
+}
+
+// IsAnyRuleThresholdEnabled returns true if any threshold is set, by default or for a rule type
+func IsAnyRuleThresholdEnabled(threshold types.RuleThreshold, ruleTypeThresholds map[string]types.RuleThreshold) bool {
+	if IsRuleThresholdEnabled(threshold) {
+		return true
+	}
+	for _, typeThreshold := range ruleTypeThresholds {
+		if IsRuleThresholdEnabled(typeThreshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// MeetsRuleThreshold returns true if the rule is observed enough to be proposed
+func MeetsRuleThreshold(stats types.RuleStats, threshold types.RuleThreshold) bool {
+	if stats.HitCount < threshold.MinCount {
+		return false
+	}
+	if len(stats.Pods) < threshold.MinPods {
+		return false
+	}
+	if stats.LastSeen-stats.FirstSeen < threshold.MinSpan {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package libs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRuleStats(t *testing.T) {
+	exist := types.RuleStats{FirstSeen: 100, LastSeen: 200, HitCount: 3, Stale: true, Pruned: true}
+	merged := MergeRuleStats(exist, types.RuleStats{FirstSeen: 300, LastSeen: 300, HitCount: 2})
+
+	assert.Equal(t, types.RuleStats{FirstSeen: 100, LastSeen: 300, HitCount: 5}, merged)
+}
+
+func TestCombineRuleStats(t *testing.T) {
+	a := types.RuleStats{FirstSeen: 100, LastSeen: 200, HitCount: 3, Stale: true, Pruned: true}
+	b := types.RuleStats{FirstSeen: 50, LastSeen: 150, HitCount: 1, Stale: true}
+
+	assert.Equal(t, types.RuleStats{FirstSeen: 50, LastSeen: 200, HitCount: 4, Stale: true}, CombineRuleStats(a, b))
+	assert.Equal(t, a, CombineRuleStats(types.RuleStats{}, a))
+}
+
+func TestUpdateRuleStaleness(t *testing.T) {
+	now := int64(10000)
+
+	// disabled
+	stats := types.RuleStats{LastSeen: 100}
+	assert.False(t, UpdateRuleStaleness(&stats, now, 0, types.StaleRuleActionDrop))
+
+	// rule discovered before the tracking
+	stats = types.RuleStats{}
+	assert.True(t, UpdateRuleStaleness(&stats, now, 3600, types.StaleRuleActionDrop))
+	assert.Equal(t, now, stats.LastSeen)
+
+	// seen within the window
+	stats = types.RuleStats{LastSeen: now - 60}
+	assert.False(t, UpdateRuleStaleness(&stats, now, 3600, types.StaleRuleActionDrop))
+
+	// flagged only
+	stats = types.RuleStats{LastSeen: 100}
+	assert.True(t, UpdateRuleStaleness(&stats, now, 3600, types.StaleRuleActionFlag))
+	assert.True(t, stats.Stale)
+	assert.False(t, stats.Pruned)
+	assert.False(t, UpdateRuleStaleness(&stats, now, 3600, types.StaleRuleActionFlag))
+
+	// dropped
+	assert.True(t, UpdateRuleStaleness(&stats, now, 3600, types.StaleRuleActionDrop))
+	assert.True(t, stats.Pruned)
+}
+
+func TestParseStaleRuleWindow(t *testing.T) {
+	assert.Equal(t, int64(0), ParseStaleRuleWindow(""))
+	assert.Equal(t, int64(0), ParseStaleRuleWindow("0"))
+	assert.Equal(t, int64(0), ParseStaleRuleWindow("invalid"))
+	assert.Equal(t, int64(720*3600), ParseStaleRuleWindow("720h"))
+}
+
+func TestMergeRuleEvidence(t *testing.T) {
+	exist := []types.RuleEvidence{{FlowID: 1}, {FlowID: 2}}
+
+	assert.Equal(t, exist, MergeRuleEvidence(exist, nil))
+	assert.Equal(t, []types.RuleEvidence{{FlowID: 2}, {FlowID: 3}, {FlowID: 4}},
+		MergeRuleEvidence(exist, []types.RuleEvidence{{FlowID: 3}, {FlowID: 4}}))
+
+	merged := MergeRuleStats(types.RuleStats{Evidence: exist}, types.RuleStats{Evidence: []types.RuleEvidence{{FlowID: 3}}})
+	assert.Len(t, merged.Evidence, MaxRuleEvidence)
+}
+
+func TestMergeRulePods(t *testing.T) {
+	assert.Equal(t, []string{"a"}, MergeRulePods([]string{"a"}, nil))
+	assert.Equal(t, []string{"a", "b", "c"}, MergeRulePods([]string{"c", "a"}, []string{"b", "a", ""}))
+}
+
+func TestMeetsRuleThreshold(t *testing.T) {
+	threshold := NewRuleThreshold(3, 2, "1h")
+	assert.Equal(t, types.RuleThreshold{MinCount: 3, MinPods: 2, MinSpan: 3600}, threshold)
+	assert.True(t, IsRuleThresholdEnabled(threshold))
+	assert.False(t, IsRuleThresholdEnabled(NewRuleThreshold(1, 0, "0")))
+
+	stats := types.RuleStats{FirstSeen: 100, LastSeen: 100 + 7200, HitCount: 3, Pods: []string{"a", "b"}}
+	assert.True(t, MeetsRuleThreshold(stats, threshold))
+
+	stats.HitCount = 2
+	assert.False(t, MeetsRuleThreshold(stats, threshold))
+
+	stats.HitCount = 3
+	stats.Pods = []string{"a"}
+	assert.False(t, MeetsRuleThreshold(stats, threshold))
+
+	stats.Pods = []string{"a", "b"}
+	stats.LastSeen = 200
+	assert.False(t, MeetsRuleThreshold(stats, threshold))
+}
+
+func TestMergeRulePodsBounded(t *testing.T) {
+	pods := []string{}
+	for i := 0; i < MaxRulePods+5; i++ {
+		pods = MergeRulePods(pods, []string{fmt.Sprintf("pod-%02d", i)})
+	}
+	assert.Len(t, pods, MaxRulePods)
+
+	assert.Equal(t, MaxRulePods, NewRuleThreshold(0, MaxRulePods+5, "0").MinPods)
+}
+
+func TestGetRuleThreshold(t *testing.T) {
+	threshold := NewRuleThreshold(2, 0, "0")
+	ruleTypeThresholds := NewRuleTypeThresholds(map[string]types.RuleThresholdConfig{
+		"toFQDNs": {MinCount: 5},
+	})
+
+	assert.Equal(t, int64(5), GetRuleThreshold(threshold, ruleTypeThresholds, "toFQDNs").MinCount)
+	assert.Equal(t, int64(2), GetRuleThreshold(threshold, ruleTypeThresholds, "toCIDRs").MinCount)
+
+	assert.False(t, IsAnyRuleThresholdEnabled(types.RuleThreshold{}, nil))
+	assert.True(t, IsAnyRuleThresholdEnabled(types.RuleThreshold{}, ruleTypeThresholds))
+}
@@ -20,6 +20,11 @@ const TableSystemPolicySQLite_TableName = "system_policy"
 const TableSystemLogsSQLite_TableName = "system_logs"
 const TableNetworkLogsSQLite_TableName = "network_logs"
 const PolicyYamlSQLite_TableName = "policy_yaml"
+const WorkloadProcessRuleStatsSQLite_TableName = "workload_process_rulestats"
+const RuleStagingSQLite_TableName = "rule_staging"
+const WorkloadStateSQLite_TableName = "workload_state"
+const AnomalySQLite_TableName = "anomaly"
+const PolicyGapSQLite_TableName = "policy_gap"
 const TableSystemSummarySQLite = "system_summary"
 
 // ================ //
@@ -79,7 +84,6 @@ func GetNetworkPoliciesFromSQLite(cfg types.ConfigDB, cluster, namespace, status
 	db := connectSQLite(cfg, cfg.SQLiteDBPath)
 	defer db.Close()
 
-	policies := []types.KnoxNetworkPolicy{}
 	var results *sql.Rows
 	var err error
 
@@ -106,58 +110,25 @@ func GetNetworkPoliciesFromSQLite(cfg types.ConfigDB, cluster, namespace, status
 		return nil, err
 	}
 
-	for results.Next() {
-		policy := types.KnoxNetworkPolicy{}
-
-		var name, clusterName, namespace, policyType, rule, status string
-		specByte := []byte{}
-		spec := types.Spec{}
-
-		flowIDsByte := []byte{}
-		flowIDs := []int{}
-
-		if err := results.Scan(
-			&policy.APIVersion,
-			&policy.Kind,
-			&flowIDsByte,
-			&name,
-			&clusterName,
-			&namespace,
-			&policyType,
-			&rule,
-			&status,
-			&policy.Outdated,
-			&specByte,
-			&policy.GeneratedTime,
-			&policy.UpdatedTime,
-		); err != nil {
-			return nil, err
-		}
-
-		if err := json.Unmarshal(specByte, &spec); err != nil {
-			return nil, err
-		}
-
-		if err := json.Unmarshal(flowIDsByte, &flowIDs); err != nil {
-			return nil, err
-		}
+	return scanNetworkPolicies(results)
+}
 
-		policy.Metadata = map[string]string{
-			"name":         name,
-			"cluster_name": clusterName,
-			"namespace":    namespace,
-			"type":         policyType,
-			"rule":         rule,
-			"status":       status,
-		}
+// GetNetworkPolicyByNameFromSQLite returns the network policies of the name
+func GetNetworkPolicyByNameFromSQLite(cfg types.ConfigDB, name, status string) ([]types.KnoxNetworkPolicy, error) {
+	db := connectSQLite(cfg, cfg.SQLiteDBPath)
+	defer db.Close()
 
-		policy.FlowIDs = flowIDs
-		policy.Spec = spec
+	query := "SELECT apiVersion,kind,flow_ids,name,cluster_name,namespace,type,rule,status,outdated,spec,generatedTime,updatedTime FROM " + TableNetworkPolicySQLite_TableName +
+		" WHERE name = ? and status = ?"
 
-		policies = append(policies, policy)
+	results, err := db.Query(query, name, status)
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return nil, err
 	}
+	defer results.Close()
 
-	return policies, nil
+	return scanNetworkPolicies(results)
 }
 
 func UpdateNetworkPolicyToSQLite(cfg types.ConfigDB, policy types.KnoxNetworkPolicy) error {
@@ -498,6 +469,31 @@ func ClearDBTablesSQLite(cfg types.ConfigDB) error {
 		return err
 	}
 
+	query = "DELETE FROM " + WorkloadProcessRuleStatsSQLite_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + RuleStagingSQLite_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + WorkloadStateSQLite_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + AnomalySQLite_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
+	query = "DELETE FROM " + PolicyGapSQLite_TableName
+	if _, err := db.Query(query); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -511,7 +507,7 @@ func CreateTableNetworkPolicySQLite(cfg types.ConfigDB) error {
 		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
 			"	`id` INTEGER AUTO_INCREMENT," +
 			"	`apiVersion` varchar(20) DEFAULT NULL," +
-			"	`kind` varchar(20) DEFAULT NULL," +
+			"	`kind` varchar(50) DEFAULT NULL," +
 			"	`flow_ids` JSON DEFAULT NULL," +
 			"	`name` varchar(50) DEFAULT NULL," +
 			"	`cluster_name` varchar(50) DEFAULT NULL," +
@@ -590,6 +586,122 @@ func CreateTableWorkLoadProcessFileSetSQLite(cfg types.ConfigDB) error {
 	return err
 }
 
+func CreateTableWorkLoadProcessRuleStatsSQLite(cfg types.ConfigDB) error {
+	db := connectSQLite(cfg, cfg.SQLiteDBPath)
+	defer db.Close()
+
+	tableName := WorkloadProcessRuleStatsSQLite_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` INTEGER AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"   `containerName` varchar(100) NOT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`fromSource` varchar(256) DEFAULT NULL," +
+			"	`settype` varchar(16) DEFAULT NULL," +
+			"	`rulestats` text DEFAULT NULL," + // json, key: path|dir|pattern of the fileset
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Exec(query)
+	return err
+}
+
+func CreateTableRuleStagingSQLite(cfg types.ConfigDB) error {
+	db := connectSQLite(cfg, cfg.SQLiteDBPath)
+	defer db.Close()
+
+	tableName := RuleStagingSQLite_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` INTEGER AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`ruleKey` varchar(64) NOT NULL," +
+			"	`candidate` text DEFAULT NULL," +
+			"	`rulestats` text DEFAULT NULL," +
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Exec(query)
+	return err
+}
+
+func CreateTableAnomalySQLite(cfg types.ConfigDB) error {
+	db := connectSQLite(cfg, cfg.SQLiteDBPath)
+	defer db.Close()
+
+	tableName := AnomalySQLite_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` INTEGER AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`ruleKey` varchar(64) NOT NULL," +
+			"	`status` varchar(16) DEFAULT NULL," + // pending|acknowledged
+			"	`anomaly` text DEFAULT NULL," + // json
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Exec(query)
+	return err
+}
+
+func CreateTablePolicyGapSQLite(cfg types.ConfigDB) error {
+	db := connectSQLite(cfg, cfg.SQLiteDBPath)
+	defer db.Close()
+
+	tableName := PolicyGapSQLite_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` INTEGER AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`ruleKey` varchar(64) NOT NULL," +
+			"	`gap` text DEFAULT NULL," + // json
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Exec(query)
+	return err
+}
+
+func CreateTableWorkloadStateSQLite(cfg types.ConfigDB) error {
+	db := connectSQLite(cfg, cfg.SQLiteDBPath)
+	defer db.Close()
+
+	tableName := WorkloadStateSQLite_TableName
+
+	query :=
+		"CREATE TABLE IF NOT EXISTS `" + tableName + "` (" +
+			"	`id` INTEGER AUTO_INCREMENT," +
+			"	`clusterName` varchar(50) DEFAULT NULL," +
+			"	`namespace` varchar(50) DEFAULT NULL," +
+			"	`labels` varchar(1000) DEFAULT NULL," +
+			"	`policyType` varchar(16) DEFAULT NULL," + // network|system
+			"	`state` text DEFAULT NULL," + // json
+			"	`updatedTime` bigint NOT NULL," +
+			"	PRIMARY KEY (`id`)" +
+			"  );"
+
+	_, err := db.Exec(query)
+	return err
+}
+
 func CreateTableSystemLogsSQLite(cfg types.ConfigDB) error {
 	db := connectSQLite(cfg, config.GetCfgObservabilityDBName())
 	defer db.Close()
@@ -613,11 +725,20 @@ func CreateTableSystemLogsSQLite(cfg types.ConfigDB) error {
 			"	`updated_time` bigint NOT NULL," +
 			"	`result` varchar(100) DEFAULT NULL," +
 			"	`total` INTEGER, " +
+			"	`process_name` varchar(250) DEFAULT ''," +
+			"	`parent_process_name` varchar(250) DEFAULT ''," +
 			"	PRIMARY KEY (`id`)" +
 			"  );"
 
-	_, err := db.Exec(query)
-	return err
+	if _, err := db.Exec(query); err != nil {
+		return err
+	}
+
+	// the tables created before keep the process lineage as well
+	return addColumnsIfNotExist(db, tableName, map[string]string{
+		"process_name":        "varchar(250) DEFAULT ''",
+		"parent_process_name": "varchar(250) DEFAULT ''",
+	})
 }
 
 func CreateTableNetworkLogsSQLite(cfg types.ConfigDB) error {
@@ -839,7 +960,8 @@ func GetWorkloadProcessFileSetSQLite(cfg types.ConfigDB, wpfs types.WorkloadProc
 func InsertWorkloadProcessFileSetSQLite(cfg types.ConfigDB, wpfs types.WorkloadProcessFileSet, fs []string) error {
 	db := connectSQLite(cfg, cfg.SQLiteDBPath)
 	defer db.Close()
-	policyName := "autopol-" + strings.ToLower(wpfs.SetType) + "-" + RandSeq(15)
+	policyName := "autopol-" + strings.ToLower(wpfs.SetType) + "-" +
+		PolicyNameHash(wpfs.ClusterName, wpfs.Namespace, wpfs.ContainerName, wpfs.Labels, wpfs.FromSource)
 	time := ConvertStrToUnixTime("now")
 
 	stmt, err := db.Prepare("INSERT INTO " + WorkloadProcessFileSetSQLite_TableName +
@@ -967,7 +1089,8 @@ func UpdateOrInsertKubearmorLogsSQLite(cfg types.ConfigDB, kubearmorlogmap map[t
 
 func updateOrInsertKubearmorLogsSQLite(db *sql.DB, kubearmorlog types.KubeArmorLog, count int) error {
 	queryString := `cluster_name = ? and namespace_name = ? and pod_name = ? and container_name = ? and operation = ? and labels = ? 
-					and data = ? and category = ? and action = ? and result = ? and source = ? and resource = ?`
+					and data = ? and category = ? and action = ? and result = ? and source = ? and resource = ?
+					and process_name = ? and parent_process_name = ?`
 
 	query := "UPDATE " + TableSystemLogs_TableName + " SET total=total+?, updated_time=? WHERE " + queryString + " "
 
@@ -992,6 +1115,8 @@ func updateOrInsertKubearmorLogsSQLite(db *sql.DB, kubearmorlog types.KubeArmorL
 		kubearmorlog.Result,
 		kubearmorlog.Source,
 		kubearmorlog.Resource,
+		kubearmorlog.ProcessName,
+		kubearmorlog.ParentProcessName,
 	)
 	if err != nil {
 		log.Error().Msg(err.Error())
@@ -1003,7 +1128,7 @@ func updateOrInsertKubearmorLogsSQLite(db *sql.DB, kubearmorlog types.KubeArmorL
 	if err == nil && rowsAffected == 0 {
 
 		updateQueryString := `(cluster_name,namespace_name,pod_name,container_name,operation,labels,data,category,action,
-		updated_time,result,total,source,resource) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+		updated_time,result,total,source,resource,process_name,parent_process_name) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 		updateQuery := "INSERT INTO " + TableSystemLogs_TableName + updateQueryString
 
@@ -1027,7 +1152,9 @@ func updateOrInsertKubearmorLogsSQLite(db *sql.DB, kubearmorlog types.KubeArmorL
 			kubearmorlog.Result,
 			count,
 			kubearmorlog.Source,
-			kubearmorlog.Resource)
+			kubearmorlog.Resource,
+			kubearmorlog.ProcessName,
+			kubearmorlog.ParentProcessName)
 		if err != nil {
 			log.Error().Msg(err.Error())
 			return err
@@ -1038,7 +1165,7 @@ func updateOrInsertKubearmorLogsSQLite(db *sql.DB, kubearmorlog types.KubeArmorL
 }
 
 // GetSystemLogsMySQL
-func GetSystemLogsSQLite(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]types.KubeArmorLog, []uint32, error) {
+func GetSystemLogsSQLite(cfg types.ConfigDB, filterLog types.KubeArmorLog, since int64) ([]types.KubeArmorLog, []uint32, error) {
 	db := connectSQLite(cfg, config.GetCfgObservabilityDBName())
 	defer db.Close()
 
@@ -1108,6 +1235,11 @@ func GetSystemLogsSQLite(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]ty
 		args = append(args, filterLog.Resource)
 	}
 
+	if since != 0 {
+		concatWhereClauseSince(&whereClause, "updated_time")
+		args = append(args, since)
+	}
+
 	results, err = db.Query(query+whereClause, args...)
 	if err != nil {
 		log.Error().Msg(err.Error())
@@ -1144,7 +1276,7 @@ func GetSystemLogsSQLite(cfg types.ConfigDB, filterLog types.KubeArmorLog) ([]ty
 }
 
 // GetNetworkLogsMySQL
-func GetCiliumLogsSQLite(cfg types.ConfigDB, filterLog types.CiliumLog) ([]types.CiliumLog, []uint32, error) {
+func GetCiliumLogsSQLite(cfg types.ConfigDB, filterLog types.CiliumLog, namespace string, since int64) ([]types.CiliumLog, []uint32, error) {
 	db := connectSQLite(cfg, config.GetCfgObservabilityDBName())
 	defer db.Close()
 
@@ -1331,6 +1463,15 @@ func GetCiliumLogsSQLite(cfg types.ConfigDB, filterLog types.CiliumLog) ([]types
 		args = append(args, filterLog.Total)
 	}
 
+	if namespace != "" {
+		concatWhereClauseAnyOf(&whereClause, "source_namespace", "destination_namespace")
+		args = append(args, namespace, namespace)
+	}
+	if since != 0 {
+		concatWhereClauseSince(&whereClause, "updated_time")
+		args = append(args, since)
+	}
+
 	results, err = db.Query(query+whereClause, args...)
 
 	if err != nil {
@@ -0,0 +1,231 @@
+package libs
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ===================== //
+// == Workload States == //
+// ===================== //
+
+// WorkloadStateConsumer receives the learning state transitions of the workloads
+type WorkloadStateConsumer struct {
+	Filter types.WorkloadState // the empty fields match any
+	Events chan *types.WorkloadState
+}
+
+// WorkloadStateStore is used for support v1.Insight.WatchWorkloadStates RPC requests
+type WorkloadStateStore struct {
+	Consumers map[*WorkloadStateConsumer]struct{}
+	Mutex     sync.Mutex
+}
+
+// WorkloadStateEvents publishes the state transitions of both the network and the system policies
+var WorkloadStateEvents = WorkloadStateStore{
+	Consumers: make(map[*WorkloadStateConsumer]struct{}),
+	Mutex:     sync.Mutex{},
+}
+
+// NewWorkloadStateConsumer returns a consumer of the state transitions matching the filter
+func NewWorkloadStateConsumer(filter types.WorkloadState) *WorkloadStateConsumer {
+	return &WorkloadStateConsumer{
+		Filter: filter,
+		Events: make(chan *types.WorkloadState, 64),
+	}
+}
+
+// AddConsumer adds a new WorkloadStateConsumer to the store
+func (ws *WorkloadStateStore) AddConsumer(c *WorkloadStateConsumer) {
+	ws.Mutex.Lock()
+	defer ws.Mutex.Unlock()
+
+	ws.Consumers[c] = struct{}{}
+}
+
+// RemoveConsumer removes a WorkloadStateConsumer from the store
+func (ws *WorkloadStateStore) RemoveConsumer(c *WorkloadStateConsumer) {
+	ws.Mutex.Lock()
+	defer ws.Mutex.Unlock()
+
+	delete(ws.Consumers, c)
+}
+
+// Publish pushes the state transition to the consumer's channels, the slow
+// consumers miss the transition rather than blocking the discovery
+func (ws *WorkloadStateStore) Publish(state *types.WorkloadState) {
+	ws.Mutex.Lock()
+	defer ws.Mutex.Unlock()
+
+	for consumer := range ws.Consumers {
+		if !MatchWorkloadState(*state, consumer.Filter) {
+			continue
+		}
+
+		select {
+		case consumer.Events <- state:
+		default:
+			log.Warn().Msgf("dropped the workload state event of %s/%s", state.Namespace, state.Labels)
+		}
+	}
+}
+
+// MatchWorkloadState returns true if the state matches the filter, the empty
+// fields of the filter match any
+func MatchWorkloadState(state, filter types.WorkloadState) bool {
+	if filter.ClusterName != "" && filter.ClusterName != state.ClusterName {
+		return false
+	}
+	if filter.Namespace != "" && filter.Namespace != state.Namespace {
+		return false
+	}
+	if filter.Labels != "" && WorkloadLabels(strings.Split(filter.Labels, ",")) != state.Labels {
+		return false
+	}
+	if filter.PolicyType != "" && filter.PolicyType != state.PolicyType {
+		return false
+	}
+	if filter.State != "" && filter.State != state.State {
+		return false
+	}
+	return true
+}
+
+// WorkloadLabels returns the workload identity from its labels (k=v)
+func WorkloadLabels(labels []string) string {
+	sorted := []string{}
+	for _, label := range labels {
+		if label != "" {
+			sorted = append(sorted, label)
+		}
+	}
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
+
+func parseDurationSeconds(name, value string) int64 {
+	if value == "" || value == "0" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Error().Msgf("invalid %s %s err=%s", name, value, err.Error())
+		return 0
+	}
+
+	return int64(duration.Seconds())
+}
+
+// NewWorkloadStateThreshold returns the durations of the learning states, e.g. 24h
+func NewWorkloadStateThreshold(window, stableAfter, enforceReadyAfter string) types.WorkloadStateThreshold {
+	return types.WorkloadStateThreshold{
+		Window:            parseDurationSeconds("workload state window", window),
+		StableAfter:       parseDurationSeconds("workload stable-after", stableAfter),
+		EnforceReadyAfter: parseDurationSeconds("workload enforce-ready-after", enforceReadyAfter),
+	}
+}
+
+// UpdateWorkloadState moves the workload through the learning states. Any change
+// of the discovered policy puts the workload back to learning; the workload is
+// stable once its policy is not changed for the quiet period, and enforce-ready
+// once it is stable for the following period. The changes within the sliding
+// window are kept to measure how often the policy changes. It returns true if
+// the state is changed.
+func UpdateWorkloadState(state *types.WorkloadState, changed bool, now int64, threshold types.WorkloadStateThreshold) bool {
+	prevState := state.State
+
+	if state.FirstSeen == 0 {
+		state.FirstSeen = now
+		state.LastChanged = now
+		state.State = types.WorkloadStateLearning
+		state.StateSince = now
+	}
+
+	if changed {
+		state.LastChanged = now
+		state.Changes = append(state.Changes, now)
+		if state.State != types.WorkloadStateLearning {
+			state.State = types.WorkloadStateLearning
+			state.StateSince = now
+		}
+	}
+
+	changes := []int64{}
+	for _, t := range state.Changes {
+		if now-t <= threshold.Window {
+			changes = append(changes, t)
+		}
+	}
+	state.Changes = changes
+
+	if state.State == types.WorkloadStateLearning && now-state.LastChanged >= threshold.StableAfter {
+		state.State = types.WorkloadStateStable
+		state.StateSince = now
+	}
+	if state.State == types.WorkloadStateStable && now-state.StateSince >= threshold.EnforceReadyAfter {
+		state.State = types.WorkloadStateEnforceReady
+		state.StateSince = now
+	}
+
+	return prevState != state.State
+}
+
+// UpdateWorkloadStates updates the learning states of the workloads of the
+// cluster. The changed map holds the workloads seen in this cycle (key: the
+// namespace and the labels of the workload), and whether their policies are
+// changed; the other workloads progress with the time. The state transitions
+// are published to WorkloadStateEvents.
+func UpdateWorkloadStates(cfg types.ConfigDB, policyType, clusterName string, changed map[types.WorkloadKey]bool,
+	threshold types.WorkloadStateThreshold) {
+	states, err := GetWorkloadStates(cfg, types.WorkloadState{ClusterName: clusterName, PolicyType: policyType})
+	if err != nil {
+		log.Error().Msgf("could not fetch the workload states err=%s", err.Error())
+		return
+	}
+
+	now := time.Now().Unix()
+	seen := map[types.WorkloadKey]bool{}
+
+	update := func(state types.WorkloadState, workloadChanged bool) {
+		prevLastChanged, prevChanges := state.LastChanged, len(state.Changes)
+
+		transitioned := UpdateWorkloadState(&state, workloadChanged, now, threshold)
+		if !transitioned && prevLastChanged == state.LastChanged && prevChanges == len(state.Changes) {
+			return
+		}
+
+		if err := UpdateWorkloadStateDB(cfg, state); err != nil {
+			log.Error().Msgf("could not update the workload state err=%s", err.Error())
+			return
+		}
+
+		if transitioned {
+			log.Info().Msgf("%s policy of the workload %s/%s is %s", state.PolicyType, state.Namespace, state.Labels, state.State)
+			WorkloadStateEvents.Publish(&state)
+		}
+	}
+
+	for _, state := range states {
+		key := types.WorkloadKey{Namespace: state.Namespace, Labels: state.Labels}
+		seen[key] = true
+		update(state, changed[key])
+	}
+
+	for key, workloadChanged := range changed {
+		if seen[key] {
+			continue
+		}
+		update(types.WorkloadState{
+			ClusterName: clusterName,
+			Namespace:   key.Namespace,
+			Labels:      key.Labels,
+			PolicyType:  policyType,
+		}, workloadChanged)
+	}
+}
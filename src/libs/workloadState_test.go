@@ -0,0 +1,57 @@
+package libs
+
+import (
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateWorkloadState(t *testing.T) {
+	threshold := types.WorkloadStateThreshold{Window: 100, StableAfter: 50, EnforceReadyAfter: 200}
+	state := types.WorkloadState{}
+
+	// first seen
+	assert.True(t, UpdateWorkloadState(&state, true, 1000, threshold))
+	assert.Equal(t, types.WorkloadStateLearning, state.State)
+	assert.Equal(t, []int64{1000}, state.Changes)
+
+	// quiet period not elapsed
+	assert.False(t, UpdateWorkloadState(&state, false, 1040, threshold))
+	assert.Equal(t, types.WorkloadStateLearning, state.State)
+
+	// stable after the quiet period
+	assert.True(t, UpdateWorkloadState(&state, false, 1050, threshold))
+	assert.Equal(t, types.WorkloadStateStable, state.State)
+	assert.Equal(t, int64(1050), state.StateSince)
+
+	// the changes out of the window are dropped
+	assert.False(t, UpdateWorkloadState(&state, false, 1200, threshold))
+	assert.Empty(t, state.Changes)
+
+	// enforce-ready once stable for the period
+	assert.True(t, UpdateWorkloadState(&state, false, 1250, threshold))
+	assert.Equal(t, types.WorkloadStateEnforceReady, state.State)
+
+	// any change puts the workload back to learning
+	assert.True(t, UpdateWorkloadState(&state, true, 1300, threshold))
+	assert.Equal(t, types.WorkloadStateLearning, state.State)
+	assert.Equal(t, int64(1300), state.LastChanged)
+	assert.Equal(t, []int64{1300}, state.Changes)
+}
+
+func TestMatchWorkloadState(t *testing.T) {
+	state := types.WorkloadState{
+		ClusterName: "default",
+		Namespace:   "wordpress-mysql",
+		Labels:      WorkloadLabels([]string{"tier=db", "app=mysql"}),
+		PolicyType:  types.PolicyTypeNetwork,
+		State:       types.WorkloadStateStable,
+	}
+
+	assert.Equal(t, "app=mysql,tier=db", state.Labels)
+	assert.True(t, MatchWorkloadState(state, types.WorkloadState{}))
+	assert.True(t, MatchWorkloadState(state, types.WorkloadState{Namespace: "wordpress-mysql", Labels: "tier=db,app=mysql"}))
+	assert.False(t, MatchWorkloadState(state, types.WorkloadState{State: types.WorkloadStateLearning}))
+	assert.False(t, MatchWorkloadState(state, types.WorkloadState{PolicyType: types.PolicyTypeSystem}))
+}
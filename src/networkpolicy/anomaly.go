@@ -0,0 +1,104 @@
+package networkpolicy
+
+import (
+	"errors"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+)
+
+// =============== //
+// == Anomalies == //
+// =============== //
+
+// applyAnomalyMode records the new rules of the stable workloads as anomalies
+// rather than widening their policies. The rules already in the existing
+// policies, and the policies of the other workloads, are kept.
+func applyAnomalyMode(existingPolicies, discoveredPolicies []types.KnoxNetworkPolicy, clusterName, namespace string) []types.KnoxNetworkPolicy {
+	workloads := libs.GetAnomalyWorkloads(CfgDB, AnomalyMode, types.PolicyTypeNetwork, clusterName, namespace)
+	if len(workloads) == 0 {
+		return discoveredPolicies
+	}
+
+	proposedPolicies := []types.KnoxNetworkPolicy{}
+
+	for _, policy := range discoveredPolicies {
+		labels := libs.WorkloadLabels(getLabelArrayFromMap(policy.Spec.Selector.MatchLabels))
+		if len(policy.Spec.Selector.MatchLabels) == 0 || !workloads[labels] {
+			proposedPolicies = append(proposedPolicies, policy)
+			continue
+		}
+
+		proposed := policy
+		proposed.Spec.Egress = nil
+		proposed.Spec.Ingress = nil
+
+		for _, single := range splitPolicyRules(policy) {
+			if !isKnownRule(existingPolicies, single) {
+				key, _ := networkRuleKey(single)
+				stats := singleRuleStats(&single)
+
+				anomaly := types.Anomaly{
+					ClusterName: clusterName,
+					Namespace:   namespace,
+					Labels:      labels,
+					PolicyType:  types.PolicyTypeNetwork,
+					RuleKey:     key,
+					RuleStats:   *stats,
+				}
+
+				// the candidate keeps the metadata to be merged once acknowledged
+				*stats = types.RuleStats{}
+				candidateBytes, err := json.Marshal(single)
+				if err != nil {
+					log.Error().Msg(err.Error())
+					continue
+				}
+				anomaly.Candidate = string(candidateBytes)
+
+				libs.RecordAnomaly(CfgDB, anomaly)
+				continue
+			}
+
+			proposed.Spec.Egress = append(proposed.Spec.Egress, single.Spec.Egress...)
+			proposed.Spec.Ingress = append(proposed.Spec.Ingress, single.Spec.Ingress...)
+		}
+
+		if len(proposed.Spec.Egress) == 0 && len(proposed.Spec.Ingress) == 0 {
+			continue
+		}
+
+		updateFlowIDsFromEvidence(&proposed)
+		proposedPolicies = append(proposedPolicies, proposed)
+	}
+
+	return proposedPolicies
+}
+
+// AckNetworkAnomaly merges the rule of the acknowledged anomaly into the policy
+// of the workload
+func AckNetworkAnomaly(anomaly types.Anomaly) error {
+	NetworkPolicyLock.Lock()
+	defer NetworkPolicyLock.Unlock()
+
+	single := types.KnoxNetworkPolicy{}
+	if err := json.Unmarshal([]byte(anomaly.Candidate), &single); err != nil {
+		return err
+	}
+	if len(single.Spec.Egress) == 0 && len(single.Spec.Ingress) == 0 {
+		return errors.New("no rule in the anomaly " + anomaly.RuleKey)
+	}
+	*singleRuleStats(&single) = anomaly.RuleStats
+	updateFlowIDsFromEvidence(&single)
+
+	existingPolicies := libs.GetNetworkPolicies(CfgDB, anomaly.ClusterName, anomaly.Namespace, "latest", "", "")
+	existingPolicies, _ = splitBaselinePolicies(existingPolicies)
+
+	newPolicies, updatedPolicies, observedPolicies := UpdateDuplicatedPolicy(existingPolicies,
+		[]types.KnoxNetworkPolicy{single}, DomainToIPs, anomaly.ClusterName)
+	storeNetworkPolicies(newPolicies, updatedPolicies, observedPolicies)
+
+	log.Info().Msgf("anomaly of the workload %s/%s is merged into the policy", anomaly.Namespace, anomaly.Labels)
+	return nil
+}
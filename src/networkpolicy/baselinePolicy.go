@@ -0,0 +1,181 @@
+package networkpolicy
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// ======================= //
+// == Baseline Policies == //
+// ======================= //
+
+// PolicyTypeDefaultDeny denies both ingress and egress
+const PolicyTypeDefaultDeny = "default-deny"
+
+// the labels of the kube-dns endpoints if the service is not resolved
+var defaultDNSLabels = map[string]string{
+	"k8s-app": "kube-dns",
+}
+
+var defaultDNSPorts = []types.SpecPort{
+	{Port: "53", Protocol: "UDP"},
+	{Port: "53", Protocol: "TCP"},
+}
+
+func isBaselinePolicy(policy types.KnoxNetworkPolicy) bool {
+	return policy.Metadata["rule"] == types.NetworkRuleDefaultDeny ||
+		policy.Metadata["rule"] == types.NetworkRuleDNSBaseline
+}
+
+// splitBaselinePolicies separates the baseline policies from the discovered
+// ones, so that the baselines are never merged by the deduplicator
+func splitBaselinePolicies(policies []types.KnoxNetworkPolicy) ([]types.KnoxNetworkPolicy, []types.KnoxNetworkPolicy) {
+	discovered := []types.KnoxNetworkPolicy{}
+	baselines := []types.KnoxNetworkPolicy{}
+
+	for _, policy := range policies {
+		if isBaselinePolicy(policy) {
+			baselines = append(baselines, policy)
+		} else {
+			discovered = append(discovered, policy)
+		}
+	}
+
+	return discovered, baselines
+}
+
+func baselinePolicyName(polType, namespace, clusterName string) string {
+	return libs.GeneratePolicyNameFromTemplate(PolicyNameTemplate, libs.PolicyNameFields{
+		Type:      polType,
+		Workload:  "all",
+		Namespace: namespace,
+		Cluster:   clusterName,
+		Hash:      libs.PolicyNameHash(polType, namespace, clusterName),
+	})
+}
+
+// buildDefaultDenyPolicy selects all the endpoints in the namespace, and has
+// the ingress/egress rules without any peer, which denies all the traffic not
+// allowed by the other policies
+func buildDefaultDenyPolicy(namespace, clusterName string) types.KnoxNetworkPolicy {
+	policy := buildNewKnoxPolicy()
+	policy.Metadata["type"] = PolicyTypeDefaultDeny
+	policy.Metadata["rule"] = types.NetworkRuleDefaultDeny
+	policy.Metadata["namespace"] = namespace
+	policy.Metadata["cluster_name"] = clusterName
+	policy.Metadata["name"] = baselinePolicyName(PolicyTypeDefaultDeny, namespace, clusterName)
+
+	policy.Spec.Ingress = []types.Ingress{{}}
+	policy.Spec.Egress = []types.Egress{{}}
+
+	return policy
+}
+
+// buildDNSBaselinePolicy allows all the endpoints in the namespace to reach the
+// kube-dns endpoints
+func buildDNSBaselinePolicy(namespace, clusterName string) types.KnoxNetworkPolicy {
+	policy := buildNewKnoxEgressPolicy()
+	policy.Metadata["rule"] = types.NetworkRuleDNSBaseline
+	policy.Metadata["namespace"] = namespace
+	policy.Metadata["cluster_name"] = clusterName
+	policy.Metadata["name"] = baselinePolicyName("dns", namespace, clusterName)
+
+	egress := types.Egress{
+		MatchLabels: map[string]string{
+			types.CiliumNamespaceLabel: "kube-system",
+		},
+		ToPorts: []types.SpecPort{},
+	}
+
+	// the kube-dns services resolved in updateServiceEndpoint
+	for _, svc := range K8sDNSServices {
+		for k, v := range svc.Selector {
+			egress.MatchLabels[k] = v
+		}
+
+		// the policy is enforced on the endpoints, thus the target port
+		port := svc.TargetPort
+		if port == 0 {
+			port = svc.ServicePort
+		}
+
+		toPort := types.SpecPort{Port: strconv.Itoa(port), Protocol: strings.ToUpper(svc.Protocol)}
+		if !containsSpecPort(egress.ToPorts, toPort) {
+			egress.ToPorts = append(egress.ToPorts, toPort)
+		}
+	}
+
+	if len(egress.MatchLabels) == 1 {
+		for k, v := range defaultDNSLabels {
+			egress.MatchLabels[k] = v
+		}
+	}
+
+	if len(egress.ToPorts) == 0 {
+		egress.ToPorts = append(egress.ToPorts, defaultDNSPorts...)
+	}
+
+	sort.Slice(egress.ToPorts, func(i, j int) bool {
+		if egress.ToPorts[i].Port != egress.ToPorts[j].Port {
+			return egress.ToPorts[i].Port < egress.ToPorts[j].Port
+		}
+		return egress.ToPorts[i].Protocol > egress.ToPorts[j].Protocol
+	})
+
+	policy.Spec.Egress = append(policy.Spec.Egress, egress)
+
+	return policy
+}
+
+func containsSpecPort(ports []types.SpecPort, port types.SpecPort) bool {
+	for _, p := range ports {
+		if p.Equal(port) {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateBaselinePolicies returns the baseline policies of the namespace, which
+// are new or changed compared to the existing baselines
+func GenerateBaselinePolicies(existingBaselines []types.KnoxNetworkPolicy, namespace, clusterName string) ([]types.KnoxNetworkPolicy, []types.KnoxNetworkPolicy) {
+	newPolicies := []types.KnoxNetworkPolicy{}
+	updatedPolicies := []types.KnoxNetworkPolicy{}
+
+	if libs.ContainsElement(config.CurrentCfg.ConfigNetPolicy.NsNotFilter, namespace) {
+		return newPolicies, updatedPolicies
+	}
+
+	baselines := []types.KnoxNetworkPolicy{buildDefaultDenyPolicy(namespace, clusterName)}
+	if BaselineDNSAllow {
+		baselines = append(baselines, buildDNSBaselinePolicy(namespace, clusterName))
+	}
+
+	for _, baseline := range baselines {
+		exist := false
+
+		for _, existing := range existingBaselines {
+			if existing.Metadata["name"] != baseline.Metadata["name"] {
+				continue
+			}
+
+			exist = true
+			if !cmp.Equal(existing.Spec, baseline.Spec) {
+				updatedPolicies = append(updatedPolicies, baseline)
+			}
+			break
+		}
+
+		if !exist {
+			newPolicies = append(newPolicies, baseline)
+		}
+	}
+
+	return newPolicies, updatedPolicies
+}
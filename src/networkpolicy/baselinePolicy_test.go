@@ -0,0 +1,92 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/plugin"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+	"github.com/stretchr/testify/assert"
+	nv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildDNSBaselinePolicy(t *testing.T) {
+	K8sDNSServices = []types.Service{
+		{Namespace: "kube-system", ServiceName: "kube-dns", Protocol: "UDP", ServicePort: 53, TargetPort: 1053,
+			Selector: map[string]string{"k8s-app": "coredns"}},
+		{Namespace: "kube-system", ServiceName: "kube-dns", Protocol: "TCP", ServicePort: 53, TargetPort: 1053,
+			Selector: map[string]string{"k8s-app": "coredns"}},
+	}
+	defer func() { K8sDNSServices = []types.Service{} }()
+
+	policy := buildDNSBaselinePolicy("default", "cluster1")
+
+	assert.Equal(t, PolicyTypeEgress, policy.Metadata["type"])
+	assert.Empty(t, policy.Spec.Selector.MatchLabels)
+	assert.Equal(t, []types.Egress{{
+		MatchLabels: map[string]string{
+			types.CiliumNamespaceLabel: "kube-system",
+			"k8s-app":                  "coredns",
+		},
+		ToPorts: []types.SpecPort{
+			{Port: "1053", Protocol: "UDP"},
+			{Port: "1053", Protocol: "TCP"},
+		},
+	}}, policy.Spec.Egress)
+}
+
+func TestBuildDNSBaselinePolicy_NoService(t *testing.T) {
+	K8sDNSServices = []types.Service{}
+
+	policy := buildDNSBaselinePolicy("default", "cluster1")
+
+	assert.Equal(t, "kube-dns", policy.Spec.Egress[0].MatchLabels["k8s-app"])
+	assert.Equal(t, defaultDNSPorts, policy.Spec.Egress[0].ToPorts)
+}
+
+func TestGenerateBaselinePolicies(t *testing.T) {
+	BaselineDNSAllow = true
+	K8sDNSServices = []types.Service{}
+
+	newPolicies, updatedPolicies := GenerateBaselinePolicies(nil, "default", "cluster1")
+	assert.Len(t, newPolicies, 2)
+	assert.Len(t, updatedPolicies, 0)
+
+	// already exist
+	newPolicies, updatedPolicies = GenerateBaselinePolicies(newPolicies, "default", "cluster1")
+	assert.Len(t, newPolicies, 0)
+	assert.Len(t, updatedPolicies, 0)
+
+	// the baselines are not merged with the discovered policies
+	discovered, baselines := splitBaselinePolicies(append(newPolicies, buildDefaultDenyPolicy("default", "cluster1"), buildNewKnoxEgressPolicy()))
+	assert.Len(t, discovered, 1)
+	assert.Len(t, baselines, 1)
+
+	// excluded namespace
+	config.CurrentCfg.ConfigNetPolicy.NsNotFilter = []string{"kube-system"}
+	defer func() { config.CurrentCfg.ConfigNetPolicy.NsNotFilter = nil }()
+
+	newPolicies, _ = GenerateBaselinePolicies(nil, "kube-system", "cluster1")
+	assert.Len(t, newPolicies, 0)
+}
+
+func TestDefaultDenyPolicyConversion(t *testing.T) {
+	policy := buildDefaultDenyPolicy("default", "cluster1")
+
+	ciliumPolicy := plugin.ConvertKnoxNetworkPolicyToCiliumPolicy(policy)
+	jsonBytes, err := json.Marshal(ciliumPolicy)
+	assert.NoError(t, err)
+	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
+	assert.NoError(t, err)
+	assert.Contains(t, string(yamlBytes), "egress:\n  - {}\n")
+	assert.Contains(t, string(yamlBytes), "ingress:\n  - {}\n")
+	assert.Contains(t, string(yamlBytes), "k8s:io.kubernetes.pod.namespace: default")
+
+	k8sPolicies := plugin.ConvertKnoxNetPolicyToK8sNetworkPolicy("", "", []types.KnoxNetworkPolicy{policy})
+	assert.Len(t, k8sPolicies, 1)
+	assert.Equal(t, []nv1.PolicyType{nv1.PolicyTypeIngress, nv1.PolicyTypeEgress}, k8sPolicies[0].Spec.PolicyTypes)
+	assert.Empty(t, k8sPolicies[0].Spec.Ingress)
+	assert.Empty(t, k8sPolicies[0].Spec.Egress)
+}
@@ -0,0 +1,40 @@
+package networkpolicy
+
+import (
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ================== //
+// == Cluster Mesh == //
+// ================== //
+
+// isClusterMeshLog checks if the log is between the clusters of the mesh
+func isClusterMeshLog(log *types.KnoxNetworkLog) bool {
+	return log.SrcCluster != "" && log.DstCluster != "" && log.SrcCluster != log.DstCluster
+}
+
+// isRemoteSrc checks if the src is in the remote cluster; the flow is observed
+// at the endpoint in the local cluster, i.e. the ingress of the local dst
+func isRemoteSrc(log *types.KnoxNetworkLog) bool {
+	return isClusterMeshLog(log) && log.Direction == "INGRESS" && len(log.SrcReservedLabels) == 0
+}
+
+// isRemoteDst checks if the dst is in the remote cluster, i.e. the egress of the
+// local src
+func isRemoteDst(log *types.KnoxNetworkLog) bool {
+	return isClusterMeshLog(log) && log.Direction == "EGRESS" && len(log.DstReservedLabels) == 0
+}
+
+// getRemotePeerLabels returns the labels selecting the peer in the remote cluster,
+// i.e. its labels plus the namespace and the cluster
+func getRemotePeerLabels(labels []string, namespace, clusterName string) map[string]string {
+	matchLabels := getLabelMapFromArray(labels)
+	if len(matchLabels) == 0 {
+		return nil
+	}
+
+	matchLabels["io.kubernetes.pod.namespace"] = namespace
+	matchLabels[types.CiliumClusterLabel] = clusterName
+
+	return matchLabels
+}
@@ -0,0 +1,61 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertClusterMeshLog(t *testing.T) {
+	pods := []types.Pod{
+		{Namespace: "shop", PodName: "cart-1", Labels: []string{"app=cart"}},
+	}
+
+	// the remote src is observed at the ingress of the local dst
+	log := types.KnoxNetworkLog{
+		SrcNamespace: "frontend", SrcPodName: "web-1", SrcCluster: "east", SrcLabels: []string{"app=web"},
+		DstNamespace: "shop", DstPodName: "cart-1", DstCluster: "west", DstLabels: []string{"app=cart"},
+		Protocol: libs.IPProtocolTCP, DstPort: 8080, Direction: "INGRESS",
+	}
+
+	ingress, egress := convertKnoxNetworkLogToKnoxNetworkPolicy(&log, nil, pods)
+	assert.Nil(t, egress)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, map[string]string{"app": "cart"}, ingress.Spec.Selector.MatchLabels)
+	assert.Equal(t, "shop", ingress.Metadata["namespace"])
+	assert.Equal(t, map[string]string{
+		"app":                         "web",
+		"io.kubernetes.pod.namespace": "frontend",
+		types.CiliumClusterLabel:      "east",
+	}, ingress.Spec.Ingress[0].MatchLabels)
+
+	// the remote namespace is unknown locally, grouped by the local dst
+	assert.Len(t, FilterNetworkLogsByNamespace("shop", []types.KnoxNetworkLog{log}), 1)
+	assert.Empty(t, FilterNetworkLogsByNamespace("frontend", []types.KnoxNetworkLog{log}))
+
+	// the remote dst is observed at the egress of the local src
+	log = types.KnoxNetworkLog{
+		SrcNamespace: "shop", SrcPodName: "cart-1", SrcCluster: "west", SrcLabels: []string{"app=cart"},
+		DstNamespace: "payment", DstCluster: "east", DstLabels: []string{"app=payment"},
+		Protocol: libs.IPProtocolTCP, DstPort: 443, Direction: "EGRESS",
+	}
+
+	ingress, egress = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, nil, pods)
+	assert.Nil(t, ingress)
+	assert.NotNil(t, egress)
+	assert.Equal(t, map[string]string{"app": "cart"}, egress.Spec.Selector.MatchLabels)
+	assert.Equal(t, "shop", egress.Metadata["namespace"])
+	assert.Equal(t, map[string]string{
+		"app":                         "payment",
+		"io.kubernetes.pod.namespace": "payment",
+		types.CiliumClusterLabel:      "east",
+	}, egress.Spec.Egress[0].MatchLabels)
+
+	// the remote peer without the labels is not selected
+	log.DstLabels = nil
+	ingress, egress = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, nil, pods)
+	assert.Nil(t, ingress)
+	assert.Nil(t, egress)
+}
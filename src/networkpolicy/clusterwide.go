@@ -0,0 +1,250 @@
+package networkpolicy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+)
+
+// ================================ //
+// == Clusterwide network policy == //
+// ================================ //
+
+// ClusterwideNamespace is the namespace of the policies not bound to a namespace,
+// i.e. the clusterwide and the host policies
+const ClusterwideNamespace = ""
+
+// sharedEgress is the egress rule discovered in the namespaces
+type sharedEgress struct {
+	rule       types.Egress
+	namespaces []string
+	selectors  []map[string]string
+}
+
+// isShareableEgress checks if the egress rule means the same in any namespace,
+// i.e. the peer is not the endpoints in the namespace of the policy
+func isShareableEgress(egress types.Egress) bool {
+	if egress.Pruned {
+		return false
+	}
+	if len(egress.MatchLabels) > 0 {
+		_, ok := egress.MatchLabels["io.kubernetes.pod.namespace"]
+		return ok
+	}
+	return true
+}
+
+// sharedEgressKey returns the key of the egress rule without the statistics
+func sharedEgressKey(egress types.Egress) string {
+	egress.RuleStats = types.RuleStats{}
+
+	b, err := json.Marshal(egress)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// getCommonLabels returns the labels shared by all the selectors; if the label
+// keys are given, only those labels are used
+func getCommonLabels(selectors []map[string]string, labelKeys []string) map[string]string {
+	common := map[string]string{}
+	if len(selectors) == 0 {
+		return common
+	}
+
+	for k, v := range selectors[0] {
+		if len(labelKeys) > 0 && !libs.ContainsElement(labelKeys, k) {
+			continue
+		}
+		common[k] = v
+	}
+
+	for _, selector := range selectors[1:] {
+		for k, v := range common {
+			if selector[k] != v {
+				delete(common, k)
+			}
+		}
+	}
+
+	return common
+}
+
+// selectsAll checks if the selector includes all the labels
+func selectsAll(selector, labels map[string]string) bool {
+	for k, v := range labels {
+		if selector[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// selectsOtherNamespaces checks if the selector selects any pod outside the namespaces
+func selectsOtherNamespaces(selector map[string]string, namespaces []string, pods []types.Pod) bool {
+	for _, pod := range pods {
+		if !libs.ContainsElement(namespaces, pod.Namespace) && selectsPod(selector, pod.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// LiftClusterwideEgress lifts the egress rules discovered in at least minNamespaces
+// namespaces into the clusterwide policies selecting the endpoints by the labels
+// common to the sources. The rule is not lifted if the common labels select the
+// pods in the other namespaces, where it is never observed. The lifted rules, and
+// the rules already covered by the existing clusterwide policies, are removed
+// from the namespace policies.
+func LiftClusterwideEgress(policiesPerNamespace map[string][]types.KnoxNetworkPolicy,
+	existingClusterwide []types.KnoxNetworkPolicy, minNamespaces int, labelKeys []string, pods []types.Pod) []types.KnoxNetworkPolicy {
+
+	namespaces := []string{}
+	for namespace := range policiesPerNamespace {
+		if namespace != ClusterwideNamespace {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	// 1. collect the shareable egress rules per key
+	keys := []string{}
+	shared := map[string]*sharedEgress{}
+
+	for _, namespace := range namespaces {
+		for _, policy := range policiesPerNamespace[namespace] {
+			if policy.Kind != types.KindKnoxNetworkPolicy || policy.Metadata["type"] != PolicyTypeEgress {
+				continue
+			}
+
+			for _, egress := range policy.Spec.Egress {
+				if !isShareableEgress(egress) {
+					continue
+				}
+
+				key := sharedEgressKey(egress)
+				s, ok := shared[key]
+				if !ok {
+					keys = append(keys, key)
+					s = &sharedEgress{rule: egress}
+					shared[key] = s
+				} else {
+					s.rule.RuleStats = libs.CombineRuleStats(s.rule.RuleStats, egress.RuleStats)
+				}
+
+				if !libs.ContainsElement(s.namespaces, namespace) {
+					s.namespaces = append(s.namespaces, namespace)
+				}
+				s.selectors = append(s.selectors, policy.Spec.Selector.MatchLabels)
+			}
+		}
+	}
+
+	// 2. build the clusterwide policies per common selector
+	lifted := map[string][]map[string]string{} // key: rule key - val: the selectors of the clusterwide rules
+	for _, policy := range existingClusterwide {
+		if policy.Kind != types.KindKnoxClusterwideNetworkPolicy {
+			continue
+		}
+		for _, egress := range policy.Spec.Egress {
+			key := sharedEgressKey(egress)
+			lifted[key] = append(lifted[key], policy.Spec.Selector.MatchLabels)
+		}
+	}
+
+	clusterwidePolicies := []types.KnoxNetworkPolicy{}
+	policyIdx := map[string]int{} // key: selector
+
+	for _, key := range keys {
+		s := shared[key]
+		if len(s.namespaces) < minNamespaces {
+			continue
+		}
+
+		selector := getCommonLabels(s.selectors, labelKeys)
+		if len(selector) == 0 {
+			// no common label, selecting all the endpoints is too broad
+			continue
+		}
+		if selectsOtherNamespaces(selector, s.namespaces, pods) {
+			continue
+		}
+
+		selectorKey := strings.Join(getLabelArrayFromMap(selector), ",")
+		idx, ok := policyIdx[selectorKey]
+		if !ok {
+			policy := buildNewKnoxEgressPolicy()
+			policy.Kind = types.KindKnoxClusterwideNetworkPolicy
+			policy.Metadata["namespace"] = ClusterwideNamespace
+			policy.Spec.Selector.MatchLabels = selector
+
+			idx = len(clusterwidePolicies)
+			policyIdx[selectorKey] = idx
+			clusterwidePolicies = append(clusterwidePolicies, policy)
+		}
+
+		clusterwidePolicies[idx].Spec.Egress = append(clusterwidePolicies[idx].Spec.Egress, s.rule)
+		lifted[key] = append(lifted[key], selector)
+	}
+
+	for i := range clusterwidePolicies {
+		updateFlowIDsFromEvidence(&clusterwidePolicies[i])
+	}
+
+	// 3. remove the lifted rules from the namespace policies
+	for _, namespace := range namespaces {
+		policies := []types.KnoxNetworkPolicy{}
+
+		for _, policy := range policiesPerNamespace[namespace] {
+			if policy.Kind != types.KindKnoxNetworkPolicy || policy.Metadata["type"] != PolicyTypeEgress {
+				policies = append(policies, policy)
+				continue
+			}
+
+			egresses := []types.Egress{}
+			for _, egress := range policy.Spec.Egress {
+				covered := false
+				for _, selector := range lifted[sharedEgressKey(egress)] {
+					if selectsAll(policy.Spec.Selector.MatchLabels, selector) {
+						covered = true
+						break
+					}
+				}
+				if !covered {
+					egresses = append(egresses, egress)
+				}
+			}
+
+			if len(egresses) == 0 {
+				continue
+			}
+
+			if len(egresses) != len(policy.Spec.Egress) {
+				policy.Spec.Egress = egresses
+				updateFlowIDsFromEvidence(&policy)
+			}
+			policies = append(policies, policy)
+		}
+
+		policiesPerNamespace[namespace] = policies
+	}
+
+	return clusterwidePolicies
+}
+
+// getExistingClusterwidePolicies returns the latest policies not bound to a namespace
+func getExistingClusterwidePolicies(clusterName string) []types.KnoxNetworkPolicy {
+	results := []types.KnoxNetworkPolicy{}
+
+	for _, policy := range libs.GetNetworkPolicies(CfgDB, clusterName, ClusterwideNamespace, "latest", "", "") {
+		if policy.Metadata["namespace"] == ClusterwideNamespace {
+			results = append(results, policy)
+		}
+	}
+
+	return results
+}
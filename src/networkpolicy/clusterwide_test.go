@@ -0,0 +1,87 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildEgressPolicyForTest(namespace string, selector map[string]string, egresses ...types.Egress) types.KnoxNetworkPolicy {
+	policy := buildNewKnoxEgressPolicy()
+	policy.Metadata["namespace"] = namespace
+	policy.Spec.Selector.MatchLabels = selector
+	policy.Spec.Egress = egresses
+	return policy
+}
+
+func TestLiftClusterwideEgress(t *testing.T) {
+	toMonitoring := types.Egress{
+		MatchLabels: map[string]string{"app": "prometheus", "io.kubernetes.pod.namespace": "monitoring"},
+		ToPorts:     []types.SpecPort{{Port: "9090", Protocol: "TCP"}},
+	}
+	toLocalDB := types.Egress{
+		MatchLabels: map[string]string{"app": "db"},
+		ToPorts:     []types.SpecPort{{Port: "5432", Protocol: "TCP"}},
+	}
+
+	withStats := func(egress types.Egress, pod string) types.Egress {
+		egress.RuleStats = types.RuleStats{FirstSeen: 1, LastSeen: 2, HitCount: 1, Pods: []string{pod}}
+		return egress
+	}
+
+	policies := map[string][]types.KnoxNetworkPolicy{
+		"cart": {buildEgressPolicyForTest("cart", map[string]string{"app": "cart", "part-of": "shop"},
+			withStats(toMonitoring, "cart-1"), toLocalDB)},
+		"order": {buildEgressPolicyForTest("order", map[string]string{"app": "order", "part-of": "shop"},
+			withStats(toMonitoring, "order-1"))},
+		"payment": {buildEgressPolicyForTest("payment", map[string]string{"app": "payment", "part-of": "shop"},
+			withStats(toMonitoring, "payment-1"), toLocalDB)},
+	}
+
+	// seen in 3 namespaces, lifted with the common label
+	results := LiftClusterwideEgress(policies, nil, 3, nil, nil)
+	assert.Len(t, results, 1)
+	assert.Equal(t, types.KindKnoxClusterwideNetworkPolicy, results[0].Kind)
+	assert.Equal(t, ClusterwideNamespace, results[0].Metadata["namespace"])
+	assert.Equal(t, map[string]string{"part-of": "shop"}, results[0].Spec.Selector.MatchLabels)
+	assert.Len(t, results[0].Spec.Egress, 1)
+	assert.Equal(t, toMonitoring.MatchLabels, results[0].Spec.Egress[0].MatchLabels)
+	assert.Equal(t, int64(3), results[0].Spec.Egress[0].HitCount)
+	assert.Equal(t, []string{"cart-1", "order-1", "payment-1"}, results[0].Spec.Egress[0].Pods)
+
+	// the egress in the namespace is never lifted, the empty policy is removed
+	assert.Equal(t, []types.Egress{toLocalDB}, policies["cart"][0].Spec.Egress)
+	assert.Equal(t, []types.Egress{toLocalDB}, policies["payment"][0].Spec.Egress)
+	assert.Empty(t, policies["order"])
+
+	// covered by the existing clusterwide policy, even if seen in one namespace
+	policies = map[string][]types.KnoxNetworkPolicy{
+		"cart": {buildEgressPolicyForTest("cart", map[string]string{"app": "cart", "part-of": "shop"},
+			withStats(toMonitoring, "cart-1"), toLocalDB)},
+	}
+	assert.Empty(t, LiftClusterwideEgress(policies, results, 3, nil, nil))
+	assert.Equal(t, []types.Egress{toLocalDB}, policies["cart"][0].Spec.Egress)
+
+	// no common label of the keys
+	policies = map[string][]types.KnoxNetworkPolicy{
+		"cart":  {buildEgressPolicyForTest("cart", map[string]string{"app": "cart", "part-of": "shop"}, toMonitoring)},
+		"order": {buildEgressPolicyForTest("order", map[string]string{"app": "order", "part-of": "shop"}, toMonitoring)},
+	}
+	assert.Empty(t, LiftClusterwideEgress(policies, nil, 2, []string{"team"}, nil))
+	assert.Len(t, policies["cart"], 1)
+	assert.Len(t, policies["order"], 1)
+
+	// the common label selects the pod in the other namespace, never observed
+	pods := []types.Pod{
+		{Namespace: "cart", PodName: "cart-1", Labels: []string{"app=cart", "part-of=shop"}},
+		{Namespace: "order", PodName: "order-1", Labels: []string{"app=order", "part-of=shop"}},
+		{Namespace: "admin", PodName: "admin-1", Labels: []string{"app=admin", "part-of=shop"}},
+	}
+	assert.Empty(t, LiftClusterwideEgress(policies, nil, 2, nil, pods))
+	assert.Len(t, policies["cart"], 1)
+	assert.Len(t, policies["order"], 1)
+
+	assert.Len(t, LiftClusterwideEgress(policies, nil, 2, nil, pods[:2]), 1)
+	assert.Empty(t, policies["cart"])
+}
@@ -1,9 +1,11 @@
 package networkpolicy
 
 import (
+	"reflect"
+	"regexp"
 	"sort"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/accuknox/auto-policy-discovery/src/common"
 	"github.com/accuknox/auto-policy-discovery/src/libs"
@@ -85,8 +87,8 @@ func GetLatestFQDNPolicy(existingPolicies []types.KnoxNetworkPolicy, policy type
 
 			// check FQDN list
 			matchFQDN := true
-			for _, dns := range policy.Spec.Egress[0].ToFQDNs[0].MatchNames {
-				for _, existDNS := range exist.Spec.Egress[0].ToFQDNs[0].MatchNames {
+			for _, dns := range policy.Spec.Egress[0].ToFQDNs[0].Names() {
+				for _, existDNS := range exist.Spec.Egress[0].ToFQDNs[0].Names() {
 					if dns != existDNS {
 						matchFQDN = false
 					}
@@ -141,7 +143,7 @@ func GetLatestHTTPPolicy(existingPolicies []types.KnoxNetworkPolicy, policy type
 					newCIDRs = policy.Spec.Egress[0].ToCIDRs[0].CIDRs
 				}
 				if strings.Contains(rule, "toFQDNs") {
-					newFQDNs = policy.Spec.Egress[0].ToFQDNs[0].MatchNames
+					newFQDNs = policy.Spec.Egress[0].ToFQDNs[0].Names()
 				}
 				newEntities = policy.Spec.Egress[0].ToEntities
 				newToPorts = policy.Spec.Egress[0].ToPorts
@@ -151,7 +153,7 @@ func GetLatestHTTPPolicy(existingPolicies []types.KnoxNetworkPolicy, policy type
 					existCIDRs = exist.Spec.Egress[0].ToCIDRs[0].CIDRs
 				}
 				if strings.Contains(rule, "toFQDNs") {
-					existFQDNs = exist.Spec.Egress[0].ToFQDNs[0].MatchNames
+					existFQDNs = exist.Spec.Egress[0].ToFQDNs[0].Names()
 				}
 				existEntities = exist.Spec.Egress[0].ToEntities
 				existToPorts = exist.Spec.Egress[0].ToPorts
@@ -325,7 +327,8 @@ func includedHTTPPath(httpRules []types.SpecHTTP, targetRule types.SpecHTTP) boo
 	included := false
 
 	for _, httpRule := range httpRules {
-		if httpRule.Method != targetRule.Method {
+		if httpRule.Method != targetRule.Method || (httpRule.Host != "" && httpRule.Host != targetRule.Host) ||
+			!reflect.DeepEqual(httpRule.Headers, targetRule.Headers) {
 			continue
 		}
 
@@ -772,10 +775,16 @@ func GeneratePolicyName(policyNamesMap map[string]bool, policy types.KnoxNetwork
 	}
 	sort.Strings(labels) // sort labels
 
-	hashInt := common.HashInt(polType + strings.Join(labels, ",") + policy.Metadata["namespace"] + clusterName)
-	hash := strconv.FormatUint(uint64(hashInt), 10)
-	name := "autopol-" + polType + "-" + hash
+	name := libs.GeneratePolicyNameFromTemplate(PolicyNameTemplate, libs.PolicyNameFields{
+		Type:      polType,
+		Workload:  libs.GetWorkloadNameFromLabels(policy.Spec.Selector.MatchLabels),
+		Namespace: policy.Metadata["namespace"],
+		Cluster:   clusterName,
+		Hash:      libs.PolicyNameHash(polType, strings.Join(labels, ","), policy.Metadata["namespace"], clusterName),
+	})
 
+	// the same name generated for another selector
+	name = libs.ResolvePolicyNameCollision(policyNamesMap, name)
 	policyNamesMap[name] = true
 
 	policy.Metadata["name"] = name
@@ -784,6 +793,45 @@ func GeneratePolicyName(policyNamesMap map[string]bool, policy types.KnoxNetwork
 	return policy
 }
 
+// legacyPolicyName matches the names generated before the name template
+var legacyPolicyName = regexp.MustCompile(`^autopol-(ingress|egress)-[0-9]+$`)
+
+// MigratePolicyNames renames the policies having the legacy names in the db with
+// the name template. Every version of a policy is renamed to the same name, so
+// the outdated history is preserved.
+func MigratePolicyNames() {
+	policies := libs.GetNetworkPolicies(CfgDB, "", "", "latest", "", "")
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].GeneratedTime < policies[j].GeneratedTime
+	})
+
+	policyNamesMap := map[string]bool{}
+	for _, policy := range policies {
+		policyNamesMap[policy.Metadata["name"]] = true
+	}
+
+	renamed := map[string]string{}
+	for _, policy := range policies {
+		oldName := policy.Metadata["name"]
+		if !legacyPolicyName.MatchString(oldName) {
+			continue
+		}
+		if _, ok := renamed[oldName]; ok {
+			continue
+		}
+
+		delete(policyNamesMap, oldName)
+		newName := GeneratePolicyName(policyNamesMap, policy, policy.Metadata["cluster_name"]).Metadata["name"]
+		renamed[oldName] = newName
+
+		if err := libs.RenamePolicy(CfgDB, types.PolicyTypeNetwork, oldName, newName); err != nil {
+			log.Error().Msgf("failed to rename network policy %s to %s err=%s", oldName, newName, err.Error())
+		}
+	}
+
+	log.Info().Msgf("migrated %d network policy names", len(renamed))
+}
+
 func GetToFQDNsFromNewDiscoveredPolicies(policy types.KnoxNetworkPolicy, newPolicies []types.KnoxNetworkPolicy) []types.KnoxNetworkPolicy {
 	toFQDNs := []types.KnoxNetworkPolicy{}
 
@@ -819,6 +867,11 @@ func GetFQDNFromDomainName(domainName string, fqdnPolicies []types.KnoxNetworkPo
 				if libs.ContainsElement(fqdn.MatchNames, domainName) {
 					return policy, true
 				}
+				for _, pattern := range fqdn.MatchPatterns {
+					if common.MatchFQDNPattern(pattern, domainName) {
+						return policy, true
+					}
+				}
 			}
 		}
 	}
@@ -918,13 +971,67 @@ func IsExistingPolicySpec(existingPolicies []types.KnoxNetworkPolicy, newPolicy
 // == Update Duplicated Network Policy == //
 // ====================================== //
 
-func UpdateDuplicatedPolicy(existingPolicies []types.KnoxNetworkPolicy, discoveredPolicies []types.KnoxNetworkPolicy, dnsToIPs map[string][]string, clusterName string) ([]types.KnoxNetworkPolicy, []types.KnoxNetworkPolicy) {
+// updateStaleRules flags (or prunes) the rules of the policy not seen within the
+// staleness window. It returns whether the rule statistics are changed, and
+// whether the generated policy is changed as well.
+func updateStaleRules(policy *types.KnoxNetworkPolicy, now int64) (bool, bool) {
+	changed, specChanged := false, false
+
+	update := func(stats *types.RuleStats) {
+		pruned := stats.Pruned
+		if libs.UpdateRuleStaleness(stats, now, StaleRuleWindow, StaleRuleAction) {
+			changed = true
+			if pruned != stats.Pruned {
+				specChanged = true
+			}
+		}
+	}
+
+	for i := range policy.Spec.Ingress {
+		update(&policy.Spec.Ingress[i].RuleStats)
+	}
+	for i := range policy.Spec.Egress {
+		update(&policy.Spec.Egress[i].RuleStats)
+	}
+
+	return changed, specChanged
+}
+
+// isPrunedPolicy returns true if every rule of the policy is pruned, i.e. the
+// policy allows nothing anymore
+func isPrunedPolicy(policy types.KnoxNetworkPolicy) bool {
+	if len(policy.Spec.Ingress) == 0 && len(policy.Spec.Egress) == 0 {
+		return false
+	}
+
+	for _, ingress := range policy.Spec.Ingress {
+		if !ingress.Pruned {
+			return false
+		}
+	}
+	for _, egress := range policy.Spec.Egress {
+		if !egress.Pruned {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UpdateDuplicatedPolicy merges the discovered policies into the existing ones.
+// It returns the new policies, the updated policies, and the policies of which
+// only the rule statistics are changed (no need to regenerate the yaml).
+func UpdateDuplicatedPolicy(existingPolicies []types.KnoxNetworkPolicy, discoveredPolicies []types.KnoxNetworkPolicy, dnsToIPs map[string][]string, clusterName string) ([]types.KnoxNetworkPolicy, []types.KnoxNetworkPolicy, []types.KnoxNetworkPolicy) {
 	newPolicies := []types.KnoxNetworkPolicy{}
 	updatedPolicies := []types.KnoxNetworkPolicy{}
+	observedPolicies := []types.KnoxNetworkPolicy{}
 
 	existIngressPolicies := map[Selector]types.KnoxNetworkPolicy{}
 	existEgressPolicies := map[Selector]types.KnoxNetworkPolicy{}
 
+	// the existing policies seen in the discovered ones
+	observed := map[string]bool{}
+
 	policyNamesMap := map[string]bool{}
 	for _, existPolicy := range existingPolicies {
 		policyNamesMap[existPolicy.Metadata["name"]] = true
@@ -947,10 +1054,15 @@ func UpdateDuplicatedPolicy(existingPolicies []types.KnoxNetworkPolicy, discover
 			if ok {
 				// Ingress policy for this endpoint exists already
 				mergedPolicy, updated := mergeIngressPolicies(existPolicy, []types.KnoxNetworkPolicy{newPolicy})
+				if AggregatePolicyPortRanges(&mergedPolicy) {
+					updated = true
+				}
+				updateFlowIDsFromEvidence(&mergedPolicy)
 				if updated {
 					mergedPolicy.Metadata["status"] = "updated"
-					existIngressPolicies[selector] = mergedPolicy
 				}
+				observed[mergedPolicy.Metadata["name"]] = true
+				existIngressPolicies[selector] = mergedPolicy
 			} else {
 				// Ingress policy for this endpoint does not exists previously
 				namedPolicy := GeneratePolicyName(policyNamesMap, newPolicy, clusterName)
@@ -961,10 +1073,18 @@ func UpdateDuplicatedPolicy(existingPolicies []types.KnoxNetworkPolicy, discover
 			if ok {
 				// Egress policy for this endpoint exists already
 				mergedPolicy, updated := mergeEgressPolicies(existPolicy, []types.KnoxNetworkPolicy{newPolicy})
+				if AggregatePolicyPortRanges(&mergedPolicy) {
+					updated = true
+				}
+				if AggregatePolicyFQDNs(&mergedPolicy) {
+					updated = true
+				}
+				updateFlowIDsFromEvidence(&mergedPolicy)
 				if updated {
 					mergedPolicy.Metadata["status"] = "updated"
-					existEgressPolicies[selector] = mergedPolicy
 				}
+				observed[mergedPolicy.Metadata["name"]] = true
+				existEgressPolicies[selector] = mergedPolicy
 			} else {
 				// Egress policy for this endpoint does not exists previously
 				namedPolicy := GeneratePolicyName(policyNamesMap, newPolicy, clusterName)
@@ -973,20 +1093,32 @@ func UpdateDuplicatedPolicy(existingPolicies []types.KnoxNetworkPolicy, discover
 		}
 	}
 
-	for _, policy := range existIngressPolicies {
-		if policy.Metadata["status"] == "updated" {
-			policy.Metadata["status"] = "latest"
-			//delete(policy.Metadata, "status")
-			updatedPolicies = append(updatedPolicies, policy)
-		}
-	}
-	for _, policy := range existEgressPolicies {
-		if policy.Metadata["status"] == "updated" {
-			policy.Metadata["status"] = "latest"
-			//delete(policy.Metadata, "status")
-			updatedPolicies = append(updatedPolicies, policy)
+	now := time.Now().Unix()
+
+	for _, existPolicies := range []map[Selector]types.KnoxNetworkPolicy{existIngressPolicies, existEgressPolicies} {
+		for _, policy := range existPolicies {
+			changed, specChanged := updateStaleRules(&policy, now)
+			if specChanged {
+				policy.Metadata["status"] = "updated"
+			}
+
+			// all the rules are pruned, the policy is outdated rather than left
+			// selecting the workload without any rule
+			if isPrunedPolicy(policy) {
+				policy.Metadata["status"] = "outdated"
+				updatedPolicies = append(updatedPolicies, policy)
+				continue
+			}
+
+			if policy.Metadata["status"] == "updated" {
+				policy.Metadata["status"] = "latest"
+				//delete(policy.Metadata, "status")
+				updatedPolicies = append(updatedPolicies, policy)
+			} else if changed || observed[policy.Metadata["name"]] {
+				observedPolicies = append(observedPolicies, policy)
+			}
 		}
 	}
 
-	return newPolicies, updatedPolicies
+	return newPolicies, updatedPolicies, observedPolicies
 }
@@ -2,6 +2,7 @@ package networkpolicy
 
 import (
 	"testing"
+	"time"
 
 	"github.com/accuknox/auto-policy-discovery/src/libs"
 	"github.com/accuknox/auto-policy-discovery/src/types"
@@ -502,3 +503,128 @@ func TestUpdateService(t *testing.T) {
 
 	assert.Equal(t, result, expected, ShouldBeEqual)
 }
+
+func TestUpdateDuplicatedPolicy_RuleStats(t *testing.T) {
+	StaleRuleWindow = 3600
+	StaleRuleAction = types.StaleRuleActionDrop
+	defer func() {
+		StaleRuleWindow = 0
+		StaleRuleAction = ""
+	}()
+
+	now := time.Now().Unix()
+
+	existPolicy := types.KnoxNetworkPolicy{
+		Kind: "KnoxNetworkPolicy",
+		Metadata: map[string]string{
+			"name":   "autopol-egress-test",
+			"status": "latest",
+			"type":   PolicyTypeEgress,
+		},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "test"}},
+			Egress: []types.Egress{
+				{
+					ToEntities: []string{"world"},
+					ToPorts:    []types.SpecPort{{Port: "443", Protocol: "TCP"}},
+					RuleStats:  types.RuleStats{FirstSeen: now - 7200, LastSeen: now - 60, HitCount: 1},
+				},
+				{
+					ToEntities: []string{"host"},
+					ToPorts:    []types.SpecPort{{Port: "443", Protocol: "TCP"}},
+					RuleStats:  types.RuleStats{FirstSeen: now - 7200, LastSeen: now - 7200, HitCount: 1},
+				},
+			},
+		},
+	}
+
+	newPolicy := types.KnoxNetworkPolicy{
+		Kind:     "KnoxNetworkPolicy",
+		Metadata: map[string]string{"type": PolicyTypeEgress},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "test"}},
+			Egress: []types.Egress{
+				{
+					ToEntities: []string{"world"},
+					ToPorts:    []types.SpecPort{{Port: "443", Protocol: "TCP"}},
+					RuleStats:  libs.NewRuleStats(2),
+				},
+			},
+		},
+	}
+
+	newPolicies, updatedPolicies, observedPolicies := UpdateDuplicatedPolicy(
+		[]types.KnoxNetworkPolicy{existPolicy}, []types.KnoxNetworkPolicy{newPolicy}, nil, "default")
+
+	assert.Empty(t, newPolicies)
+	assert.Empty(t, observedPolicies)
+	assert.Len(t, updatedPolicies, 1)
+
+	// the hits are accumulated
+	egress := updatedPolicies[0].Spec.Egress
+	assert.Equal(t, int64(3), egress[0].HitCount)
+	assert.Equal(t, now-7200, egress[0].FirstSeen)
+	assert.False(t, egress[0].Stale)
+
+	// not seen within the window
+	assert.True(t, egress[1].Stale)
+	assert.True(t, egress[1].Pruned)
+
+	// seen again
+	newPolicy.Spec.Egress[0].ToEntities = []string{"host"}
+	_, updatedPolicies, observedPolicies = UpdateDuplicatedPolicy(
+		updatedPolicies, []types.KnoxNetworkPolicy{newPolicy}, nil, "default")
+
+	assert.Empty(t, observedPolicies)
+	assert.Len(t, updatedPolicies, 1)
+	assert.False(t, updatedPolicies[0].Spec.Egress[1].Pruned)
+
+	// only the hits are changed
+	_, updatedPolicies, observedPolicies = UpdateDuplicatedPolicy(
+		updatedPolicies, []types.KnoxNetworkPolicy{newPolicy}, nil, "default")
+
+	assert.Empty(t, updatedPolicies)
+	assert.Len(t, observedPolicies, 1)
+	assert.Equal(t, int64(5), observedPolicies[0].Spec.Egress[1].HitCount)
+}
+
+func TestUpdateDuplicatedPolicy_AllRulesPruned(t *testing.T) {
+	StaleRuleWindow = 3600
+	StaleRuleAction = types.StaleRuleActionDrop
+	defer func() {
+		StaleRuleWindow = 0
+		StaleRuleAction = ""
+	}()
+
+	now := time.Now().Unix()
+
+	existPolicy := types.KnoxNetworkPolicy{
+		Kind: "KnoxNetworkPolicy",
+		Metadata: map[string]string{
+			"name":   "autopol-ingress-test",
+			"status": "latest",
+			"type":   PolicyTypeIngress,
+		},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "test"}},
+			Ingress: []types.Ingress{
+				{
+					MatchLabels: map[string]string{"app": "client"},
+					ToPorts:     []types.SpecPort{{Port: "80", Protocol: "TCP"}},
+					RuleStats:   types.RuleStats{FirstSeen: now - 7200, LastSeen: now - 7200, HitCount: 1},
+				},
+			},
+		},
+	}
+
+	newPolicies, updatedPolicies, observedPolicies := UpdateDuplicatedPolicy(
+		[]types.KnoxNetworkPolicy{existPolicy}, nil, nil, "default")
+
+	assert.Empty(t, newPolicies)
+	assert.Empty(t, observedPolicies)
+	assert.Len(t, updatedPolicies, 1)
+
+	// nothing is allowed anymore, the policy is outdated
+	assert.True(t, updatedPolicies[0].Spec.Ingress[0].Pruned)
+	assert.Equal(t, "outdated", updatedPolicies[0].Metadata["status"])
+}
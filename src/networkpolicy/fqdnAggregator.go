@@ -0,0 +1,156 @@
+package networkpolicy
+
+import (
+	"encoding/json"
+
+	"github.com/accuknox/auto-policy-discovery/src/common"
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ===================== //
+// == FQDN Aggregation == //
+// ===================== //
+
+// fqdnsInclude checks if the names are in the fqdn rules, or matched by their patterns
+func fqdnsInclude(fqdns []types.SpecFQDN, names []string) bool {
+	for _, name := range names {
+		included := false
+		for _, fqdn := range fqdns {
+			if libs.ContainsElement(fqdn.MatchNames, name) || libs.ContainsElement(fqdn.MatchPatterns, name) {
+				included = true
+				break
+			}
+			for _, pattern := range fqdn.MatchPatterns {
+				if common.MatchFQDNPattern(pattern, name) {
+					included = true
+					break
+				}
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	return true
+}
+
+// fqdnRuleNames returns the names of the fqdn rules
+func fqdnRuleNames(fqdns []types.SpecFQDN) []string {
+	names := []string{}
+	for _, fqdn := range fqdns {
+		names = append(names, fqdn.Names()...)
+	}
+	return names
+}
+
+// fqdnRuleKey returns the key of the L4 rules of the fqdn rule, empty if the rule
+// is not a candidate
+func fqdnRuleKey(egress types.Egress) string {
+	if len(egress.ToFQDNs) == 0 || egress.Pruned || len(egress.ICMPs) > 0 ||
+		len(egress.ToHTTPs) > 0 || len(egress.ToKafkas) > 0 {
+		return ""
+	}
+
+	b, err := json.Marshal(egress.ToPorts)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// aggregateEgressFQDNRules collapses the fqdn rules sharing the L4 rules into the
+// wildcard patterns, the rules of the names not aggregated are kept as is
+func aggregateEgressFQDNRules(egresses []types.Egress, threshold int, protectedSuffixes []string) []types.Egress {
+	if len(egresses) < 2 {
+		return egresses
+	}
+
+	keys := make([]string, len(egresses))
+	groups := map[string][]int{}
+	for i, egress := range egresses {
+		keys[i] = fqdnRuleKey(egress)
+		if keys[i] != "" {
+			groups[keys[i]] = append(groups[keys[i]], i)
+		}
+	}
+
+	// the aggregated rule of the group, placed at the first member absorbed
+	aggregated := map[int]types.Egress{}
+	absorbed := map[int]bool{}
+
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+
+		names := []string{}
+		existPatterns := []string{}
+		for _, i := range members {
+			names = append(names, fqdnRuleNames(egresses[i].ToFQDNs)...)
+			for _, fqdn := range egresses[i].ToFQDNs {
+				existPatterns = append(existPatterns, fqdn.MatchPatterns...)
+			}
+		}
+
+		_, patterns := common.AggregateFQDNs(names, threshold, protectedSuffixes)
+		if len(patterns) == len(common.StringDeDuplication(existPatterns)) {
+			// no new pattern
+			continue
+		}
+
+		patternRule := []types.SpecFQDN{{MatchPatterns: patterns}}
+		first := -1
+		var merged types.Egress
+
+		for _, i := range members {
+			if !fqdnsInclude(patternRule, fqdnRuleNames(egresses[i].ToFQDNs)) {
+				continue
+			}
+
+			if first < 0 {
+				first = i
+				merged = egresses[i]
+				merged.ToFQDNs = patternRule
+			} else {
+				merged.RuleStats = libs.CombineRuleStats(merged.RuleStats, egresses[i].RuleStats)
+			}
+			absorbed[i] = true
+		}
+
+		if first >= 0 {
+			aggregated[first] = merged
+		}
+	}
+
+	if len(aggregated) == 0 {
+		return egresses
+	}
+
+	results := []types.Egress{}
+	for i, egress := range egresses {
+		if merged, ok := aggregated[i]; ok {
+			results = append(results, merged)
+		} else if !absorbed[i] {
+			results = append(results, egress)
+		}
+	}
+
+	return results
+}
+
+// AggregatePolicyFQDNs collapses the fqdn rules of the sibling domains sharing the
+// L4 rules into the wildcard patterns, and returns true if the rules are changed
+func AggregatePolicyFQDNs(policy *types.KnoxNetworkPolicy) bool {
+	threshold := cfg.GetCfgNetworkFQDNThreshold()
+	if threshold <= 0 {
+		return false
+	}
+
+	egressCount := len(policy.Spec.Egress)
+	policy.Spec.Egress = aggregateEgressFQDNRules(policy.Spec.Egress, threshold, cfg.GetCfgNetworkFQDNProtectedSuffixes())
+
+	return egressCount != len(policy.Spec.Egress)
+}
@@ -0,0 +1,42 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverNetworkPolicyFQDNAggregation(t *testing.T) {
+	config.CurrentCfg.ConfigNetPolicy.FQDNThreshold = 2
+	defer func() { config.CurrentCfg.ConfigNetPolicy.FQDNThreshold = 0 }()
+
+	pods := []types.Pod{{Namespace: "default", PodName: "web-1", Labels: []string{"app=web"}}}
+
+	logs := []types.KnoxNetworkLog{}
+	for _, name := range []string{"a.s3.amazonaws.com", "b.s3.amazonaws.com", "c.s3.amazonaws.com", "api.example.com"} {
+		logs = append(logs, types.KnoxNetworkLog{
+			SrcNamespace: "default", SrcPodName: "web-1", DstReservedLabels: []string{ReservedWorld},
+			DNSQuery: name, Protocol: libs.IPProtocolTCP, DstPort: 443, Direction: "EGRESS", Action: "allow",
+		})
+	}
+
+	initMultiClusterVariables("default")
+	policies := DiscoverNetworkPolicy("default", logs, nil, pods)
+	assert.Len(t, policies, 1)
+
+	egress := policies[0].Spec.Egress
+	assert.Len(t, egress, 2)
+	assert.Equal(t, []types.SpecFQDN{{MatchPatterns: []string{"*.s3.amazonaws.com"}}}, egress[0].ToFQDNs)
+	assert.Equal(t, int64(3), egress[0].HitCount)
+	assert.Equal(t, []types.SpecFQDN{{MatchNames: []string{"api.example.com"}}}, egress[1].ToFQDNs)
+
+	// the name of the aggregated domain is merged into the pattern
+	policies[0].Metadata["name"] = "autopol-egress-web"
+	newPolicies := DiscoverNetworkPolicy("default", logs[:1], nil, pods)
+	merged, updated := mergeEgressPolicies(policies[0], newPolicies)
+	assert.False(t, updated)
+	assert.Len(t, merged.Spec.Egress, 2)
+}
@@ -75,6 +75,18 @@ func updateMultiClusterVariables(clusterName string) {
 	}
 }
 
+// GetDomainToIPs returns a copy of the ips resolved for the domains of the cluster
+func GetDomainToIPs(clusterName string) map[string][]string {
+	NetworkPolicyLock.Lock()
+	defer NetworkPolicyLock.Unlock()
+
+	domainToIPs := map[string][]string{}
+	for domain, ips := range ClusterVariableMap[clusterName].DomainToIPs {
+		domainToIPs[domain] = append([]string{}, ips...)
+	}
+	return domainToIPs
+}
+
 // =========================== //
 // == Network Policy Filter == //
 // =========================== //
@@ -121,11 +133,11 @@ func FilterNetworkLogsByConfig(logs []types.KnoxNetworkLog, pods []types.Pod) []
 			continue
 		}
 
-		if log.L7Protocol == libs.L7ProtocolHTTP && log.IsReply {
+		if libs.IsL7Request(log.L7Protocol) && log.IsReply {
 			continue
 		}
 
-		if log.L7Protocol != libs.L7ProtocolHTTP && log.Protocol == libs.IPProtocolTCP && !log.SynFlag { // In case of TCP only handle flows with SYN flag
+		if !libs.IsL7Request(log.L7Protocol) && log.Protocol == libs.IPProtocolTCP && !log.SynFlag { // In case of TCP only handle flows with SYN flag
 			continue
 		}
 
@@ -195,12 +207,13 @@ func FilterNetworkLogsByConfig(logs []types.KnoxNetworkLog, pods []types.Pod) []
 func FilterNetworkLogsByNamespace(targetNamespace string, logs []types.KnoxNetworkLog) []types.KnoxNetworkLog {
 	filteredLogs := []types.KnoxNetworkLog{}
 
-	for _, log := range logs {
-		if log.SrcNamespace == targetNamespace {
+	for i, log := range logs {
+		remoteSrc := isRemoteSrc(&logs[i])
+		if log.SrcNamespace == targetNamespace && !remoteSrc {
 			// Preferred case: group by src namespace
 			filteredLogs = append(filteredLogs, log)
-		} else if len(log.SrcReservedLabels) > 0 && log.DstNamespace == targetNamespace {
-			// When src is reserved: group by dst namespace
+		} else if (len(log.SrcReservedLabels) > 0 || remoteSrc) && log.DstNamespace == targetNamespace {
+			// When src is reserved or in the remote cluster: group by dst namespace
 			filteredLogs = append(filteredLogs, log)
 		}
 	}
@@ -305,10 +318,21 @@ func getNetworkLogs() []types.KnoxNetworkLog {
 		return nil
 	}
 
+	// flow ids are not persisted, continue from the ids the stored evidence already refers to
+	if libs.LastFlowID == 0 {
+		libs.SeedLastFlowID(CfgDB)
+	}
+
 	for i, log := range networkLogs {
 		if log.ClusterName == "" {
 			networkLogs[i].ClusterName = "Default"
 		}
+
+		// hubble/feed-consumer flows have no flow id, assign one to refer from the evidence
+		if log.FlowID == 0 {
+			libs.LastFlowID++
+			networkLogs[i].FlowID = int(libs.LastFlowID)
+		}
 	}
 
 	return networkLogs
@@ -544,13 +568,21 @@ func getFlowIDFromTrackMap2(aggregatedLabel string, dst Dst) []int {
 // == Domain To IP addrs == //
 // ======================== //
 
-func updateDNSFlows(networkLogs []types.KnoxNetworkLog) {
+func updateDNSFlows(networkLogs []types.KnoxNetworkLog, services []types.Service) {
 	// step 1: update dnsToIPs map
 	for _, log := range networkLogs {
 		if log.DNSRes != "" && len(log.DNSResIPs) > 0 {
 			domainName := log.DNSRes
 			newDNSIPs := log.DNSResIPs
 
+			// internal services, only the ExternalName service resolves to the outside
+			if libs.IsClusterDomain(domainName) {
+				domainName = getExternalNameFromDomain(domainName, services)
+				if domainName == "" {
+					continue
+				}
+			}
+
 			// udpate DNS to IPs map
 			if dnsIps, ok := DomainToIPs[domainName]; ok {
 				for _, ip := range newDNSIPs {
@@ -651,7 +683,7 @@ func removeDstFromMergedDstSlice(dsts []MergedPortDst, remove MergedPortDst) []M
 
 func checkK8sService(log types.KnoxNetworkLog, services []types.Service) (types.Service, bool) {
 	for _, svc := range services {
-		if log.DstIP == svc.ClusterIP {
+		if libs.IsSameIP(log.DstIP, svc.ClusterIP) || libs.ContainsIP(svc.ClusterIPs, log.DstIP) {
 			return svc, true
 		} else if svc.Type == "NodePort" {
 			if libs.ContainsElement(svc.ExternalIPs, log.DstIP) &&
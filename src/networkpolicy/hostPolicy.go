@@ -0,0 +1,138 @@
+package networkpolicy
+
+import (
+	"strconv"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ================================= //
+// == Host firewall policy (node) == //
+// ================================= //
+
+// HostNodeLabels is the labels of the nodes, key: node name - val: node labels
+var HostNodeLabels map[string]map[string]string
+
+// isHostNetworkLog checks if the log is from or to the local host of the node
+// observing it, i.e. the traffic subject to the host firewall
+func isHostNetworkLog(log types.KnoxNetworkLog) bool {
+	srcHost := log.SrcPodName == "" && libs.ContainsElement(log.SrcReservedLabels, ReservedHost)
+	dstHost := log.DstPodName == "" && libs.ContainsElement(log.DstReservedLabels, ReservedHost)
+
+	return log.NodeName != "" && srcHost != dstHost
+}
+
+// FilterHostNetworkLogs returns the logs subject to the host firewall
+func FilterHostNetworkLogs(logs []types.KnoxNetworkLog) []types.KnoxNetworkLog {
+	filteredLogs := []types.KnoxNetworkLog{}
+
+	for _, log := range logs {
+		if isHostNetworkLog(log) {
+			filteredLogs = append(filteredLogs, log)
+		}
+	}
+
+	return filteredLogs
+}
+
+// getHostNodeSelector returns the node selector of the host. If the node has any
+// of the configured labels, those are used so that the nodes sharing the labels
+// are merged, otherwise the node is selected by its hostname.
+func getHostNodeSelector(nodeName string) map[string]string {
+	selector := map[string]string{}
+
+	nodeLabels := HostNodeLabels[nodeName]
+	for _, key := range cfg.GetCfgNetworkHostPolicyNodeLabels() {
+		if val, ok := nodeLabels[key]; ok {
+			selector[key] = val
+		}
+	}
+
+	if len(selector) == 0 {
+		selector["kubernetes.io/hostname"] = nodeName
+	}
+
+	return selector
+}
+
+// getHostPeerLabels returns the labels of the pod peer; the host has no namespace,
+// so the namespace label is always set
+func getHostPeerLabels(namespace, podName string, pods []types.Pod) map[string]string {
+	matchLabels := getEndpointMatchLabels(podName, pods)
+	if len(matchLabels) == 0 {
+		return nil
+	}
+
+	matchLabels["io.kubernetes.pod.namespace"] = namespace
+	return matchLabels
+}
+
+// getHostL4Rules returns the port or the icmp rule of the log; the host firewall
+// has no L7 rules
+func getHostL4Rules(log *types.KnoxNetworkLog) ([]types.SpecPort, []types.SpecICMP) {
+	if libs.IsICMP(log.Protocol) {
+		return nil, []types.SpecICMP{{Family: libs.GetICMPFamily(log.Protocol), Type: uint8(log.ICMPType)}}
+	}
+
+	return []types.SpecPort{{Port: strconv.Itoa(log.DstPort), Protocol: libs.GetProtocol(log.Protocol)}}, nil
+}
+
+// convertHostNetworkLogToKnoxPolicy converts the host log into the host policy,
+// i.e. the ingress policy of the dst host, or the egress policy of the src host
+func convertHostNetworkLogToKnoxPolicy(log *types.KnoxNetworkLog, pods []types.Pod) (_, _ *types.KnoxNetworkPolicy) {
+	if !isHostNetworkLog(*log) {
+		return nil, nil
+	}
+
+	toPorts, icmps := getHostL4Rules(log)
+
+	if libs.ContainsElement(log.DstReservedLabels, ReservedHost) {
+		iPolicy := buildNewKnoxIngressPolicy()
+		iPolicy.Kind = types.KindKnoxHostNetworkPolicy
+		iPolicy.Spec.Selector.MatchLabels = getHostNodeSelector(log.NodeName)
+
+		ingress := types.Ingress{ToPorts: toPorts, ICMPs: icmps}
+		if log.SrcPodName != "" {
+			ingress.MatchLabels = getHostPeerLabels(log.SrcNamespace, log.SrcPodName, pods)
+			if ingress.MatchLabels == nil {
+				return nil, nil
+			}
+		} else if srcEntity := getEntityFromReservedLabels(log.SrcReservedLabels); srcEntity != "" {
+			ingress.FromEntities = []string{srcEntity}
+		} else {
+			return nil, nil
+		}
+
+		iPolicy.Spec.Ingress = append(iPolicy.Spec.Ingress, ingress)
+		return &iPolicy, nil
+	}
+
+	ePolicy := buildNewKnoxEgressPolicy()
+	ePolicy.Kind = types.KindKnoxHostNetworkPolicy
+	ePolicy.Spec.Selector.MatchLabels = getHostNodeSelector(log.NodeName)
+
+	egress := types.Egress{ToPorts: toPorts, ICMPs: icmps}
+	if log.DstPodName != "" {
+		egress.MatchLabels = getHostPeerLabels(log.DstNamespace, log.DstPodName, pods)
+		if egress.MatchLabels == nil {
+			return nil, nil
+		}
+	} else if dstEntity := getEntityFromReservedLabels(log.DstReservedLabels); dstEntity != "" {
+		egress.ToEntities = []string{dstEntity}
+	} else {
+		return nil, nil
+	}
+
+	ePolicy.Spec.Egress = append(ePolicy.Spec.Egress, egress)
+	return nil, &ePolicy
+}
+
+// DiscoverHostNetworkPolicy discovers the host firewall policies, selecting the
+// nodes, from the host logs
+func DiscoverHostNetworkPolicy(networkLogs []types.KnoxNetworkLog, pods []types.Pod) []types.KnoxNetworkPolicy {
+	return discoverNetworkPolicies(networkLogs, func(log *types.KnoxNetworkLog) (_, _ *types.KnoxNetworkPolicy) {
+		return convertHostNetworkLogToKnoxPolicy(log, pods)
+	})
+}
@@ -0,0 +1,68 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverHostNetworkPolicy(t *testing.T) {
+	saved := cfg.CurrentCfg.ConfigNetPolicy
+	savedNodeLabels := HostNodeLabels
+	defer func() {
+		cfg.CurrentCfg.ConfigNetPolicy = saved
+		HostNodeLabels = savedNodeLabels
+	}()
+
+	cfg.CurrentCfg.ConfigNetPolicy.HostPolicyNodeLabels = []string{"node-role.kubernetes.io/control-plane"}
+	HostNodeLabels = map[string]map[string]string{
+		"master-1": {"node-role.kubernetes.io/control-plane": "", "kubernetes.io/hostname": "master-1"},
+		"worker-1": {"kubernetes.io/hostname": "worker-1"},
+	}
+
+	pods := []types.Pod{
+		{Namespace: "monitoring", PodName: "node-exporter-scraper", Labels: []string{"app=prometheus"}},
+	}
+
+	logs := []types.KnoxNetworkLog{
+		// the pod to the host, ingress of the control plane
+		{NodeName: "master-1", SrcNamespace: "monitoring", SrcPodName: "node-exporter-scraper",
+			DstReservedLabels: []string{ReservedHost}, Protocol: libs.IPProtocolTCP, DstPort: 9100},
+		// the world to the host, ingress of the worker
+		{NodeName: "worker-1", SrcReservedLabels: []string{ReservedWorld},
+			DstReservedLabels: []string{ReservedHost}, Protocol: libs.IPProtocolTCP, DstPort: 22},
+		// the host to the world, egress of the worker
+		{NodeName: "worker-1", SrcReservedLabels: []string{ReservedHost},
+			DstReservedLabels: []string{ReservedWorld}, Protocol: libs.IPProtocolUDP, DstPort: 123},
+		// the pods only, not subject to the host firewall
+		{NodeName: "worker-1", SrcNamespace: "monitoring", SrcPodName: "node-exporter-scraper",
+			DstNamespace: "monitoring", DstPodName: "node-exporter-scraper", Protocol: libs.IPProtocolTCP, DstPort: 80},
+	}
+
+	hostLogs := FilterHostNetworkLogs(logs)
+	assert.Len(t, hostLogs, 3)
+
+	results := DiscoverHostNetworkPolicy(hostLogs, pods)
+	assert.Len(t, results, 3)
+
+	for _, policy := range results {
+		assert.Equal(t, types.KindKnoxHostNetworkPolicy, policy.Kind)
+		assert.Equal(t, ClusterwideNamespace, policy.Metadata["namespace"])
+
+		switch {
+		case policy.Spec.Selector.MatchLabels["kubernetes.io/hostname"] == "worker-1" && policy.Metadata["type"] == PolicyTypeEgress:
+			assert.Equal(t, []string{"world"}, policy.Spec.Egress[0].ToEntities)
+			assert.Equal(t, []types.SpecPort{{Port: "123", Protocol: "UDP"}}, policy.Spec.Egress[0].ToPorts)
+		case policy.Spec.Selector.MatchLabels["kubernetes.io/hostname"] == "worker-1":
+			assert.Equal(t, []string{"world"}, policy.Spec.Ingress[0].FromEntities)
+			assert.Equal(t, []types.SpecPort{{Port: "22", Protocol: "TCP"}}, policy.Spec.Ingress[0].ToPorts)
+		default:
+			// the control plane nodes are selected by the role
+			assert.Equal(t, map[string]string{"node-role.kubernetes.io/control-plane": ""}, policy.Spec.Selector.MatchLabels)
+			assert.Equal(t, map[string]string{"app": "prometheus", "io.kubernetes.pod.namespace": "monitoring"}, policy.Spec.Ingress[0].MatchLabels)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package networkpolicy
 
 import (
 	"net/http"
+	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
@@ -60,6 +61,104 @@ func CheckSpecHTTP(specs []string) bool {
 	return false
 }
 
+// getHTTPRule returns the http rule of the request, the host of the rule is the
+// regex matched against the host header
+func getHTTPRule(log types.KnoxNetworkLog) types.SpecHTTP {
+	return types.SpecHTTP{
+		Method:  log.HTTPMethod,
+		Path:    log.HTTPPath,
+		GRPC:    log.L7Protocol == libs.L7ProtocolGRPC,
+		Host:    regexp.QuoteMeta(log.HTTPHost),
+		Headers: log.HTTPHeaders,
+	}
+}
+
+// httpRulesInclude checks if the http rules allow the target rule; the rule without
+// the host allows any host
+func httpRulesInclude(httpRules []types.SpecHTTP, targetRule types.SpecHTTP) bool {
+	for _, httpRule := range httpRules {
+		target := targetRule
+		if httpRule.Host == "" {
+			target.Host = ""
+		}
+
+		if reflect.DeepEqual(httpRule, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the separator in the fields of the http info is escaped, since the paths and
+// the header values could have it
+var httpInfoEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`)
+
+// formatHTTPInfo returns the http rule as the string, method|path|grpc|host|headers,
+// the headers are the trailing fields
+func formatHTTPInfo(http types.SpecHTTP) string {
+	grpc := ""
+	if http.GRPC {
+		grpc = libs.L7ProtocolGRPC
+	}
+
+	fields := []string{http.Method, http.Path, grpc, http.Host}
+	if len(http.Headers) == 0 {
+		fields = append(fields, "")
+	}
+	fields = append(fields, http.Headers...)
+
+	for i := range fields {
+		fields[i] = httpInfoEscaper.Replace(fields[i])
+	}
+
+	return strings.Join(fields, "|")
+}
+
+// splitHTTPInfo splits the http info by the separator not escaped, and unescapes
+// the fields
+func splitHTTPInfo(httpInfo string) []string {
+	fields := []string{}
+
+	var field strings.Builder
+	escaped := false
+
+	for _, c := range httpInfo {
+		switch {
+		case escaped:
+			field.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(c)
+		}
+	}
+
+	return append(fields, field.String())
+}
+
+func parseHTTPInfo(httpInfo string) types.SpecHTTP {
+	info := splitHTTPInfo(httpInfo)
+	if len(info) < 2 {
+		return types.SpecHTTP{}
+	}
+
+	http := types.SpecHTTP{Method: info[0], Path: info[1]}
+	if len(info) >= 5 {
+		http.GRPC = info[2] == libs.L7ProtocolGRPC
+		http.Host = info[3]
+		if len(info) > 5 || info[4] != "" {
+			http.Headers = info[4:]
+		}
+	}
+
+	return http
+}
+
 // ================== //
 // == Get/Set Tree == //
 // ================== //
@@ -427,7 +526,7 @@ func AggregateHTTPRule(aggregatedSrcPerAggregatedDst map[string][]MergedPortDst)
 				continue
 			}
 
-			// httpTree = key: METHOD - val: Tree
+			// httpTree = key: METHOD|||HOST|HEADERS - val: Tree
 			httpTree := getHTTPTree(aggregatedSrc, dst)
 			if httpTree == nil {
 				httpTree = map[string]map[string]*Node{}
@@ -438,36 +537,47 @@ func AggregateHTTPRule(aggregatedSrcPerAggregatedDst map[string][]MergedPortDst)
 			methodToPaths := map[string][]string{}
 
 			for _, http := range dst.Additionals {
-				// http = method + path
-				if len(strings.Split(http, "|")) != 2 {
+				httpRule := parseHTTPInfo(http)
+				if httpRule.Method == "" {
+					continue
+				}
+
+				// gRPC, keep the service/method as is
+				if httpRule.GRPC {
+					updatedAdditionals = append(updatedAdditionals, http)
 					continue
 				}
 
-				method := strings.Split(http, "|")[0]
-				path := strings.Split(http, "|")[1]
+				// the paths are aggregated per method, host and headers,
+				// i.e. key = method|path(empty)|grpc(empty)|host|headers
+				path := httpRule.Path
+				httpRule.Path = ""
+				key := formatHTTPInfo(httpRule)
 
-				if val, ok := methodToPaths[method]; ok {
+				if val, ok := methodToPaths[key]; ok {
 					if !libs.ContainsElement(val, path) {
 						val = append(val, path)
 					}
-					methodToPaths[method] = val
+					methodToPaths[key] = val
 				} else {
-					methodToPaths[method] = []string{path}
+					methodToPaths[key] = []string{path}
 				}
 			}
 
-			for method, paths := range methodToPaths {
+			for key, paths := range methodToPaths {
 				httpPathTree := map[string]*Node{}
-				if existed, ok := httpTree[method]; ok {
+				if existed, ok := httpTree[key]; ok {
 					httpPathTree = existed
 				}
 
+				httpRule := parseHTTPInfo(key)
 				aggregatedPaths := AggregatePaths(httpPathTree, paths)
 				for _, aggPath := range aggregatedPaths {
-					updatedAdditionals = append(updatedAdditionals, method+"|"+aggPath)
+					httpRule.Path = aggPath
+					updatedAdditionals = append(updatedAdditionals, formatHTTPInfo(httpRule))
 				}
 
-				httpTree[method] = httpPathTree
+				httpTree[key] = httpPathTree
 			}
 
 			dsts[i].Additionals = updatedAdditionals
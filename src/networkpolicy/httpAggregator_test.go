@@ -3,6 +3,7 @@ package networkpolicy
 import (
 	"testing"
 
+	"github.com/accuknox/auto-policy-discovery/src/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,3 +22,56 @@ func TestGetChildNodesCount(t *testing.T) {
 
 	assert.Equal(t, 1, actual)
 }
+
+// ========================= //
+// == HTTP rule functions == //
+// ========================= //
+
+func TestFormatParseHTTPInfo(t *testing.T) {
+	rules := []types.SpecHTTP{
+		{Method: "GET", Path: "/api/v1/users"},
+		{Method: "POST", Path: "/hipstershop.CartService/GetCart", GRPC: true},
+		{Method: "GET", Path: "/", Host: "shop.example.com", Headers: []string{"x-api-version: 2", "x-tenant: a,b"}},
+		// the separator and the escape in the fields
+		{Method: "GET", Path: "/search/a|b", Host: `shop\.example\.com`, Headers: []string{"x-filter: a|b", `x-path: c:\d`}},
+		{Method: "GET", Path: "/", Headers: []string{"x-empty:"}},
+	}
+
+	for _, rule := range rules {
+		assert.Equal(t, rule, parseHTTPInfo(formatHTTPInfo(rule)))
+	}
+}
+
+func TestAggregateHTTPRulePerHost(t *testing.T) {
+	L7DiscoveryLevel = 2
+	MergedSrcPerMergedDstForHTTP = map[string][]*HTTPDst{}
+
+	dsts := map[string][]MergedPortDst{
+		"src": {{
+			Namespace: "default",
+			Additionals: []string{
+				formatHTTPInfo(types.SpecHTTP{Method: "GET", Path: "/index", Host: "a.example.com"}),
+				formatHTTPInfo(types.SpecHTTP{Method: "GET", Path: "/index", Host: "b.example.com"}),
+			},
+		}},
+	}
+
+	AggregateHTTPRule(dsts)
+
+	assert.ElementsMatch(t, []string{
+		formatHTTPInfo(types.SpecHTTP{Method: "GET", Path: "/index", Host: "a.example.com"}),
+		formatHTTPInfo(types.SpecHTTP{Method: "GET", Path: "/index", Host: "b.example.com"}),
+	}, dsts["src"][0].Additionals)
+}
+
+func TestHTTPRulesInclude(t *testing.T) {
+	log := types.KnoxNetworkLog{HTTPMethod: "GET", HTTPPath: "/cart", HTTPHost: "shop.example.com"}
+	httpRule := getHTTPRule(log)
+	assert.Equal(t, `shop\.example\.com`, httpRule.Host)
+
+	// the stored rule without the host allows any host
+	assert.True(t, httpRulesInclude([]types.SpecHTTP{{Method: "GET", Path: "/cart"}}, httpRule))
+	assert.True(t, httpRulesInclude([]types.SpecHTTP{httpRule}, httpRule))
+	assert.False(t, httpRulesInclude([]types.SpecHTTP{{Method: "GET", Path: "/cart", Host: `api\.example\.com`}}, httpRule))
+	assert.False(t, httpRulesInclude([]types.SpecHTTP{httpRule}, types.SpecHTTP{Method: "GET", Path: "/cart"}))
+}
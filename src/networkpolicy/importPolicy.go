@@ -0,0 +1,130 @@
+package networkpolicy
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/cluster"
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/plugin"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	nv1 "k8s.io/api/networking/v1"
+)
+
+// ======================= //
+// == Policy Import == //
+// ======================= //
+
+// ConvertAppliedNetworkPolicy converts the cilium or the k8s network policy into
+// the knox policies
+func ConvertAppliedNetworkPolicy(policy types.AppliedPolicy) ([]types.KnoxNetworkPolicy, error) {
+	spec, err := json.Marshal(policy.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	switch policy.Kind {
+	case types.KindCiliumNetworkPolicy, types.KindCiliumClusterwideNetworkPolicy:
+		ciliumPolicy := types.CiliumNetworkPolicy{
+			Kind:     policy.Kind,
+			Metadata: map[string]string{"name": policy.Name, "namespace": policy.Namespace},
+		}
+		if err := json.Unmarshal(spec, &ciliumPolicy.Spec); err != nil {
+			return nil, err
+		}
+		return plugin.ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy)
+	case types.KindK8sNetworkPolicy:
+		k8NetPol := nv1.NetworkPolicy{}
+		k8NetPol.Name = policy.Name
+		k8NetPol.Namespace = policy.Namespace
+		if err := json.Unmarshal(spec, &k8NetPol.Spec); err != nil {
+			return nil, err
+		}
+		return plugin.ConvertK8sNetworkPolicyToKnoxNetworkPolicies(k8NetPol)
+	}
+
+	return nil, nil
+}
+
+// ImportNetworkPolicies imports the existing Cilium and k8s network policies as
+// the baseline of the deduplication, so that only the new behaviour is proposed.
+// The imported policies keep their names and are never proposed themselves.
+func ImportNetworkPolicies() {
+	policies, err := cluster.GetPoliciesToImport(cfg.GetCfgNetworkImportPoliciesFrom(), cfg.GetCfgNetworkImportPoliciesDir())
+	if err != nil {
+		log.Error().Msgf("failed to read the network policies to import err=%s", err.Error())
+		return
+	}
+
+	clusterName := cfg.GetCfgClusterName()
+	generatedTime := time.Now().Unix()
+
+	imported := []types.KnoxNetworkPolicy{}
+	for _, policy := range policies {
+		knoxPolicies, err := ConvertAppliedNetworkPolicy(policy)
+		if err != nil {
+			log.Warn().Msgf("failed to convert the %s %s/%s err=%s", policy.Kind, policy.Namespace, policy.Name, err.Error())
+			continue
+		}
+
+		for _, knoxPolicy := range knoxPolicies {
+			knoxPolicy.Metadata["cluster_name"] = clusterName
+			knoxPolicy.GeneratedTime = generatedTime
+			imported = append(imported, knoxPolicy)
+		}
+	}
+
+	if err := libs.DeleteImportedPolicies(CfgDB, types.PolicyTypeNetwork, clusterName); err != nil {
+		log.Error().Msgf("failed to remove the previously imported network policies err=%s", err.Error())
+		return
+	}
+	libs.InsertNetworkPolicies(CfgDB, imported)
+
+	log.Info().Msgf("-> Network policy import done, [%d] policies imported", len(imported))
+}
+
+// removeImportedRules removes the discovered rules already allowed by the
+// imported policies
+func removeImportedRules(importedPolicies, discoveredPolicies []types.KnoxNetworkPolicy) []types.KnoxNetworkPolicy {
+	if len(importedPolicies) == 0 {
+		return discoveredPolicies
+	}
+
+	results := []types.KnoxNetworkPolicy{}
+
+	for _, policy := range discoveredPolicies {
+		remained := policy
+		remained.Spec.Egress = nil
+		remained.Spec.Ingress = nil
+
+		for _, single := range splitPolicyRules(policy) {
+			if isImportedRule(importedPolicies, single) {
+				continue
+			}
+			remained.Spec.Egress = append(remained.Spec.Egress, single.Spec.Egress...)
+			remained.Spec.Ingress = append(remained.Spec.Ingress, single.Spec.Ingress...)
+		}
+
+		if len(remained.Spec.Egress) == 0 && len(remained.Spec.Ingress) == 0 {
+			continue
+		}
+
+		updateFlowIDsFromEvidence(&remained)
+		results = append(results, remained)
+	}
+
+	return results
+}
+
+// isImportedRule checks if any of the imported policies allows the single rule,
+// an applied policy selecting the workload is converted to the imported policies
+// of its own
+func isImportedRule(importedPolicies []types.KnoxNetworkPolicy, single types.KnoxNetworkPolicy) bool {
+	for _, imported := range importedPolicies {
+		if isKnownRule([]types.KnoxNetworkPolicy{imported}, single) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveImportedRules(t *testing.T) {
+	imported := types.KnoxNetworkPolicy{
+		Kind:     types.KindKnoxNetworkPolicy,
+		Metadata: map[string]string{"name": "web", "namespace": "default", "type": "egress", "status": types.PolicyStatusImported},
+	}
+	imported.Spec.Selector.MatchLabels = map[string]string{"app": "web"}
+	imported.Spec.Egress = []types.Egress{
+		{MatchLabels: map[string]string{"app": "redis"}, ToPorts: []types.SpecPort{{Port: "6379", Protocol: "TCP"}}},
+	}
+
+	discovered := types.KnoxNetworkPolicy{
+		Kind:     types.KindKnoxNetworkPolicy,
+		Metadata: map[string]string{"namespace": "default", "type": "egress", "rule": "matchLabels"},
+	}
+	discovered.Spec.Selector.MatchLabels = map[string]string{"app": "web"}
+	discovered.Spec.Egress = []types.Egress{
+		{MatchLabels: map[string]string{"app": "redis"}, ToPorts: []types.SpecPort{{Port: "6379", Protocol: "TCP"}}},
+		{MatchLabels: map[string]string{"app": "db"}, ToPorts: []types.SpecPort{{Port: "5432", Protocol: "TCP"}}},
+	}
+
+	results := removeImportedRules([]types.KnoxNetworkPolicy{imported}, []types.KnoxNetworkPolicy{discovered})
+	assert.Len(t, results, 1)
+	assert.Equal(t, []types.Egress{discovered.Spec.Egress[1]}, results[0].Spec.Egress)
+
+	// the policy fully allowed by the imported policy is not proposed
+	discovered.Spec.Egress = discovered.Spec.Egress[:1]
+	results = removeImportedRules([]types.KnoxNetworkPolicy{imported}, []types.KnoxNetworkPolicy{discovered})
+	assert.Empty(t, results)
+}
@@ -0,0 +1,142 @@
+package networkpolicy
+
+import (
+	"strconv"
+	"strings"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ============================ //
+// == Ingress from the world == //
+// ============================ //
+
+const (
+	IngressWorldFromEntity = "entity"
+	IngressWorldFromCIDR   = "cidr"
+)
+
+// isExternalIngress checks if the log is the ingress from the ip without the
+// identity, i.e. neither the pod nor the reserved entity
+func isExternalIngress(log *types.KnoxNetworkLog) bool {
+	return cfg.GetCfgNetworkIngressWorldFrom() != "" && log.DstPodName != "" &&
+		log.SrcPodName == "" && len(log.SrcReservedLabels) == 0 && log.SrcIP != ""
+}
+
+// getIngressControllerPod returns the ingress controller pod having the ip; the
+// controller running in the host network is skipped since it has the node ip
+// shared by the other host processes
+func getIngressControllerPod(ip string, pods []types.Pod) (types.Pod, bool) {
+	controllerLabels := cfg.GetCfgNetworkIngressControllerLabels()
+	if ip == "" || len(controllerLabels) == 0 {
+		return types.Pod{}, false
+	}
+
+	for _, pod := range pods {
+		if pod.HostNetwork {
+			continue
+		}
+
+		if !libs.IsSameIP(pod.PodIP, ip) && !libs.ContainsIP(pod.PodIPs, ip) {
+			continue
+		}
+
+		for _, label := range controllerLabels {
+			if libs.ContainsElement(pod.Labels, label) {
+				return pod, true
+			}
+		}
+	}
+
+	return types.Pod{}, false
+}
+
+// isExposedService checks if the service is reachable from outside the cluster
+func isExposedService(svc types.Service) bool {
+	return svc.Type == "LoadBalancer" || svc.Type == "NodePort" || len(svc.ExternalIPs) > 0
+}
+
+// getExposedPorts returns the ports of the pod exposed by the LoadBalancer/NodePort
+// services or the external ips, i.e. the target ports of the services
+func getExposedPorts(namespace, podName string, services []types.Service, pods []types.Pod) []types.SpecPort {
+	exposedPorts := []types.SpecPort{}
+
+	podLabels := []string{}
+	for _, pod := range pods {
+		if pod.Namespace == namespace && pod.PodName == podName {
+			podLabels = pod.Labels
+			break
+		}
+	}
+
+	for _, svc := range services {
+		if svc.Namespace != namespace || !isExposedService(svc) || !selectsPod(svc.Selector, podLabels) {
+			continue
+		}
+
+		// the named target port is unknown, use the service port
+		port := svc.TargetPort
+		if port == 0 {
+			port = svc.ServicePort
+		}
+
+		exposedPort := types.SpecPort{Port: strconv.Itoa(port), Protocol: strings.ToUpper(svc.Protocol)}
+		if !libs.ContainsElement(exposedPorts, exposedPort) {
+			exposedPorts = append(exposedPorts, exposedPort)
+		}
+	}
+
+	return exposedPorts
+}
+
+// setIngressSource sets the source of the ingress rule, and returns false if the
+// rule should not be discovered.
+//   - the ingress controller pod: if enabled, the labels of the controller
+//   - the world: if enabled, fromEntities world or fromCIDRs only on the exposed ports
+//   - the other entities: fromEntities
+func setIngressSource(ingress *types.Ingress, log *types.KnoxNetworkLog, services []types.Service, pods []types.Pod) bool {
+	from := cfg.GetCfgNetworkIngressWorldFrom()
+
+	if from != "" {
+		if controller, ok := getIngressControllerPod(log.SrcIP, pods); ok {
+			ingress.MatchLabels = getLabelMapFromArray(controller.Labels)
+			if controller.Namespace != log.DstNamespace {
+				// cross namespace policy
+				ingress.MatchLabels["io.kubernetes.pod.namespace"] = controller.Namespace
+			}
+			return true
+		}
+	}
+
+	srcEntity := getEntityFromReservedLabels(log.SrcReservedLabels)
+	if len(log.SrcReservedLabels) == 0 && isExternalIngress(log) {
+		srcEntity = "world"
+	}
+	if srcEntity == "" {
+		return false
+	}
+
+	if srcEntity != "world" || from == "" {
+		ingress.FromEntities = append(ingress.FromEntities, srcEntity)
+		return true
+	}
+
+	toPort := types.SpecPort{Port: strconv.Itoa(log.DstPort), Protocol: libs.GetProtocol(log.Protocol)}
+	if !libs.ContainsElement(getExposedPorts(log.DstNamespace, log.DstPodName, services, pods), toPort) {
+		// the world reaches only the exposed ports
+		return false
+	}
+
+	if from == IngressWorldFromCIDR {
+		cidrBits, cidrBitsV6 := cfg.GetCfgNetworkIngressWorldCIDRBits()
+		if cidr := libs.GetCIDR(log.SrcIP, cidrBits, cidrBitsV6); cidr != "" {
+			ingress.FromCIDRs = []types.SpecCIDR{{CIDRs: []string{cidr}}}
+			return true
+		}
+	}
+
+	ingress.FromEntities = append(ingress.FromEntities, srcEntity)
+	return true
+}
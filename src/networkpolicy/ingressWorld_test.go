@@ -0,0 +1,87 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngressFromWorld(t *testing.T) {
+	saved := cfg.CurrentCfg.ConfigNetPolicy
+	defer func() { cfg.CurrentCfg.ConfigNetPolicy = saved }()
+
+	cfg.CurrentCfg.ConfigNetPolicy.IngressWorldFrom = IngressWorldFromCIDR
+	cfg.CurrentCfg.ConfigNetPolicy.IngressWorldCIDRBits = 24
+	cfg.CurrentCfg.ConfigNetPolicy.IngressWorldCIDRBitsV6 = 64
+	cfg.CurrentCfg.ConfigNetPolicy.IngressControllerLabels = []string{"app.kubernetes.io/name=ingress-nginx"}
+
+	pods := []types.Pod{
+		{Namespace: "shop", PodName: "web-1", Labels: []string{"app=web"}, PodIP: "10.0.1.5"},
+		{Namespace: "ingress-nginx", PodName: "controller-1", Labels: []string{"app.kubernetes.io/name=ingress-nginx"}, PodIP: "10.0.2.10"},
+		{Namespace: "ingress-nginx", PodName: "controller-2", Labels: []string{"app.kubernetes.io/name=ingress-nginx"}, PodIP: "192.168.0.10", HostNetwork: true},
+	}
+	services := []types.Service{
+		{Namespace: "shop", ServiceName: "web", Type: "LoadBalancer", Protocol: "TCP", ServicePort: 80, TargetPort: 8080, Selector: map[string]string{"app": "web"}},
+		{Namespace: "shop", ServiceName: "web-admin", Type: "ClusterIP", Protocol: "TCP", ServicePort: 9000, TargetPort: 9000, Selector: map[string]string{"app": "web"}},
+	}
+
+	// world to the exposed port, aggregated into the cidr
+	log := types.KnoxNetworkLog{
+		DstNamespace: "shop", DstPodName: "web-1", SrcReservedLabels: []string{ReservedWorld},
+		SrcIP: "203.0.113.7", Protocol: libs.IPProtocolTCP, DstPort: 8080,
+	}
+	ingress, _ := convertKnoxNetworkLogToKnoxNetworkPolicy(&log, services, pods)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, []types.SpecCIDR{{CIDRs: []string{"203.0.113.0/24"}}}, ingress.Spec.Ingress[0].FromCIDRs)
+	assert.Equal(t, []types.SpecPort{{Port: "8080", Protocol: "TCP"}}, ingress.Spec.Ingress[0].ToPorts)
+
+	// world to the port not exposed outside the cluster
+	log.DstPort = 9000
+	ingress, _ = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, services, pods)
+	assert.Nil(t, ingress)
+
+	// the external ip without the identity
+	cfg.CurrentCfg.ConfigNetPolicy.IngressWorldFrom = IngressWorldFromEntity
+	log = types.KnoxNetworkLog{
+		DstNamespace: "shop", DstPodName: "web-1", SrcIP: "198.51.100.1", Protocol: libs.IPProtocolTCP, DstPort: 8080,
+	}
+	ingress, _ = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, services, pods)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, []string{"world"}, ingress.Spec.Ingress[0].FromEntities)
+
+	// the ingress controller pod without the identity
+	log = types.KnoxNetworkLog{
+		DstNamespace: "shop", DstPodName: "web-1", SrcIP: "10.0.2.10", Protocol: libs.IPProtocolTCP, DstPort: 8080,
+	}
+	ingress, _ = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, services, pods)
+	assert.NotNil(t, ingress)
+	assert.Empty(t, ingress.Spec.Ingress[0].FromEntities)
+	assert.Equal(t, map[string]string{
+		"app.kubernetes.io/name":      "ingress-nginx",
+		"io.kubernetes.pod.namespace": "ingress-nginx",
+	}, ingress.Spec.Ingress[0].MatchLabels)
+
+	// the host network ingress controller is not matched by the node ip
+	log = types.KnoxNetworkLog{
+		DstNamespace: "shop", DstPodName: "web-1", SrcReservedLabels: []string{"reserved:host"},
+		SrcIP: "192.168.0.10", Protocol: libs.IPProtocolTCP, DstPort: 8080,
+	}
+	ingress, _ = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, services, pods)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, []string{"host"}, ingress.Spec.Ingress[0].FromEntities)
+	assert.Empty(t, ingress.Spec.Ingress[0].MatchLabels)
+
+	// the controller is not attributed if disabled
+	cfg.CurrentCfg.ConfigNetPolicy.IngressWorldFrom = ""
+	log = types.KnoxNetworkLog{
+		DstNamespace: "shop", DstPodName: "web-1", SrcReservedLabels: []string{ReservedWorld},
+		SrcIP: "10.0.2.10", Protocol: libs.IPProtocolTCP, DstPort: 8080,
+	}
+	ingress, _ = convertKnoxNetworkLogToKnoxNetworkPolicy(&log, services, pods)
+	assert.NotNil(t, ingress)
+	assert.Equal(t, []string{"world"}, ingress.Spec.Ingress[0].FromEntities)
+	assert.Empty(t, ingress.Spec.Ingress[0].MatchLabels)
+}
@@ -0,0 +1,114 @@
+package networkpolicy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ======================= //
+// == Kafka aggregation == //
+// ======================= //
+
+// getKafkaRule returns the kafka rule of the request; the api key is kept only
+// if it is not specific to the produce/consume role, e.g. metadata
+func getKafkaRule(log types.KnoxNetworkLog) types.SpecKafka {
+	rule := types.SpecKafka{Topic: log.KafkaTopic}
+
+	if role := libs.KafkaRole(log.KafkaAPIKey); role != "" {
+		rule.Role = role
+	} else {
+		rule.APIKey = strings.ToLower(log.KafkaAPIKey)
+	}
+
+	return rule
+}
+
+// getKafkaInfo returns the kafka rule as the string, role|apiKey|topic
+func getKafkaInfo(log types.KnoxNetworkLog) string {
+	rule := getKafkaRule(log)
+	return rule.Role + "|" + rule.APIKey + "|" + rule.Topic
+}
+
+func parseKafkaInfo(kafkaInfo string) types.SpecKafka {
+	info := strings.Split(kafkaInfo, "|")
+	if len(info) != 3 {
+		return types.SpecKafka{}
+	}
+
+	return types.SpecKafka{Role: info[0], APIKey: info[1], Topic: info[2]}
+}
+
+// AggregateKafkaRules aggregates the kafka rules of a client workload into the
+// produce/consume topics; the rules without the topic (e.g. joingroup) and the
+// api keys allowed by the roles (e.g. metadata) are covered by the role rules
+func AggregateKafkaRules(rules []types.SpecKafka) []types.SpecKafka {
+	// key: role, value: topics
+	roleTopics := map[string]map[string]bool{}
+	for _, rule := range rules {
+		if rule.Role != "" && rule.Topic != "" {
+			if roleTopics[rule.Role] == nil {
+				roleTopics[rule.Role] = map[string]bool{}
+			}
+			roleTopics[rule.Role][rule.Topic] = true
+		}
+	}
+
+	results := []types.SpecKafka{}
+	for _, rule := range rules {
+		if rule.Role != "" && rule.Topic == "" && roleTopics[rule.Role] != nil {
+			continue
+		}
+		if rule.APIKey != "" && rule.ClientID == "" && isKafkaRoleCovered(roleTopics, rule) {
+			continue
+		}
+		if !libs.ContainsElement(results, rule) {
+			results = append(results, rule)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Role != results[j].Role {
+			return results[i].Role < results[j].Role
+		}
+		if results[i].APIKey != results[j].APIKey {
+			return results[i].APIKey < results[j].APIKey
+		}
+		return results[i].Topic < results[j].Topic
+	})
+
+	return results
+}
+
+// isKafkaRoleCovered checks if the api key rule is allowed by a role rule, i.e.
+// the api key is a member of the role, and the role has the topic if any
+func isKafkaRoleCovered(roleTopics map[string]map[string]bool, rule types.SpecKafka) bool {
+	for role, topics := range roleTopics {
+		if libs.KafkaRoleIncludes(role, rule.APIKey) && (rule.Topic == "" || topics[rule.Topic]) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeKafkaRules merges the new kafka rules into the existing ones, and returns
+// true if the existing rules are updated
+func mergeKafkaRules(existRules *[]types.SpecKafka, newRules []types.SpecKafka) bool {
+	updated := false
+
+	merged := *existRules
+	for _, rule := range newRules {
+		if !libs.ContainsElement(merged, rule) {
+			merged = append(merged, rule)
+			updated = true
+		}
+	}
+
+	if updated {
+		*existRules = AggregateKafkaRules(merged)
+	}
+
+	return updated
+}
@@ -0,0 +1,63 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKafkaRule(t *testing.T) {
+	assert.Equal(t, types.SpecKafka{Role: "produce", Topic: "orders"},
+		getKafkaRule(types.KnoxNetworkLog{KafkaAPIKey: "produce", KafkaTopic: "orders"}))
+	assert.Equal(t, types.SpecKafka{Role: "consume", Topic: "orders"},
+		getKafkaRule(types.KnoxNetworkLog{KafkaAPIKey: "fetch", KafkaTopic: "orders"}))
+	assert.Equal(t, types.SpecKafka{APIKey: "metadata"},
+		getKafkaRule(types.KnoxNetworkLog{KafkaAPIKey: "metadata"}))
+
+	log := types.KnoxNetworkLog{KafkaAPIKey: "offsetfetch", KafkaTopic: "payments"}
+	assert.Equal(t, getKafkaRule(log), parseKafkaInfo(getKafkaInfo(log)))
+}
+
+func TestAggregateKafkaRules(t *testing.T) {
+	rules := []types.SpecKafka{
+		{Role: "produce", Topic: "orders"},
+		{Role: "consume", Topic: "payments"},
+		{Role: "consume", Topic: "orders"},
+		{Role: "consume"}, // e.g. joingroup
+		{APIKey: "metadata"},
+		{Role: "produce", Topic: "orders"},
+	}
+
+	expected := []types.SpecKafka{
+		{Role: "consume", Topic: "orders"},
+		{Role: "consume", Topic: "payments"},
+		{Role: "produce", Topic: "orders"},
+	}
+	assert.Equal(t, expected, AggregateKafkaRules(rules))
+
+	// metadata only
+	assert.Equal(t, []types.SpecKafka{{APIKey: "metadata"}}, AggregateKafkaRules([]types.SpecKafka{{APIKey: "metadata"}}))
+
+	// the api keys not allowed by the roles, or of the other topics, are kept
+	rules = []types.SpecKafka{
+		{Role: "produce", Topic: "orders"},
+		{APIKey: "describeconfigs"},
+		{APIKey: "metadata", Topic: "audit"},
+		{APIKey: "apiversions"},
+	}
+	expected = []types.SpecKafka{
+		{APIKey: "describeconfigs"},
+		{APIKey: "metadata", Topic: "audit"},
+		{Role: "produce", Topic: "orders"},
+	}
+	assert.Equal(t, expected, AggregateKafkaRules(rules))
+}
+
+func TestMergeKafkaRules(t *testing.T) {
+	exist := []types.SpecKafka{{Role: "produce", Topic: "orders"}}
+
+	assert.False(t, mergeKafkaRules(&exist, []types.SpecKafka{{Role: "produce", Topic: "orders"}}))
+	assert.True(t, mergeKafkaRules(&exist, []types.SpecKafka{{Role: "consume", Topic: "orders"}}))
+	assert.Equal(t, []types.SpecKafka{{Role: "consume", Topic: "orders"}, {Role: "produce", Topic: "orders"}}, exist)
+}
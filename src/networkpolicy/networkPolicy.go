@@ -4,9 +4,11 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/accuknox/auto-policy-discovery/src/cluster"
+	"github.com/accuknox/auto-policy-discovery/src/common"
 	"github.com/accuknox/auto-policy-discovery/src/config"
 	cfg "github.com/accuknox/auto-policy-discovery/src/config"
 	fc "github.com/accuknox/auto-policy-discovery/src/feedconsumer"
@@ -14,7 +16,6 @@ import (
 	logger "github.com/accuknox/auto-policy-discovery/src/logging"
 	"github.com/accuknox/auto-policy-discovery/src/plugin"
 	"github.com/accuknox/auto-policy-discovery/src/types"
-	cu "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/utils"
 	"github.com/clarketm/json"
 	"github.com/google/go-cmp/cmp"
 	"sigs.k8s.io/yaml"
@@ -53,16 +54,17 @@ const (
 	EGRESS_INGRESS = 3
 
 	// discovery rule type
-	MATCH_LABELS  = 1 << 0 // 1
-	TO_ICMPS      = 1 << 1 // 2
-	TO_PORTS      = 1 << 2 // 4
-	TO_HTTPS      = 1 << 3 // 8
-	TO_CIDRS      = 1 << 4 // 16
-	TO_ENTITIES   = 1 << 5 // 32
-	TO_SERVICES   = 1 << 6 // 64
-	TO_FQDNS      = 1 << 7 // 126
-	FROM_CIDRS    = 1 << 8 // 256
-	FROM_ENTITIES = 1 << 9 // 512
+	MATCH_LABELS  = 1 << 0  // 1
+	TO_ICMPS      = 1 << 1  // 2
+	TO_PORTS      = 1 << 2  // 4
+	TO_HTTPS      = 1 << 3  // 8
+	TO_CIDRS      = 1 << 4  // 16
+	TO_ENTITIES   = 1 << 5  // 32
+	TO_SERVICES   = 1 << 6  // 64
+	TO_FQDNS      = 1 << 7  // 126
+	FROM_CIDRS    = 1 << 8  // 256
+	FROM_ENTITIES = 1 << 9  // 512
+	TO_KAFKAS     = 1 << 10 // 1024
 )
 
 const (
@@ -82,6 +84,9 @@ const (
 // NetworkWorkerStatus global worker
 var NetworkWorkerStatus string
 
+// NetworkPolicyLock serializes the discovery cycle and the other updates of the policies in db
+var NetworkPolicyLock sync.Mutex
+
 // for cron job
 var NetworkCronJob *cron.Cron
 
@@ -95,6 +100,7 @@ var NetworkLogFile string
 var NetworkPolicyTo string
 
 var CIDRBits int
+var CIDRBitsV6 int
 var HTTPThreshold int
 
 var L3DiscoveryLevel int
@@ -104,6 +110,23 @@ var L7DiscoveryLevel int
 var NetworkLogFilters []types.NetworkLogFilter
 var NamespaceFilters []string
 
+var PolicyNameTemplate string
+
+var BaselinePolicy bool
+var BaselineDNSAllow bool
+
+var StaleRuleWindow int64
+var StaleRuleAction string
+
+var RuleThreshold types.RuleThreshold
+var RuleTypeThresholds map[string]types.RuleThreshold
+var RuleStagingTTL int64
+
+var WorkloadStateThreshold types.WorkloadStateThreshold
+var AnomalyMode bool
+
+var GapAnalysis bool
+
 // init Function
 func init() {
 	NetworkWorkerStatus = STATUS_IDLE
@@ -125,10 +148,30 @@ func InitNetPolicyDiscoveryConfiguration() {
 	L7DiscoveryLevel = cfg.GetCfgNetworkL7Level()
 
 	CIDRBits = cfg.GetCfgCIDRBits()
+	CIDRBitsV6 = cfg.GetCfgCIDRBitsV6()
 	HTTPThreshold = cfg.GetCfgNetworkHTTPThreshold()
 
 	NetworkLogFilters = cfg.GetCfgNetworkLogFilters()
 	NamespaceFilters = cfg.GetCfgNetworkSkipNamespaces()
+
+	PolicyNameTemplate = cfg.GetCfgNetworkPolicyNameTemplate()
+
+	BaselinePolicy = cfg.GetCfgNetworkBaselinePolicy()
+	BaselineDNSAllow = cfg.GetCfgNetworkBaselineDNSAllow()
+
+	StaleRuleWindow = libs.ParseStaleRuleWindow(cfg.GetCfgNetworkStaleRuleWindow())
+	StaleRuleAction = cfg.GetCfgNetworkStaleRuleAction()
+
+	RuleThreshold = libs.NewRuleThreshold(cfg.GetCfgNetworkRuleMinCount(),
+		cfg.GetCfgNetworkRuleMinPods(), cfg.GetCfgNetworkRuleMinSpan())
+	RuleTypeThresholds = libs.NewRuleTypeThresholds(cfg.GetCfgNetworkRuleTypeThresholds())
+	RuleStagingTTL = libs.ParseStaleRuleWindow(cfg.GetCfgNetworkRuleStagingTTL())
+
+	WorkloadStateThreshold = libs.NewWorkloadStateThreshold(cfg.GetCfgWorkloadStateWindow(),
+		cfg.GetCfgWorkloadStateStableAfter(), cfg.GetCfgWorkloadStateEnforceReadyAfter())
+	AnomalyMode = cfg.GetCfgWorkloadStateAnomalyMode()
+
+	GapAnalysis = cfg.GetCfgNetworkGapAnalysis()
 }
 
 // ============================= //
@@ -198,6 +241,7 @@ type Dst struct {
 	Protocol    int
 	DstPort     int
 	HTTP        string
+	Kafka       string
 }
 
 type MergedPortDst struct {
@@ -210,6 +254,7 @@ type MergedPortDst struct {
 	ToPorts     []types.SpecPort
 	ICMPs       []types.SpecICMP
 	ToHTTPs     []types.SpecHTTP
+	ToKafkas    []types.SpecKafka
 }
 
 type LabelCount struct {
@@ -242,11 +287,16 @@ type Selector struct {
 // =========================================== //
 
 func getDst(log types.KnoxNetworkLog, services []types.Service, cidrBits int) (Dst, bool) {
-	var httpInfo string
+	var httpInfo, kafkaInfo string
 
-	// check HTTP
+	// check HTTP, gRPC is marked not to aggregate its service/method
 	if log.HTTPMethod != "" && log.HTTPPath != "" {
-		httpInfo = log.HTTPMethod + "|" + log.HTTPPath
+		httpInfo = formatHTTPInfo(getHTTPRule(log))
+	}
+
+	// check Kafka
+	if log.L7Protocol == libs.L7ProtocolKafka {
+		kafkaInfo = getKafkaInfo(log)
 	}
 
 	// check DNS
@@ -257,6 +307,7 @@ func getDst(log types.KnoxNetworkLog, services []types.Service, cidrBits int) (D
 			Protocol:   log.Protocol,
 			DstPort:    log.DstPort,
 			HTTP:       httpInfo,
+			Kafka:      kafkaInfo,
 		}
 
 		return dst, true
@@ -310,6 +361,7 @@ func getDst(log types.KnoxNetworkLog, services []types.Service, cidrBits int) (D
 				DstPort:    log.DstPort,
 				ICMPType:   log.ICMPType,
 				HTTP:       httpInfo,
+				Kafka:      kafkaInfo,
 			}
 			return dst, true
 		}
@@ -329,6 +381,7 @@ func getDst(log types.KnoxNetworkLog, services []types.Service, cidrBits int) (D
 		DstPort:   log.DstPort,
 		ICMPType:  log.ICMPType,
 		HTTP:      httpInfo,
+		Kafka:     kafkaInfo,
 	}
 
 	return dst, true
@@ -350,12 +403,13 @@ func groupNetworkLogPerDst(networkLogs []types.KnoxNetworkLog, services []types.
 		}
 	}
 
-	// remove tcp dst which is included in http dst
+	// remove tcp dst which is included in http/kafka dst
 	for dst := range perDst {
-		if dst.Protocol == libs.IPProtocolTCP && CheckHTTPMethod(dst.HTTP) {
+		if dst.Protocol == libs.IPProtocolTCP && (CheckHTTPMethod(dst.HTTP) || dst.Kafka != "") {
 			dstCopy := dst
 
 			dstCopy.HTTP = ""
+			dstCopy.Kafka = ""
 			for tcp := range perDst {
 				if dstCopy == tcp {
 					delete(perDst, tcp)
@@ -792,26 +846,26 @@ func mergeProtocolPorts(src string, dsts []Dst) []MergedPortDst {
 	}
 
 	for _, dst := range dsts {
-		if !CheckHTTPMethod(dst.HTTP) {
+		if !CheckHTTPMethod(dst.HTTP) && dst.Kafka == "" {
 			// L4 dst
 			// Merged all the L4 dsts into one dst
 
 			l4DstExists = true
 
 			if libs.IsICMP(dst.Protocol) {
-				family := "IPv4"
-				if dst.Protocol == libs.IPProtocolICMPv6 {
-					family = "IPv6"
-				}
+				family := libs.GetICMPFamily(dst.Protocol)
 				l4MergedDst.ICMPs = []types.SpecICMP{{
 					Family: family,
 					Type:   uint8(dst.ICMPType),
 				}}
 			} else {
-				l4MergedDst.ToPorts = []types.SpecPort{{
+				toPort := types.SpecPort{
 					Protocol: libs.GetProtocol(dst.Protocol),
 					Port:     strconv.Itoa(dst.DstPort),
-				}}
+				}
+				if !libs.ContainsElement(l4MergedDst.ToPorts, toPort) {
+					l4MergedDst.ToPorts = append(l4MergedDst.ToPorts, toPort)
+				}
 			}
 
 			flowIDs := getFlowIDFromTrackMap2(src, dst)
@@ -843,18 +897,21 @@ func mergeProtocolPorts(src string, dsts []Dst) []MergedPortDst {
 				}
 			}
 
-			method, path := strings.Split(dst.HTTP, "|")[0], strings.Split(dst.HTTP, "|")[1]
-			toHTTP := types.SpecHTTP{
-				Method: method,
-				Path:   path,
+			if dst.Kafka != "" {
+				mergedDst.ToKafkas = AggregateKafkaRules(append(mergedDst.ToKafkas, parseKafkaInfo(dst.Kafka)))
+				l7MergedDsts[dst.DstPort] = mergedDst
+				continue
 			}
-			mergedDst.ToHTTPs = append(mergedDst.ToHTTPs, toHTTP)
+
+			mergedDst.ToHTTPs = append(mergedDst.ToHTTPs, parseHTTPInfo(dst.HTTP))
 			l7MergedDsts[dst.DstPort] = mergedDst
 		}
 	}
 
 	l47Dsts := []MergedPortDst{}
 	if l4DstExists {
+		// collapse the dense ports, e.g. the ephemeral ports, into the ranges
+		l4MergedDst.ToPorts = aggregateToPorts(l4MergedDst.ToPorts)
 		l47Dsts = append(l47Dsts, l4MergedDst)
 	}
 	if l7DstExists {
@@ -1180,6 +1237,14 @@ func buildNewIngressPolicyFromEgressPolicy(egressRule types.Egress, selector typ
 			copy(cpyHTTP, egressRule.ToHTTPs)
 			ingress.Spec.Ingress[0].ToHTTPs = cpyHTTP
 		}
+
+		if len(egressRule.ToKafkas) > 0 {
+			ingress.Metadata["rule"] = ingress.Metadata["rule"] + "+toKafkas"
+
+			cpyKafka := make([]types.SpecKafka, len(egressRule.ToKafkas))
+			copy(cpyKafka, egressRule.ToKafkas)
+			ingress.Spec.Ingress[0].ToKafkas = cpyKafka
+		}
 	}
 
 	if len(egressRule.ICMPs) > 0 {
@@ -1331,6 +1396,12 @@ func buildNetworkPolicy(namespace string, services []types.Service, aggregatedSr
 					egressPolicy.Metadata["rule"] = egressPolicy.Metadata["rule"] + "+toHTTPs"
 					egressRule.ToHTTPs = dst.ToHTTPs
 				}
+
+				// check toKafkas rule
+				if len(dst.ToKafkas) > 0 && discoverRuleTypes&TO_KAFKAS > 0 {
+					egressPolicy.Metadata["rule"] = egressPolicy.Metadata["rule"] + "+toKafkas"
+					egressRule.ToKafkas = dst.ToKafkas
+				}
 			}
 
 			if len(dst.ICMPs) > 0 && (discoverRuleTypes&TO_ICMPS) > 0 {
@@ -1409,9 +1480,9 @@ func buildNetworkPolicy(namespace string, services []types.Service, aggregatedSr
 					egressPolicy.Metadata["rule"] = egressPolicy.Metadata["rule"] + "+toFQDNs"
 
 					sort.Strings(dst.Additionals)
-					fqdn := types.SpecFQDN{
-						MatchNames: dst.Additionals,
-					}
+					fqdn := types.SpecFQDN{}
+					fqdn.MatchNames, fqdn.MatchPatterns = common.AggregateFQDNs(dst.Additionals,
+						cfg.GetCfgNetworkFQDNThreshold(), cfg.GetCfgNetworkFQDNProtectedSuffixes())
 
 					egressRule.ToFQDNs = []types.SpecFQDN{fqdn}
 					egressPolicy.Spec.Egress = append(egressPolicy.Spec.Egress, egressRule)
@@ -1487,7 +1558,7 @@ func buildNetworkPolicy(namespace string, services []types.Service, aggregatedSr
 func updateLabeledSrcPerDst(labeledSrcsPerDst map[Dst][]SrcSimple) map[Dst][]SrcSimple {
 	// only maintains pod-to-pod in cluster
 	for dst := range labeledSrcsPerDst {
-		if strings.HasPrefix(dst.Namespace, "reserved") || dst.HTTP != "" {
+		if strings.HasPrefix(dst.Namespace, "reserved") || dst.HTTP != "" || dst.Kafka != "" {
 			delete(labeledSrcsPerDst, dst)
 		}
 	}
@@ -1504,20 +1575,43 @@ func DiscoverNetworkPolicy(namespace string,
 	services []types.Service,
 	pods []types.Pod) []types.KnoxNetworkPolicy {
 
+	return discoverNetworkPolicies(networkLogs, func(log *types.KnoxNetworkLog) (_, _ *types.KnoxNetworkPolicy) {
+		return convertKnoxNetworkLogToKnoxNetworkPolicy(log, services, pods)
+	})
+}
+
+// discoverNetworkPolicies converts the network logs into the policies, and merges
+// the policies per kind and selector
+func discoverNetworkPolicies(networkLogs []types.KnoxNetworkLog,
+	convert func(*types.KnoxNetworkLog) (_, _ *types.KnoxNetworkPolicy)) []types.KnoxNetworkPolicy {
+
 	networkPolicies := []types.KnoxNetworkPolicy{}
 
 	ingressPolicies := map[Selector][]types.KnoxNetworkPolicy{}
 	egressPolicies := map[Selector][]types.KnoxNetworkPolicy{}
 
 	for i := range networkLogs {
-		ingress, egress := convertKnoxNetworkLogToKnoxNetworkPolicy(&networkLogs[i], pods)
+		ingress, egress := convert(&networkLogs[i])
+
+		evidence := []types.RuleEvidence{networkLogEvidence(networkLogs[i])}
 
 		if ingress != nil {
+			for j := range ingress.Spec.Ingress {
+				ingress.Spec.Ingress[j].RuleStats = libs.NewRuleStats(1)
+				ingress.Spec.Ingress[j].Pods = libs.MergeRulePods(nil, []string{networkLogs[i].DstPodName})
+				ingress.Spec.Ingress[j].Evidence = evidence
+			}
+
 			endpointSelector := getLabelArrayFromMap(ingress.Spec.Selector.MatchLabels)
 			selector := Selector{ingress.Kind, strings.Join(endpointSelector, ",")}
 			ingressPolicies[selector] = append(ingressPolicies[selector], *ingress)
 		}
 		if egress != nil {
+			for j := range egress.Spec.Egress {
+				egress.Spec.Egress[j].RuleStats = libs.NewRuleStats(1)
+				egress.Spec.Egress[j].Pods = libs.MergeRulePods(nil, []string{networkLogs[i].SrcPodName})
+				egress.Spec.Egress[j].Evidence = evidence
+			}
 			endpointSelector := getLabelArrayFromMap(egress.Spec.Selector.MatchLabels)
 			selector := Selector{egress.Kind, strings.Join(endpointSelector, ",")}
 			egressPolicies[selector] = append(egressPolicies[selector], *egress)
@@ -1544,9 +1638,83 @@ func DiscoverNetworkPolicy(namespace string,
 	for _, p := range egressPolicies {
 		networkPolicies = append(networkPolicies, p...)
 	}
+
+	for i := range networkPolicies {
+		AggregatePolicyPortRanges(&networkPolicies[i])
+		AggregatePolicyFQDNs(&networkPolicies[i])
+		updateFlowIDsFromEvidence(&networkPolicies[i])
+	}
+
 	return networkPolicies
 }
 
+// networkLogEvidence keeps the raw fields of the network log as the evidence of the rule
+func networkLogEvidence(log types.KnoxNetworkLog) types.RuleEvidence {
+	fields := map[string]string{}
+
+	for k, v := range map[string]string{
+		"src_namespace": log.SrcNamespace,
+		"src_pod_name":  log.SrcPodName,
+		"src_ip":        log.SrcIP,
+		"dst_namespace": log.DstNamespace,
+		"dst_pod_name":  log.DstPodName,
+		"dst_ip":        log.DstIP,
+		"l7_protocol":   log.L7Protocol,
+		"dns_query":     log.DNSQuery,
+		"http_method":   log.HTTPMethod,
+		"http_path":     log.HTTPPath,
+		"direction":     log.Direction,
+		"action":        log.Action,
+	} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	if log.Protocol != 0 {
+		fields["protocol"] = strconv.Itoa(log.Protocol)
+	}
+	if log.DstPort != 0 {
+		fields["dst_port"] = strconv.Itoa(log.DstPort)
+	}
+	if len(log.SrcReservedLabels) > 0 {
+		fields["src_reserved_labels"] = strings.Join(log.SrcReservedLabels, ",")
+	}
+	if len(log.DstReservedLabels) > 0 {
+		fields["dst_reserved_labels"] = strings.Join(log.DstReservedLabels, ",")
+	}
+
+	return types.RuleEvidence{
+		FlowID:    log.FlowID,
+		Timestamp: time.Now().Unix(),
+		Namespace: log.SrcNamespace,
+		PodName:   log.SrcPodName,
+		Fields:    fields,
+	}
+}
+
+// updateFlowIDsFromEvidence sets the flow ids of the policy to the ones kept as
+// the evidence of the rules, so that those are bounded as well
+func updateFlowIDsFromEvidence(policy *types.KnoxNetworkPolicy) {
+	flowIDs := []int{}
+
+	add := func(evidence []types.RuleEvidence) {
+		for _, e := range evidence {
+			if e.FlowID != 0 && !libs.ContainsElement(flowIDs, e.FlowID) {
+				flowIDs = append(flowIDs, e.FlowID)
+			}
+		}
+	}
+
+	for _, egress := range policy.Spec.Egress {
+		add(egress.Evidence)
+	}
+	for _, ingress := range policy.Spec.Ingress {
+		add(ingress.Evidence)
+	}
+
+	policy.FlowIDs = flowIDs
+}
+
 func mergeNetworkPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.KnoxNetworkPolicy) (types.KnoxNetworkPolicy, bool) {
 	if existPolicy.Metadata["type"] == PolicyTypeIngress {
 		return mergeIngressPolicies(existPolicy, policies)
@@ -1554,36 +1722,42 @@ func mergeNetworkPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.
 	return mergeEgressPolicies(existPolicy, policies)
 }
 
-func checkIfIngressEgressPortExist(polType string, newToPort types.SpecPort, mergedPolicy types.KnoxNetworkPolicy) bool {
-	isExist := false
-
+// checkIfIngressEgressPortExist returns the index of the rule having the same port (or the port range
+// covering it) and cidrs, -1 if not exist
+func checkIfIngressEgressPortExist(polType string, newToPort types.SpecPort, newCIDRs []types.SpecCIDR, mergedPolicy types.KnoxNetworkPolicy) int {
 	if polType == "EGRESS" {
-		for _, existEgress := range mergedPolicy.Spec.Egress {
+		for i, existEgress := range mergedPolicy.Spec.Egress {
 			if len(existEgress.ToPorts) == 0 {
 				continue
 			}
 			existToPort := existEgress.ToPorts[0]
 
-			if existToPort == newToPort {
-				isExist = true
-				break
+			if existToPort.Includes(newToPort) && cmp.Equal(existEgress.ToCIDRs, newCIDRs) {
+				return i
 			}
 		}
 	} else if polType == "INGRESS" {
-		for _, existIngress := range mergedPolicy.Spec.Ingress {
+		for i, existIngress := range mergedPolicy.Spec.Ingress {
 			if len(existIngress.ToPorts) == 0 {
 				continue
 			}
 			existToPort := existIngress.ToPorts[0]
 
-			if existToPort == newToPort {
-				isExist = true
-				break
+			if existToPort.Includes(newToPort) && cmp.Equal(existIngress.FromCIDRs, newCIDRs) {
+				return i
 			}
 		}
 	}
 
-	return isExist
+	return -1
+}
+
+// mergeRuleStats merges the statistics of the matched rule, and returns true
+// if the rule was pruned and is seen again, i.e. the generated policy changes
+func mergeRuleStats(existStats *types.RuleStats, newStats types.RuleStats) bool {
+	revived := existStats.Pruned && newStats.HitCount > 0
+	*existStats = libs.MergeRuleStats(*existStats, newStats)
+	return revived
 }
 
 func mergeIngressPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.KnoxNetworkPolicy) (types.KnoxNetworkPolicy, bool) {
@@ -1610,6 +1784,12 @@ func mergeIngressPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.
 					if newSelector == existSelector {
 						ingressMatched, updated, mergedPolicy.Spec.Ingress[i].ToHTTPs = mergeHttpRules(existIngress, newIngress)
 						if ingressMatched {
+							if mergeKafkaRules(&mergedPolicy.Spec.Ingress[i].ToKafkas, newIngress.ToKafkas) {
+								updated = true
+							}
+							if mergeRuleStats(&mergedPolicy.Spec.Ingress[i].RuleStats, newIngress.RuleStats) {
+								updated = true
+							}
 							break
 						}
 					}
@@ -1626,13 +1806,24 @@ func mergeIngressPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.
 					if newEntity == existEntity {
 						ingressMatched, updated, mergedPolicy.Spec.Ingress[i].ToHTTPs = mergeHttpRules(existIngress, newIngress)
 						if ingressMatched {
+							if mergeKafkaRules(&mergedPolicy.Spec.Ingress[i].ToKafkas, newIngress.ToKafkas) {
+								updated = true
+							}
+							if mergeRuleStats(&mergedPolicy.Spec.Ingress[i].RuleStats, newIngress.RuleStats) {
+								updated = true
+							}
 							break
 						}
 					}
 				}
 			} else if len(newIngress.FromCIDRs) > 0 && len(newIngress.ToPorts) > 0 {
 				newToPort := newIngress.ToPorts[0]
-				ingressMatched = checkIfIngressEgressPortExist("INGRESS", newToPort, mergedPolicy)
+				if i := checkIfIngressEgressPortExist("INGRESS", newToPort, newIngress.FromCIDRs, mergedPolicy); i >= 0 {
+					ingressMatched = true
+					if mergeRuleStats(&mergedPolicy.Spec.Ingress[i].RuleStats, newIngress.RuleStats) {
+						updated = true
+					}
+				}
 			}
 
 			if !ingressMatched {
@@ -1669,6 +1860,12 @@ func mergeEgressPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.K
 					if newSelector == existSelector {
 						egressMatched, updated, mergedPolicy.Spec.Egress[i].ToHTTPs = mergeHttpRules(existEgress, newEgress)
 						if egressMatched {
+							if mergeKafkaRules(&mergedPolicy.Spec.Egress[i].ToKafkas, newEgress.ToKafkas) {
+								updated = true
+							}
+							if mergeRuleStats(&mergedPolicy.Spec.Egress[i].RuleStats, newEgress.RuleStats) {
+								updated = true
+							}
 							break
 						}
 					}
@@ -1685,29 +1882,68 @@ func mergeEgressPolicies(existPolicy types.KnoxNetworkPolicy, policies []types.K
 					if newEntity == existEntity {
 						egressMatched, updated, mergedPolicy.Spec.Egress[i].ToHTTPs = mergeHttpRules(existEgress, newEgress)
 						if egressMatched {
+							if mergeKafkaRules(&mergedPolicy.Spec.Egress[i].ToKafkas, newEgress.ToKafkas) {
+								updated = true
+							}
+							if mergeRuleStats(&mergedPolicy.Spec.Egress[i].RuleStats, newEgress.RuleStats) {
+								updated = true
+							}
 							break
 						}
 					}
 				}
 			} else if len(newEgress.ToFQDNs) > 0 {
-				newFQDN := newEgress.ToFQDNs[0].MatchNames[0]
+				newFQDNs := fqdnRuleNames(newEgress.ToFQDNs)
 
 				for i, existEgress := range mergedPolicy.Spec.Egress {
 					if len(existEgress.ToFQDNs) == 0 {
 						continue
 					}
-					existFQDN := existEgress.ToFQDNs[0].MatchNames[0]
 
-					if newFQDN == existFQDN {
+					// the aggregated pattern covers the names of its domain
+					if fqdnsInclude(existEgress.ToFQDNs, newFQDNs) {
+						egressMatched, updated, mergedPolicy.Spec.Egress[i].ToHTTPs = mergeHttpRules(existEgress, newEgress)
+						if egressMatched {
+							if mergeKafkaRules(&mergedPolicy.Spec.Egress[i].ToKafkas, newEgress.ToKafkas) {
+								updated = true
+							}
+							if mergeRuleStats(&mergedPolicy.Spec.Egress[i].RuleStats, newEgress.RuleStats) {
+								updated = true
+							}
+							break
+						}
+					}
+				}
+			} else if len(newEgress.ToServices) > 0 {
+				newService := newEgress.ToServices[0]
+
+				for i, existEgress := range mergedPolicy.Spec.Egress {
+					if len(existEgress.ToServices) == 0 {
+						continue
+					}
+					existService := existEgress.ToServices[0]
+
+					if newService == existService {
 						egressMatched, updated, mergedPolicy.Spec.Egress[i].ToHTTPs = mergeHttpRules(existEgress, newEgress)
 						if egressMatched {
+							if mergeKafkaRules(&mergedPolicy.Spec.Egress[i].ToKafkas, newEgress.ToKafkas) {
+								updated = true
+							}
+							if mergeRuleStats(&mergedPolicy.Spec.Egress[i].RuleStats, newEgress.RuleStats) {
+								updated = true
+							}
 							break
 						}
 					}
 				}
 			} else if len(newEgress.ToCIDRs) > 0 && len(newEgress.ToPorts) > 0 {
 				newToPort := newEgress.ToPorts[0]
-				egressMatched = checkIfIngressEgressPortExist("EGRESS", newToPort, mergedPolicy)
+				if i := checkIfIngressEgressPortExist("EGRESS", newToPort, newEgress.ToCIDRs, mergedPolicy); i >= 0 {
+					egressMatched = true
+					if mergeRuleStats(&mergedPolicy.Spec.Egress[i].RuleStats, newEgress.RuleStats) {
+						updated = true
+					}
+				}
 			}
 
 			if !egressMatched {
@@ -1752,12 +1988,18 @@ func mergeHttpRules(existRule types.L47Rule, newRule types.L47Rule) (bool, bool,
 		return false, false, nil
 	}
 
+	// the L4 rule in the port range is covered by the range
+	if existPortRule[0].EndPort > 0 && len(newHttpRule) == 0 && len(newRule.GetKafkaRules()) == 0 &&
+		existPortRule[0].Includes(newPortRule[0]) {
+		return true, false, existHttpRule
+	}
+
 	// Handling Ingress/Egress with L4 and HTTP rules
 	mergedHttpRule := existHttpRule
 	updated := false
 	if existPortRule[0].Equal(newPortRule[0]) {
 		for _, h := range newHttpRule {
-			if !libs.ContainsElement(existHttpRule, h) {
+			if !httpRulesInclude(existHttpRule, h) {
 				mergedHttpRule = append(mergedHttpRule, h)
 				updated = true
 			}
@@ -1767,10 +2009,26 @@ func mergeHttpRules(existRule types.L47Rule, newRule types.L47Rule) (bool, bool,
 	return false, false, nil
 }
 
+// getPeerCIDR returns the cidr of the remote ip; the ipv4 peers share the 0.0.0.0/32
+// rule as before, the ipv6 peers are aggregated by the ipv6 prefix length
+func getPeerCIDR(log *types.KnoxNetworkLog) string {
+	peerIP := log.DstIP
+	if log.Direction == "INGRESS" {
+		peerIP = log.SrcIP
+	}
+
+	if libs.IsIPv6(peerIP) {
+		return libs.GetCIDR(peerIP, CIDRBits, CIDRBitsV6)
+	} else if log.EtherType == libs.EtherTypeIPv6 {
+		return "::/128"
+	}
+	return "0.0.0.0/32"
+}
+
 func populateIngressEgressPolicyFromKnoxNetLog(log *types.KnoxNetworkLog, pods []types.Pod) types.KnoxNetworkPolicy {
 	iePolicy := types.KnoxNetworkPolicy{}
 	var cidrs []string
-	cidrs = append(cidrs, "0.0.0.0/32")
+	cidrs = append(cidrs, getPeerCIDR(log))
 
 	specVal := types.SpecCIDR{
 		CIDRs: cidrs,
@@ -1781,11 +2039,20 @@ func populateIngressEgressPolicyFromKnoxNetLog(log *types.KnoxNetworkLog, pods [
 		Protocol: libs.GetProtocol(log.Protocol),
 	}
 
+	var icmps []types.SpecICMP
+	if libs.IsICMP(log.Protocol) {
+		icmps = []types.SpecICMP{{Family: libs.GetICMPFamily(log.Protocol), Type: uint8(log.ICMPType)}}
+	}
+
 	if log.Direction == "EGRESS" {
 		iePolicy = buildNewKnoxEgressPolicy()
 		egress := types.Egress{}
 
-		egress.ToPorts = append(egress.ToPorts, portVal)
+		if icmps != nil {
+			egress.ICMPs = icmps
+		} else {
+			egress.ToPorts = append(egress.ToPorts, portVal)
+		}
 		egress.ToCIDRs = append(egress.ToCIDRs, specVal)
 
 		iePolicy.Spec.Egress = append(iePolicy.Spec.Egress, egress)
@@ -1794,7 +2061,11 @@ func populateIngressEgressPolicyFromKnoxNetLog(log *types.KnoxNetworkLog, pods [
 		iePolicy = buildNewKnoxIngressPolicy()
 		ingress := types.Ingress{}
 
-		ingress.ToPorts = append(ingress.ToPorts, portVal)
+		if icmps != nil {
+			ingress.ICMPs = icmps
+		} else {
+			ingress.ToPorts = append(ingress.ToPorts, portVal)
+		}
 		ingress.FromCIDRs = append(ingress.FromCIDRs, specVal)
 
 		iePolicy.Spec.Ingress = append(iePolicy.Spec.Ingress, ingress)
@@ -1805,10 +2076,10 @@ func populateIngressEgressPolicyFromKnoxNetLog(log *types.KnoxNetworkLog, pods [
 	return iePolicy
 }
 
-func convertKnoxNetworkLogToKnoxNetworkPolicy(log *types.KnoxNetworkLog, pods []types.Pod) (_, _ *types.KnoxNetworkPolicy) {
+func convertKnoxNetworkLogToKnoxNetworkPolicy(log *types.KnoxNetworkLog, services []types.Service, pods []types.Pod) (_, _ *types.KnoxNetworkPolicy) {
 	var ingressPolicy, egressPolicy *types.KnoxNetworkPolicy = nil, nil
 
-	if log.SrcPodName != "" && log.DstPodName != "" {
+	if (log.SrcPodName != "" || isRemoteSrc(log)) && (log.DstPodName != "" || isRemoteDst(log)) {
 		// 1. Generate egress policy for the src
 		//        and ingress policy for the dst
 
@@ -1831,27 +2102,39 @@ func convertKnoxNetworkLogToKnoxNetworkPolicy(log *types.KnoxNetworkLog, pods []
 			ingress.MatchLabels["io.kubernetes.pod.namespace"] = log.DstNamespace
 		}
 
+		// 1.2.1 Select the local endpoint, and the peer in the remote cluster of the mesh
+		// by its labels and cluster
+		if isRemoteSrc(log) {
+			iPolicy.Spec.Selector.MatchLabels = getEndpointMatchLabels(log.DstPodName, pods)
+			ingress.MatchLabels = getRemotePeerLabels(log.SrcLabels, log.SrcNamespace, log.SrcCluster)
+		} else if isRemoteDst(log) {
+			ePolicy.Spec.Selector.MatchLabels = getEndpointMatchLabels(log.SrcPodName, pods)
+			egress.MatchLabels = getRemotePeerLabels(log.DstLabels, log.DstNamespace, log.DstCluster)
+		}
+
 		// 1.3 Set the dst port/protocol
 		if !libs.IsICMP(log.Protocol) {
 			egress.ToPorts = []types.SpecPort{{Port: strconv.Itoa(log.DstPort), Protocol: libs.GetProtocol(log.Protocol)}}
 			ingress.ToPorts = append(ingress.ToPorts, egress.ToPorts...)
 		} else {
 			// 1.4 Set the icmp code/type
-			family := "IPv4"
-			if log.Protocol == libs.IPProtocolICMPv6 {
-				family = "IPv6"
-			}
+			family := libs.GetICMPFamily(log.Protocol)
 			egress.ICMPs = []types.SpecICMP{{Family: family, Type: uint8(log.ICMPType)}}
 			ingress.ICMPs = append(ingress.ICMPs, egress.ICMPs...)
 		}
 
-		if log.L7Protocol == libs.L7ProtocolHTTP {
-			httpRule := types.SpecHTTP{Method: log.HTTPMethod, Path: log.HTTPPath}
+		if libs.IsL7HTTP(log.L7Protocol) {
+			httpRule := getHTTPRule(*log)
 			egress.ToHTTPs = []types.SpecHTTP{httpRule}
 			ingress.ToHTTPs = []types.SpecHTTP{httpRule}
+		} else if log.L7Protocol == libs.L7ProtocolKafka {
+			kafkaRule := getKafkaRule(*log)
+			egress.ToKafkas = []types.SpecKafka{kafkaRule}
+			ingress.ToKafkas = []types.SpecKafka{kafkaRule}
 		}
 
-		ePolicy.Spec.Egress = append(ePolicy.Spec.Egress, egress)
+		// 1.5 Set the dst per the service strategy of the namespace
+		ePolicy.Spec.Egress = append(ePolicy.Spec.Egress, getEgressDestinations(egress, log, services, pods)...)
 		iPolicy.Spec.Ingress = append(iPolicy.Spec.Ingress, ingress)
 
 		ePolicy.Metadata["namespace"] = log.DstNamespace
@@ -1859,7 +2142,22 @@ func convertKnoxNetworkLogToKnoxNetworkPolicy(log *types.KnoxNetworkLog, pods []
 
 		egressPolicy = &ePolicy
 		ingressPolicy = &iPolicy
-	} else if log.SrcPodName == "" && len(log.SrcReservedLabels) > 0 {
+
+		// the policy selecting the endpoint in the remote cluster is discovered there
+		if isRemoteSrc(log) {
+			iPolicy.Metadata["namespace"] = log.DstNamespace
+			egressPolicy = nil
+			if ingress.MatchLabels == nil {
+				ingressPolicy = nil
+			}
+		} else if isRemoteDst(log) {
+			ePolicy.Metadata["namespace"] = log.SrcNamespace
+			ingressPolicy = nil
+			if egress.MatchLabels == nil {
+				egressPolicy = nil
+			}
+		}
+	} else if log.SrcPodName == "" && (len(log.SrcReservedLabels) > 0 || isExternalIngress(log)) {
 		// 2. Generate ingress policy only for the dst
 
 		// Ingress Policy
@@ -1868,27 +2166,23 @@ func convertKnoxNetworkLogToKnoxNetworkPolicy(log *types.KnoxNetworkLog, pods []
 		// 2.1 Set the endpoint selector
 		iPolicy.Spec.Selector.MatchLabels = getEndpointMatchLabels(log.DstPodName, pods)
 
-		// 2.2 Set the fromEntities selector
+		// 2.2 Set the fromEntities/fromCIDRs/ingress controller selector
 		ingress := types.Ingress{}
-		srcEntity := getEntityFromReservedLabels(log.SrcReservedLabels)
-		if srcEntity != "" {
-			ingress.FromEntities = append(ingress.FromEntities, srcEntity)
-
+		if setIngressSource(&ingress, log, services, pods) {
 			// 2.3 Set the dst port/protocol
 			if !libs.IsICMP(log.Protocol) {
 				ingress.ToPorts = []types.SpecPort{{Port: strconv.Itoa(log.DstPort), Protocol: libs.GetProtocol(log.Protocol)}}
 			} else {
 				// 2.4 Set the icmp code/type
-				family := "IPv4"
-				if log.Protocol == libs.IPProtocolICMPv6 {
-					family = "IPv6"
-				}
+				family := libs.GetICMPFamily(log.Protocol)
 				ingress.ICMPs = []types.SpecICMP{{Family: family, Type: uint8(log.ICMPType)}}
 			}
 
-			if log.L7Protocol == libs.L7ProtocolHTTP {
-				httpRule := types.SpecHTTP{Method: log.HTTPMethod, Path: log.HTTPPath}
+			if libs.IsL7HTTP(log.L7Protocol) {
+				httpRule := getHTTPRule(*log)
 				ingress.ToHTTPs = []types.SpecHTTP{httpRule}
+			} else if log.L7Protocol == libs.L7ProtocolKafka {
+				ingress.ToKafkas = []types.SpecKafka{getKafkaRule(*log)}
 			}
 
 			iPolicy.Spec.Ingress = append(iPolicy.Spec.Ingress, ingress)
@@ -1922,16 +2216,15 @@ func convertKnoxNetworkLogToKnoxNetworkPolicy(log *types.KnoxNetworkLog, pods []
 				egress.ToPorts = []types.SpecPort{{Port: strconv.Itoa(log.DstPort), Protocol: libs.GetProtocol(log.Protocol)}}
 			} else {
 				// 3.4 Set the icmp code/type
-				family := "IPv4"
-				if log.Protocol == libs.IPProtocolICMPv6 {
-					family = "IPv6"
-				}
+				family := libs.GetICMPFamily(log.Protocol)
 				egress.ICMPs = []types.SpecICMP{{Family: family, Type: uint8(log.ICMPType)}}
 			}
 
-			if log.L7Protocol == libs.L7ProtocolHTTP {
-				httpRule := types.SpecHTTP{Method: log.HTTPMethod, Path: log.HTTPPath}
+			if libs.IsL7HTTP(log.L7Protocol) {
+				httpRule := getHTTPRule(*log)
 				egress.ToHTTPs = []types.SpecHTTP{httpRule}
+			} else if log.L7Protocol == libs.L7ProtocolKafka {
+				egress.ToKafkas = []types.SpecKafka{getKafkaRule(*log)}
 			}
 
 			ePolicy.Spec.Egress = append(ePolicy.Spec.Egress, egress)
@@ -2020,6 +2313,7 @@ func isValidPolicy(policy *types.KnoxNetworkPolicy) bool {
 			if len(egress.MatchLabels) == 0 &&
 				len(egress.ToEntities) == 0 &&
 				len(egress.ToFQDNs) == 0 &&
+				len(egress.ToServices) == 0 &&
 				len(egress.ToCIDRs) == 0 {
 				return false
 			}
@@ -2131,6 +2425,13 @@ func PopulateNetworkPoliciesFromNetworkLogs(networkLogs []types.KnoxNetworkLog)
 
 	discoveredNetworkPolicies := map[string][]types.KnoxNetworkPolicy{}
 
+	// remove the staged rules not seen within the ttl
+	if RuleStagingTTL > 0 {
+		if err := libs.DeleteExpiredStagedRules(CfgDB, types.PolicyTypeNetwork, RuleStagingTTL); err != nil {
+			log.Error().Msgf("could not remove the expired staged rules err=%s", err.Error())
+		}
+	}
+
 	// get cluster names, iterate each cluster
 	clusteredLogs := clusteringNetworkLogs(networkLogs)
 
@@ -2150,7 +2451,7 @@ func PopulateNetworkPoliciesFromNetworkLogs(networkLogs []types.KnoxNetworkLog)
 
 		log.Info().Msgf("updateDNSFlows for cluster [%s]", clusterName)
 		// update DNS req. flows, DNSToIPs map
-		updateDNSFlows(networkLogs)
+		updateDNSFlows(networkLogs, services)
 
 		log.Info().Msgf("updateServiceEndpoint for cluster [%s]", clusterName)
 		// update service ports (k8s service, endpoint, kube-dns)
@@ -2160,6 +2461,14 @@ func PopulateNetworkPoliciesFromNetworkLogs(networkLogs []types.KnoxNetworkLog)
 		// filter ignoring network logs from configuration
 		filteredLogs := FilterNetworkLogsByConfig(networkLogs, pods)
 
+		// the dropped flows are reported as the policy gaps rather than learned
+		var droppedLogs []types.KnoxNetworkLog
+		if GapAnalysis {
+			filteredLogs, droppedLogs = splitDroppedLogs(filteredLogs)
+		} else {
+			filteredLogs = removeDenyPolicyLogs(filteredLogs)
+		}
+
 		// iterate each namespace
 		for _, namespace := range namespaces {
 			// get network logs by target namespace
@@ -2192,32 +2501,106 @@ func PopulateNetworkPoliciesFromNetworkLogs(networkLogs []types.KnoxNetworkLog)
 		// filter discovered policies
 		discoveredNetworkPolicies = applyPolicyFilter(discoveredNetworkPolicies)
 
+		// discover the host firewall policies selecting the nodes
+		if cfg.GetCfgNetworkHostPolicyDiscovery() {
+			if cfg.GetCfgClusterInfoFrom() == "k8sclient" {
+				HostNodeLabels = cluster.GetNodeLabelsFromK8sClient()
+			}
+
+			clearTrackFlowIDMaps()
+
+			log.Info().Msgf("DiscoverHostNetworkPolicy for cluster [%s]", clusterName)
+			hostPolicies := DiscoverHostNetworkPolicy(FilterHostNetworkLogs(filteredLogs), pods)
+			discoveredNetworkPolicies[ClusterwideNamespace] = append(discoveredNetworkPolicies[ClusterwideNamespace], hostPolicies...)
+		}
+
+		// lift the egress shared across the namespaces into the clusterwide policies
+		var existingClusterwide []types.KnoxNetworkPolicy
+		if minNamespaces := cfg.GetCfgNetworkClusterwideMinNamespaces(); minNamespaces > 0 {
+			existingClusterwide = getExistingClusterwidePolicies(clusterName)
+
+			log.Info().Msgf("LiftClusterwideEgress for cluster [%s]", clusterName)
+			clusterwidePolicies := LiftClusterwideEgress(discoveredNetworkPolicies, existingClusterwide, minNamespaces, cfg.GetCfgNetworkClusterwideLabelKeys(), pods)
+			discoveredNetworkPolicies[ClusterwideNamespace] = append(discoveredNetworkPolicies[ClusterwideNamespace], clusterwidePolicies...)
+		}
+
+		workloadChanges := map[types.WorkloadKey]bool{}
+
 		// iterate each namespace
 		for _, namespace := range namespaces {
 			discoveredPolicies := discoveredNetworkPolicies[namespace]
-			if len(discoveredPolicies) == 0 {
+			// with the staleness window, the existing policies are checked even if no traffic is seen
+			if len(discoveredPolicies) == 0 && StaleRuleWindow == 0 {
 				continue
 			}
 
 			log.Info().Msgf("libs.GetNetworkPolicies for cluster [%s] namespace [%s]", clusterName, namespace)
 			// get existing network policies in db
 			existingNetPolicies := libs.GetNetworkPolicies(CfgDB, clusterName, namespace, "latest", "", "")
+			existingNetPolicies, existingBaselines := splitBaselinePolicies(existingNetPolicies)
+
+			// the rules allowed by the policies imported from the cluster are not proposed
+			importedPolicies := libs.GetNetworkPolicies(CfgDB, clusterName, namespace, types.PolicyStatusImported, "", "")
+			discoveredPolicies = removeImportedRules(importedPolicies, discoveredPolicies)
+
+			// record the new rules of the stable workloads as anomalies
+			discoveredPolicies = applyAnomalyMode(existingNetPolicies, discoveredPolicies, clusterName, namespace)
+
+			// hold the rules observed below the thresholds in the staging table
+			discoveredPolicies = applyRuleThresholds(existingNetPolicies, discoveredPolicies, clusterName, namespace)
 
 			log.Info().Msgf("UpdateDuplicatedPolicy for cluster [%s] namespace [%s]", clusterName, namespace)
 			// update duplicated policy
-			newPolicies, updatedPolicies := UpdateDuplicatedPolicy(existingNetPolicies, discoveredPolicies, DomainToIPs, clusterName)
+			newPolicies, updatedPolicies, observedPolicies := UpdateDuplicatedPolicy(existingNetPolicies, discoveredPolicies, DomainToIPs, clusterName)
 
-			if len(updatedPolicies) > 0 {
-				libs.UpdateNetworkPolicies(CfgDB, updatedPolicies)
-				writeNetworkPoliciesYamlToDB(updatedPolicies)
-			}
-			if len(newPolicies) > 0 {
-				libs.InsertNetworkPolicies(CfgDB, newPolicies)
-				writeNetworkPoliciesYamlToDB(newPolicies)
+			// generate namespace-wide default-deny and dns baseline
+			if BaselinePolicy {
+				newBaselines, updatedBaselines := GenerateBaselinePolicies(existingBaselines, namespace, clusterName)
+				newPolicies = append(newPolicies, newBaselines...)
+				updatedPolicies = append(updatedPolicies, updatedBaselines...)
 			}
+
+			storeNetworkPolicies(newPolicies, updatedPolicies, observedPolicies)
 			log.Info().Msgf("-> Network policy discovery done for namespace: [%s], [%d] policies updated, [%d] policies newly discovered", namespace, len(updatedPolicies), len(newPolicies))
+
+			addWorkloadChanges(workloadChanges, discoveredPolicies, false)
+			addWorkloadChanges(workloadChanges, observedPolicies, false)
+			addWorkloadChanges(workloadChanges, updatedPolicies, true)
+			addWorkloadChanges(workloadChanges, newPolicies, true)
 		}
 
+		// the clusterwide and the host policies are not bound to a namespace
+		if clusterwidePolicies := discoveredNetworkPolicies[ClusterwideNamespace]; len(clusterwidePolicies) > 0 {
+			if existingClusterwide == nil {
+				existingClusterwide = getExistingClusterwidePolicies(clusterName)
+			}
+
+			importedPolicies := libs.GetNetworkPolicies(CfgDB, clusterName, ClusterwideNamespace, types.PolicyStatusImported, "", "")
+			clusterwidePolicies = removeImportedRules(importedPolicies, clusterwidePolicies)
+
+			// the same gating as the namespaced policies
+			clusterwidePolicies = applyAnomalyMode(existingClusterwide, clusterwidePolicies, clusterName, ClusterwideNamespace)
+			clusterwidePolicies = applyRuleThresholds(existingClusterwide, clusterwidePolicies, clusterName, ClusterwideNamespace)
+
+			log.Info().Msgf("UpdateDuplicatedPolicy for cluster [%s] clusterwide policies", clusterName)
+			newPolicies, updatedPolicies, observedPolicies := UpdateDuplicatedPolicy(existingClusterwide, clusterwidePolicies, DomainToIPs, clusterName)
+			storeNetworkPolicies(newPolicies, updatedPolicies, observedPolicies)
+			log.Info().Msgf("-> Network policy discovery done for clusterwide policies, [%d] policies updated, [%d] policies newly discovered", len(updatedPolicies), len(newPolicies))
+
+			addWorkloadChanges(workloadChanges, clusterwidePolicies, false)
+			addWorkloadChanges(workloadChanges, observedPolicies, false)
+			addWorkloadChanges(workloadChanges, updatedPolicies, true)
+			addWorkloadChanges(workloadChanges, newPolicies, true)
+		}
+
+		if len(droppedLogs) > 0 {
+			log.Info().Msgf("discoverPolicyGaps for cluster [%s]", clusterName)
+			discoverPolicyGaps(clusterName, namespaces, droppedLogs, services, pods)
+		}
+
+		// move the workloads through the learning states
+		libs.UpdateWorkloadStates(CfgDB, types.PolicyTypeNetwork, clusterName, workloadChanges, WorkloadStateThreshold)
+
 		// update cluster global variables
 		updateMultiClusterVariables(clusterName)
 	}
@@ -2225,6 +2608,47 @@ func PopulateNetworkPoliciesFromNetworkLogs(networkLogs []types.KnoxNetworkLog)
 	return discoveredNetworkPolicies
 }
 
+// storeNetworkPolicies saves the results of UpdateDuplicatedPolicy to db
+func storeNetworkPolicies(newPolicies, updatedPolicies, observedPolicies []types.KnoxNetworkPolicy) {
+	if len(updatedPolicies) > 0 {
+		libs.UpdateNetworkPolicies(CfgDB, updatedPolicies)
+
+		// the outdated policies are not published
+		latestPolicies := []types.KnoxNetworkPolicy{}
+		for _, policy := range updatedPolicies {
+			if policy.Metadata["status"] != "outdated" {
+				latestPolicies = append(latestPolicies, policy)
+			}
+		}
+		writeNetworkPoliciesYamlToDB(latestPolicies)
+	}
+	if len(observedPolicies) > 0 {
+		// only the rule statistics are changed
+		libs.UpdateNetworkPolicies(CfgDB, observedPolicies)
+	}
+	if len(newPolicies) > 0 {
+		libs.InsertNetworkPolicies(CfgDB, newPolicies)
+		writeNetworkPoliciesYamlToDB(newPolicies)
+	}
+}
+
+// addWorkloadChanges marks the workloads selected by the policies as seen, and
+// as changed if the policies are new or updated
+func addWorkloadChanges(workloadChanges map[types.WorkloadKey]bool, policies []types.KnoxNetworkPolicy, changed bool) {
+	for _, policy := range policies {
+		if len(policy.Spec.Selector.MatchLabels) == 0 {
+			// namespace-wide baselines
+			continue
+		}
+
+		key := types.WorkloadKey{
+			Namespace: policy.Metadata["namespace"],
+			Labels:    libs.WorkloadLabels(getLabelArrayFromMap(policy.Spec.Selector.MatchLabels)),
+		}
+		workloadChanges[key] = workloadChanges[key] || changed
+	}
+}
+
 func writeNetworkPoliciesYamlToDB(policies []types.KnoxNetworkPolicy) {
 	res := []types.PolicyYaml{}
 
@@ -2277,10 +2701,8 @@ func writeNetworkPoliciesYamlToDB(policies []types.KnoxNetworkPolicy) {
 				continue
 			}
 
-			var labels types.LabelMap
-			if ciliumPolicy.Kind == cu.ResourceTypeCiliumNetworkPolicy {
-				labels = ciliumPolicy.Spec.EndpointSelector.MatchLabels
-			} else {
+			labels := ciliumPolicy.Spec.EndpointSelector.MatchLabels
+			if len(ciliumPolicy.Spec.NodeSelector.MatchLabels) > 0 {
 				labels = ciliumPolicy.Spec.NodeSelector.MatchLabels
 			}
 
@@ -2317,12 +2739,17 @@ func DiscoverNetworkPolicyMain() {
 		NetworkWorkerStatus = STATUS_IDLE
 	}()
 
+	NetworkPolicyLock.Lock()
+	defer NetworkPolicyLock.Unlock()
+
 	// init the configuration related to the network policy
 	InitNetPolicyDiscoveryConfiguration()
 
 	// get network logs
 	allNetworkLogs := getNetworkLogs()
 	if allNetworkLogs == nil || len(allNetworkLogs) < OperationTrigger {
+		// the workloads become stable even if no traffic is seen
+		libs.UpdateWorkloadStates(CfgDB, types.PolicyTypeNetwork, "", nil, WorkloadStateThreshold)
 		return
 	}
 
@@ -2381,6 +2808,16 @@ func StartNetworkWorker() {
 		return
 	}
 
+	if cfg.GetCfgNetworkMigratePolicyNames() {
+		InitNetPolicyDiscoveryConfiguration()
+		MigratePolicyNames()
+	}
+
+	if cfg.GetCfgNetworkImportPoliciesFrom() != "" {
+		InitNetPolicyDiscoveryConfiguration()
+		ImportNetworkPolicies()
+	}
+
 	if cfg.GetCfgNetOperationMode() == OP_MODE_NOOP { // Do not run the operation
 		log.Info().Msg("network operation mode is NOOP ... NO NETWORK POLICY DISCOVERY")
 	} else if cfg.GetCfgNetOperationMode() == OP_MODE_CRONJOB { // every time intervals
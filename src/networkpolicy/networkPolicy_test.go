@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/accuknox/auto-policy-discovery/src/libs"
 	types "github.com/accuknox/auto-policy-discovery/src/types"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -159,3 +160,138 @@ func TestGenerateNetworkPolicies(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateFlowIDsFromEvidence(t *testing.T) {
+	policy := types.KnoxNetworkPolicy{
+		FlowIDs: []int{100},
+		Spec: types.Spec{
+			Egress: []types.Egress{
+				{RuleStats: types.RuleStats{Evidence: []types.RuleEvidence{{FlowID: 1}, {FlowID: 2}}}},
+			},
+			Ingress: []types.Ingress{
+				{RuleStats: types.RuleStats{Evidence: []types.RuleEvidence{{FlowID: 2}, {FlowID: 3}}}},
+			},
+		},
+	}
+
+	updateFlowIDsFromEvidence(&policy)
+
+	assert.Equal(t, []int{1, 2, 3}, policy.FlowIDs)
+}
+
+func TestIsKnownRule(t *testing.T) {
+	exist := types.KnoxNetworkPolicy{
+		Kind:     "KnoxNetworkPolicy",
+		Metadata: map[string]string{"type": PolicyTypeEgress},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "test"}},
+			Egress: []types.Egress{
+				{
+					ToEntities: []string{"world"},
+					ToPorts:    []types.SpecPort{{Port: "443", Protocol: "TCP"}},
+					RuleStats:  types.RuleStats{HitCount: 1},
+				},
+			},
+		},
+	}
+
+	policy := exist
+	policy.Spec.Egress = []types.Egress{
+		{
+			ToEntities: []string{"world"},
+			ToPorts:    []types.SpecPort{{Port: "443", Protocol: "TCP"}},
+			RuleStats:  types.RuleStats{HitCount: 2},
+		},
+		{
+			ToEntities: []string{"host"},
+			ToPorts:    []types.SpecPort{{Port: "443", Protocol: "TCP"}},
+		},
+	}
+
+	singles := splitPolicyRules(policy)
+	assert.Len(t, singles, 2)
+
+	assert.True(t, isKnownRule([]types.KnoxNetworkPolicy{exist}, singles[0]))
+	assert.False(t, isKnownRule([]types.KnoxNetworkPolicy{exist}, singles[1]))
+	// the existing policy is not updated by the check
+	assert.Equal(t, int64(1), exist.Spec.Egress[0].HitCount)
+
+	// the key does not depend on the statistics
+	key1, _ := networkRuleKey(singles[0])
+	singles[0].Spec.Egress[0].HitCount = 10
+	key2, _ := networkRuleKey(singles[0])
+	assert.Equal(t, key1, key2)
+}
+
+func TestNetworkRuleType(t *testing.T) {
+	policy := types.KnoxNetworkPolicy{
+		Spec: types.Spec{
+			Egress: []types.Egress{
+				{MatchLabels: map[string]string{"app": "db"}},
+				{ToFQDNs: []types.SpecFQDN{{MatchNames: []string{"example.com"}}}},
+			},
+			Ingress: []types.Ingress{
+				{FromEntities: []string{"world"}},
+			},
+		},
+	}
+
+	ruleTypes := []string{}
+	for _, single := range splitPolicyRules(policy) {
+		ruleTypes = append(ruleTypes, networkRuleType(single))
+	}
+	assert.Equal(t, []string{"matchLabels", "toFQDNs", "fromEntities"}, ruleTypes)
+}
+
+func TestSplitDroppedLogs(t *testing.T) {
+	logs := []types.KnoxNetworkLog{
+		{FlowID: 1, Action: "allow"},
+		{FlowID: 2, Action: "deny", DropReason: types.DropReasonPolicyDenied},
+		{FlowID: 3, Action: "Deny"}, // kubearmor network logs
+		{FlowID: 4, Action: "deny", DropReason: types.DropReasonPolicyDeny},
+		{FlowID: 5, Action: "deny", DropReason: 132}, // invalid source ip
+	}
+
+	allowed, dropped := splitDroppedLogs(logs)
+	assert.Equal(t, []types.KnoxNetworkLog{logs[0], logs[4]}, allowed)
+	assert.Equal(t, []types.KnoxNetworkLog{logs[1], logs[2], logs[3]}, dropped)
+
+	assert.Len(t, removeDenyPolicyLogs(logs), 4)
+}
+
+func TestPopulateIPv6CIDRPolicy(t *testing.T) {
+	CIDRBits, CIDRBitsV6 = 32, 64
+
+	log := types.KnoxNetworkLog{
+		SrcPodName: "web",
+		Direction:  "EGRESS",
+		EtherType:  libs.EtherTypeIPv6,
+		Protocol:   libs.IPProtocolICMPv6,
+		SrcIP:      "fd00::10",
+		DstIP:      "2001:db8:1:2::53",
+		ICMPType:   128,
+	}
+
+	policy := populateIngressEgressPolicyFromKnoxNetLog(&log, nil)
+	assert.Equal(t, []types.SpecCIDR{{CIDRs: []string{"2001:db8:1:2::/64"}}}, policy.Spec.Egress[0].ToCIDRs)
+	assert.Equal(t, []types.SpecICMP{{Family: "IPv6", Type: 128}}, policy.Spec.Egress[0].ICMPs)
+	assert.Empty(t, policy.Spec.Egress[0].ToPorts)
+
+	log = types.KnoxNetworkLog{
+		SrcPodName: "web",
+		Direction:  "EGRESS",
+		Protocol:   libs.IPProtocolTCP,
+		DstIP:      "203.0.113.7",
+		DstPort:    443,
+	}
+
+	policy = populateIngressEgressPolicyFromKnoxNetLog(&log, nil)
+	assert.Equal(t, []types.SpecCIDR{{CIDRs: []string{"0.0.0.0/32"}}}, policy.Spec.Egress[0].ToCIDRs)
+
+	// the ipv4 peers on the same port are merged into one rule
+	existPolicy := policy
+	log.DstIP = "198.51.100.9"
+	policy = populateIngressEgressPolicyFromKnoxNetLog(&log, nil)
+	merged, _ := mergeEgressPolicies(existPolicy, []types.KnoxNetworkPolicy{policy})
+	assert.Len(t, merged.Spec.Egress, 1)
+}
@@ -0,0 +1,108 @@
+package networkpolicy
+
+import (
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ================= //
+// == Policy Gaps == //
+// ================= //
+
+// isDroppedLog returns true if the flow is dropped by the existing policy. The
+// cilium flows dropped for other reasons (e.g. invalid packets) are not. The
+// sources without the drop reason (e.g. KubeArmor) deny by the policy only.
+func isDroppedLog(log types.KnoxNetworkLog) bool {
+	if !strings.EqualFold(log.Action, "deny") {
+		return false
+	}
+	return log.DropReason == 0 || isPolicyDropReason(log.DropReason)
+}
+
+// isPolicyDropReason returns true if the drop reason is the policy verdict
+func isPolicyDropReason(reason int) bool {
+	return reason == types.DropReasonPolicyDenied || reason == types.DropReasonPolicyDeny
+}
+
+// removeDenyPolicyLogs removes the flows denied by the deny policies, those are
+// not learned as the allowed traffic
+func removeDenyPolicyLogs(logs []types.KnoxNetworkLog) []types.KnoxNetworkLog {
+	filtered := []types.KnoxNetworkLog{}
+	for _, log := range logs {
+		if log.DropReason != types.DropReasonPolicyDeny {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered
+}
+
+// splitDroppedLogs separates the flows dropped by the existing policies from
+// the allowed ones
+func splitDroppedLogs(logs []types.KnoxNetworkLog) ([]types.KnoxNetworkLog, []types.KnoxNetworkLog) {
+	allowedLogs := []types.KnoxNetworkLog{}
+	droppedLogs := []types.KnoxNetworkLog{}
+
+	for _, log := range logs {
+		if isDroppedLog(log) {
+			droppedLogs = append(droppedLogs, log)
+		} else {
+			allowedLogs = append(allowedLogs, log)
+		}
+	}
+
+	return allowedLogs, droppedLogs
+}
+
+// discoverPolicyGaps computes the minimal rules which would have allowed the
+// dropped flows, and records them per workload for the review. The rules
+// already allowed by the existing policies are not recorded.
+func discoverPolicyGaps(clusterName string, namespaces []string, droppedLogs []types.KnoxNetworkLog,
+	services []types.Service, pods []types.Pod) {
+	gapPolicies := map[string][]types.KnoxNetworkPolicy{}
+
+	for _, namespace := range namespaces {
+		logsPerNamespace := FilterNetworkLogsByNamespace(namespace, droppedLogs)
+		if len(logsPerNamespace) == 0 {
+			continue
+		}
+
+		clearTrackFlowIDMaps()
+
+		// the egress and the ingress rules could be in different namespaces
+		for _, policy := range DiscoverNetworkPolicy(namespace, logsPerNamespace, services, pods) {
+			ns := policy.Metadata["namespace"]
+			gapPolicies[ns] = append(gapPolicies[ns], policy)
+		}
+	}
+
+	for namespace, policies := range gapPolicies {
+		existingPolicies := libs.GetNetworkPolicies(CfgDB, clusterName, namespace, "latest", "", "")
+		existingPolicies, _ = splitBaselinePolicies(existingPolicies)
+
+		for _, policy := range policies {
+			if len(policy.Spec.Selector.MatchLabels) == 0 {
+				continue
+			}
+			labels := libs.WorkloadLabels(getLabelArrayFromMap(policy.Spec.Selector.MatchLabels))
+
+			for _, single := range splitPolicyRules(policy) {
+				if isKnownRule(existingPolicies, single) {
+					continue
+				}
+
+				key, candidate := networkRuleKey(single)
+				libs.RecordPolicyGap(CfgDB, types.PolicyGap{
+					ClusterName: clusterName,
+					Namespace:   namespace,
+					Labels:      labels,
+					PolicyType:  types.PolicyTypeNetwork,
+					RuleKey:     key,
+					Candidate:   candidate,
+					RuleStats:   *singleRuleStats(&single),
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package networkpolicy
+
+import (
+	"sort"
+	"strconv"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+)
+
+// ============================ //
+// == Port range aggregation == //
+// ============================ //
+
+// portSpan is a port or a port range, count is the number of the observed ports in it
+type portSpan struct {
+	start int
+	end   int
+	count int
+}
+
+// portCluster is the ports collapsed into a range
+type portCluster struct {
+	portSpan
+	spans []portSpan
+}
+
+func (c *portCluster) density() float64 {
+	return float64(c.count) / float64(c.end-c.start+1)
+}
+
+// toPorts returns the range of the cluster if it has enough ports; otherwise,
+// the ports as they are
+func (c *portCluster) toPorts(protocol string, minPorts int) []types.SpecPort {
+	if len(c.spans) == 1 || c.count >= minPorts {
+		port := types.SpecPort{Port: strconv.Itoa(c.start), Protocol: protocol}
+		if c.end > c.start {
+			port.EndPort = c.end
+		}
+		return []types.SpecPort{port}
+	}
+
+	ports := []types.SpecPort{}
+	for _, span := range c.spans {
+		ports = append(ports, (&portCluster{portSpan: span, spans: []portSpan{span}}).toPorts(protocol, minPorts)...)
+	}
+	return ports
+}
+
+// AggregatePortRanges collapses the dense clusters of the ports per protocol into
+// the port ranges. The ports are added to a cluster while #ports / range width
+// is at least the density, and a cluster of at least minPorts ports becomes a
+// range. The well-known ports (<= wellKnownMax) are always kept explicit.
+func AggregatePortRanges(ports []types.SpecPort, density float64, minPorts, wellKnownMax int) []types.SpecPort {
+	if density <= 0 || len(ports) < 2 {
+		return ports
+	}
+
+	results := []types.SpecPort{}
+
+	protocols := []string{}
+	spansPerProtocol := map[string][]portSpan{}
+
+	for _, port := range ports {
+		start, err := strconv.Atoi(port.Port)
+		if err != nil || (port.EndPort == 0 && start <= wellKnownMax) {
+			if !libs.ContainsElement(results, port) {
+				results = append(results, port)
+			}
+			continue
+		}
+
+		end := port.EndPort
+		if end < start {
+			end = start
+		}
+
+		if _, ok := spansPerProtocol[port.Protocol]; !ok {
+			protocols = append(protocols, port.Protocol)
+		}
+		spansPerProtocol[port.Protocol] = append(spansPerProtocol[port.Protocol], portSpan{start, end, end - start + 1})
+	}
+
+	for _, protocol := range protocols {
+		spans := spansPerProtocol[protocol]
+		sort.Slice(spans, func(i, j int) bool {
+			if spans[i].start != spans[j].start {
+				return spans[i].start < spans[j].start
+			}
+			return spans[i].end > spans[j].end
+		})
+
+		var cluster *portCluster
+		for _, span := range spans {
+			if cluster != nil && span.end <= cluster.end {
+				// already covered by the cluster
+				continue
+			}
+
+			if cluster != nil {
+				extended := *cluster
+				if span.start <= cluster.end {
+					extended.count += span.end - cluster.end
+				} else {
+					extended.count += span.count
+				}
+				extended.end = span.end
+
+				if extended.density() >= density {
+					extended.spans = append(append([]portSpan{}, cluster.spans...), span)
+					cluster = &extended
+					continue
+				}
+
+				results = append(results, cluster.toPorts(protocol, minPorts)...)
+			}
+
+			cluster = &portCluster{portSpan: span, spans: []portSpan{span}}
+		}
+
+		if cluster != nil {
+			results = append(results, cluster.toPorts(protocol, minPorts)...)
+		}
+	}
+
+	return results
+}
+
+// aggregateToPorts collapses the ports with the configured density
+func aggregateToPorts(ports []types.SpecPort) []types.SpecPort {
+	return AggregatePortRanges(ports, cfg.GetCfgNetworkPortRangeDensity(),
+		cfg.GetCfgNetworkPortRangeMinPorts(), cfg.GetCfgNetworkPortRangeWellKnownMax())
+}
+
+// portRangeRule is a port range and the indexes of the rules folded into it
+type portRangeRule struct {
+	port    types.SpecPort
+	members []int
+}
+
+// aggregatePortRules groups the rules by the peer key, and collapses the ports of
+// each group; the rules with the empty key are not aggregated. The groups are
+// returned at the index of their first rule.
+func aggregatePortRules(keys []string, ports []types.SpecPort) map[int][]portRangeRule {
+	groups := map[string][]int{}
+	for i, key := range keys {
+		if key != "" {
+			groups[key] = append(groups[key], i)
+		}
+	}
+
+	results := map[int][]portRangeRule{}
+	for _, members := range groups {
+		groupPorts := []types.SpecPort{}
+		for _, i := range members {
+			groupPorts = append(groupPorts, ports[i])
+		}
+
+		for _, port := range aggregateToPorts(groupPorts) {
+			rule := portRangeRule{port: port}
+			for _, i := range members {
+				if port.Includes(ports[i]) {
+					rule.members = append(rule.members, i)
+				}
+			}
+			results[members[0]] = append(results[members[0]], rule)
+		}
+	}
+
+	return results
+}
+
+// portRuleKey returns the key of the L4 rule having a single port, i.e. the rule
+// without the ports and the statistics; empty if the rule is not a candidate
+func portRuleKey(rule interface{}, toPorts []types.SpecPort, l7Rules int, icmps []types.SpecICMP) string {
+	if len(toPorts) != 1 || l7Rules > 0 || len(icmps) > 0 {
+		return ""
+	}
+
+	b, err := json.Marshal(rule)
+	if err != nil {
+		return ""
+	}
+
+	return toPorts[0].Protocol + "|" + string(b)
+}
+
+func aggregateEgressPortRules(egresses []types.Egress) []types.Egress {
+	if len(egresses) < 2 {
+		return egresses
+	}
+
+	keys := make([]string, len(egresses))
+	ports := make([]types.SpecPort, len(egresses))
+
+	for i, egress := range egresses {
+		peer := egress
+		peer.ToPorts, peer.RuleStats = nil, types.RuleStats{}
+		keys[i] = portRuleKey(peer, egress.ToPorts, len(egress.ToHTTPs)+len(egress.ToKafkas), egress.ICMPs)
+		if keys[i] != "" {
+			ports[i] = egress.ToPorts[0]
+		}
+	}
+
+	groups := aggregatePortRules(keys, ports)
+
+	results := []types.Egress{}
+	for i, egress := range egresses {
+		if keys[i] == "" {
+			results = append(results, egress)
+			continue
+		}
+
+		for _, group := range groups[i] {
+			merged := egresses[group.members[0]]
+			merged.ToPorts = []types.SpecPort{group.port}
+			for _, j := range group.members[1:] {
+				merged.RuleStats = libs.CombineRuleStats(merged.RuleStats, egresses[j].RuleStats)
+			}
+			results = append(results, merged)
+		}
+	}
+
+	return results
+}
+
+func aggregateIngressPortRules(ingresses []types.Ingress) []types.Ingress {
+	if len(ingresses) < 2 {
+		return ingresses
+	}
+
+	keys := make([]string, len(ingresses))
+	ports := make([]types.SpecPort, len(ingresses))
+
+	for i, ingress := range ingresses {
+		peer := ingress
+		peer.ToPorts, peer.RuleStats = nil, types.RuleStats{}
+		keys[i] = portRuleKey(peer, ingress.ToPorts, len(ingress.ToHTTPs)+len(ingress.ToKafkas), ingress.ICMPs)
+		if keys[i] != "" {
+			ports[i] = ingress.ToPorts[0]
+		}
+	}
+
+	groups := aggregatePortRules(keys, ports)
+
+	results := []types.Ingress{}
+	for i, ingress := range ingresses {
+		if keys[i] == "" {
+			results = append(results, ingress)
+			continue
+		}
+
+		for _, group := range groups[i] {
+			merged := ingresses[group.members[0]]
+			merged.ToPorts = []types.SpecPort{group.port}
+			for _, j := range group.members[1:] {
+				merged.RuleStats = libs.CombineRuleStats(merged.RuleStats, ingresses[j].RuleStats)
+			}
+			results = append(results, merged)
+		}
+	}
+
+	return results
+}
+
+// AggregatePolicyPortRanges collapses the L4 rules to the same peer into the port
+// ranges, and returns true if the rules are changed
+func AggregatePolicyPortRanges(policy *types.KnoxNetworkPolicy) bool {
+	if cfg.GetCfgNetworkPortRangeDensity() <= 0 {
+		return false
+	}
+
+	egressCount, ingressCount := len(policy.Spec.Egress), len(policy.Spec.Ingress)
+
+	policy.Spec.Egress = aggregateEgressPortRules(policy.Spec.Egress)
+	policy.Spec.Ingress = aggregateIngressPortRules(policy.Spec.Ingress)
+
+	return egressCount != len(policy.Spec.Egress) || ingressCount != len(policy.Spec.Ingress)
+}
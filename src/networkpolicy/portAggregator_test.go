@@ -0,0 +1,66 @@
+package networkpolicy
+
+import (
+	"strconv"
+	"testing"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func tcpPorts(ports ...int) []types.SpecPort {
+	results := []types.SpecPort{}
+	for _, port := range ports {
+		results = append(results, types.SpecPort{Port: strconv.Itoa(port), Protocol: "TCP"})
+	}
+	return results
+}
+
+func TestAggregatePortRanges(t *testing.T) {
+	// passive ftp ports are collapsed, the well-known port 21 is kept explicit
+	ports := tcpPorts(21, 30001, 30002, 30004, 30005, 30007, 45000)
+	actual := AggregatePortRanges(ports, 0.5, 3, 1023)
+	assert.Equal(t, []types.SpecPort{
+		{Port: "21", Protocol: "TCP"},
+		{Port: "30001", Protocol: "TCP", EndPort: 30007},
+		{Port: "45000", Protocol: "TCP"},
+	}, actual)
+
+	// the sparse ports are kept
+	ports = tcpPorts(8080, 9090, 10000)
+	assert.Equal(t, ports, AggregatePortRanges(ports, 0.5, 3, 1023))
+
+	// the new port is merged into the existing range
+	ports = append([]types.SpecPort{{Port: "30000", Protocol: "TCP", EndPort: 30010}}, tcpPorts(30005, 30012)...)
+	assert.Equal(t, []types.SpecPort{{Port: "30000", Protocol: "TCP", EndPort: 30012}}, AggregatePortRanges(ports, 0.5, 3, 1023))
+
+	// disabled
+	ports = tcpPorts(30001, 30002, 30003)
+	assert.Equal(t, ports, AggregatePortRanges(ports, 0, 3, 1023))
+}
+
+func TestAggregatePolicyPortRanges(t *testing.T) {
+	cfg.CurrentCfg.ConfigNetPolicy.PortRangeDensity = 0.5
+	cfg.CurrentCfg.ConfigNetPolicy.PortRangeMinPorts = 3
+	cfg.CurrentCfg.ConfigNetPolicy.PortRangeWellKnownMax = 1023
+	defer func() { cfg.CurrentCfg.ConfigNetPolicy.PortRangeDensity = 0 }()
+
+	media := map[string]string{"app": "media"}
+	policy := types.KnoxNetworkPolicy{}
+	policy.Spec.Egress = []types.Egress{
+		{MatchLabels: media, ToPorts: tcpPorts(40000), RuleStats: types.RuleStats{HitCount: 1}},
+		{MatchLabels: media, ToPorts: tcpPorts(40001), RuleStats: types.RuleStats{HitCount: 2}},
+		{MatchLabels: media, ToPorts: tcpPorts(40003), RuleStats: types.RuleStats{HitCount: 3}},
+		{MatchLabels: media, ToPorts: tcpPorts(8080), ToHTTPs: []types.SpecHTTP{{Method: "GET", Path: "/"}}},
+	}
+
+	assert.True(t, AggregatePolicyPortRanges(&policy))
+	assert.Equal(t, 2, len(policy.Spec.Egress))
+	assert.Equal(t, []types.SpecPort{{Port: "40000", Protocol: "TCP", EndPort: 40003}}, policy.Spec.Egress[0].ToPorts)
+	assert.Equal(t, int64(6), policy.Spec.Egress[0].HitCount)
+
+	// the port in the range is covered by the range rule
+	matched, _, _ := mergeHttpRules(policy.Spec.Egress[0], types.Egress{MatchLabels: media, ToPorts: tcpPorts(40002)})
+	assert.True(t, matched)
+}
@@ -0,0 +1,191 @@
+package networkpolicy
+
+import (
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+)
+
+// ===================== //
+// == Rule Thresholds == //
+// ===================== //
+
+// splitPolicyRules returns the copies of the policy having a single rule each
+func splitPolicyRules(policy types.KnoxNetworkPolicy) []types.KnoxNetworkPolicy {
+	singles := []types.KnoxNetworkPolicy{}
+
+	for _, egress := range policy.Spec.Egress {
+		single := policy
+		single.Spec.Egress = []types.Egress{egress}
+		single.Spec.Ingress = nil
+		singles = append(singles, single)
+	}
+	for _, ingress := range policy.Spec.Ingress {
+		single := policy
+		single.Spec.Egress = nil
+		single.Spec.Ingress = []types.Ingress{ingress}
+		singles = append(singles, single)
+	}
+
+	return singles
+}
+
+// singleRuleStats returns the statistics of the single rule of the policy
+func singleRuleStats(single *types.KnoxNetworkPolicy) *types.RuleStats {
+	if len(single.Spec.Egress) > 0 {
+		return &single.Spec.Egress[0].RuleStats
+	}
+	return &single.Spec.Ingress[0].RuleStats
+}
+
+// networkRuleType returns the type of the single rule by its peer, e.g. toFQDNs
+func networkRuleType(single types.KnoxNetworkPolicy) string {
+	if len(single.Spec.Egress) > 0 {
+		egress := single.Spec.Egress[0]
+		switch {
+		case len(egress.ToCIDRs) > 0:
+			return "toCIDRs"
+		case len(egress.ToFQDNs) > 0:
+			return "toFQDNs"
+		case len(egress.ToEntities) > 0:
+			return "toEntities"
+		case len(egress.ToServices) > 0:
+			return "toServices"
+		}
+		return "matchLabels"
+	}
+
+	ingress := single.Spec.Ingress[0]
+	switch {
+	case len(ingress.FromCIDRs) > 0:
+		return "fromCIDRs"
+	case len(ingress.FromEntities) > 0:
+		return "fromEntities"
+	}
+	return "matchLabels"
+}
+
+// networkRuleKey identifies the single rule policy by its selector and rule,
+// regardless of the rule statistics
+func networkRuleKey(single types.KnoxNetworkPolicy) (string, string) {
+	candidate := types.KnoxNetworkPolicy{
+		APIVersion: single.APIVersion,
+		Kind:       single.Kind,
+		Metadata: map[string]string{
+			"namespace": single.Metadata["namespace"],
+			"type":      single.Metadata["type"],
+			"rule":      single.Metadata["rule"],
+		},
+		Spec: types.Spec{Selector: single.Spec.Selector},
+	}
+	for _, egress := range single.Spec.Egress {
+		egress.RuleStats = types.RuleStats{}
+		candidate.Spec.Egress = append(candidate.Spec.Egress, egress)
+	}
+	for _, ingress := range single.Spec.Ingress {
+		ingress.RuleStats = types.RuleStats{}
+		candidate.Spec.Ingress = append(candidate.Spec.Ingress, ingress)
+	}
+
+	candidateBytes, err := json.Marshal(candidate)
+	if err != nil {
+		log.Error().Msg(err.Error())
+	}
+
+	return libs.RuleKey(string(candidateBytes)), string(candidateBytes)
+}
+
+// isKnownRule returns true if the rule is already covered by the existing
+// policy of the same selector, i.e. merging it does not change the policy
+func isKnownRule(existingPolicies []types.KnoxNetworkPolicy, single types.KnoxNetworkPolicy) bool {
+	newSelector := strings.Join(getLabelArrayFromMap(single.Spec.Selector.MatchLabels), ",")
+
+	for _, exist := range existingPolicies {
+		if exist.Kind != single.Kind || exist.Metadata["type"] != single.Metadata["type"] {
+			continue
+		}
+		if strings.Join(getLabelArrayFromMap(exist.Spec.Selector.MatchLabels), ",") != newSelector {
+			continue
+		}
+
+		// the merge updates the rules in place
+		existCopy := types.KnoxNetworkPolicy{}
+		libs.DeepCopy(&existCopy, &exist)
+
+		merged, updated := mergeNetworkPolicies(existCopy, []types.KnoxNetworkPolicy{single})
+		return !updated &&
+			len(merged.Spec.Egress) == len(exist.Spec.Egress) &&
+			len(merged.Spec.Ingress) == len(exist.Spec.Ingress)
+	}
+
+	return false
+}
+
+// applyRuleThresholds holds the new rules observed below the thresholds of their
+// rule type in the staging table, and proposes them once the accumulated
+// observations meet the thresholds. The rules already in the existing policies
+// are not held.
+func applyRuleThresholds(existingPolicies, discoveredPolicies []types.KnoxNetworkPolicy, clusterName, namespace string) []types.KnoxNetworkPolicy {
+	if !libs.IsAnyRuleThresholdEnabled(RuleThreshold, RuleTypeThresholds) || len(discoveredPolicies) == 0 {
+		return discoveredPolicies
+	}
+
+	stagedRules, err := libs.GetStagedRules(CfgDB, types.PolicyTypeNetwork, clusterName, namespace)
+	if err != nil {
+		log.Error().Msgf("could not fetch staged rules for cluster [%s] namespace [%s] err=%s", clusterName, namespace, err.Error())
+		return discoveredPolicies
+	}
+
+	proposedPolicies := []types.KnoxNetworkPolicy{}
+
+	for _, policy := range discoveredPolicies {
+		proposed := policy
+		proposed.Spec.Egress = nil
+		proposed.Spec.Ingress = nil
+
+		for _, single := range splitPolicyRules(policy) {
+			if !isKnownRule(existingPolicies, single) {
+				key, candidate := networkRuleKey(single)
+				stats := singleRuleStats(&single)
+
+				staged, ok := stagedRules[key]
+				*stats = libs.MergeRuleStats(staged.RuleStats, *stats)
+
+				threshold := libs.GetRuleThreshold(RuleThreshold, RuleTypeThresholds, networkRuleType(single))
+				if !libs.MeetsRuleThreshold(*stats, threshold) {
+					if err := libs.UpdateStagedRule(CfgDB, types.StagedRule{
+						ClusterName: clusterName,
+						Namespace:   namespace,
+						PolicyType:  types.PolicyTypeNetwork,
+						RuleKey:     key,
+						Candidate:   candidate,
+						RuleStats:   *stats,
+					}); err != nil {
+						log.Error().Msgf("could not stage the rule err=%s", err.Error())
+					}
+					continue
+				}
+
+				if ok {
+					if err := libs.DeleteStagedRule(CfgDB, types.PolicyTypeNetwork, key); err != nil {
+						log.Error().Msgf("could not remove the staged rule err=%s", err.Error())
+					}
+				}
+			}
+
+			proposed.Spec.Egress = append(proposed.Spec.Egress, single.Spec.Egress...)
+			proposed.Spec.Ingress = append(proposed.Spec.Ingress, single.Spec.Ingress...)
+		}
+
+		if len(proposed.Spec.Egress) == 0 && len(proposed.Spec.Ingress) == 0 {
+			continue
+		}
+
+		updateFlowIDsFromEvidence(&proposed)
+		proposedPolicies = append(proposedPolicies, proposed)
+	}
+
+	return proposedPolicies
+}
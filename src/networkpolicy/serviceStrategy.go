@@ -0,0 +1,133 @@
+package networkpolicy
+
+import (
+	"strings"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ============================= //
+// == Service-level dst rules == //
+// ============================= //
+
+// getServiceDomain returns the domain name of the service in the cluster
+func getServiceDomain(svc types.Service) string {
+	return svc.ServiceName + "." + svc.Namespace + "." + libs.ClusterDomain
+}
+
+// getExternalNameFromDomain returns the external name of the ExternalName service
+// of the domain name, i.e. <service>.<namespace>.svc.cluster.local
+func getExternalNameFromDomain(domainName string, services []types.Service) string {
+	domainName = strings.TrimSuffix(domainName, ".")
+
+	for _, svc := range services {
+		if svc.ExternalName != "" && getServiceDomain(svc) == domainName {
+			return strings.TrimSuffix(svc.ExternalName, ".")
+		}
+	}
+
+	return ""
+}
+
+// selectsPod checks if the service selector selects the pod labels
+func selectsPod(selector map[string]string, podLabels []string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+
+	for k, v := range selector {
+		if !libs.ContainsElement(podLabels, k+"="+v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isClusterIPService checks if the service has the virtual ip, i.e. not headless
+func isClusterIPService(svc types.Service) bool {
+	return svc.ClusterIP != "" && svc.ClusterIP != "None"
+}
+
+// getClusterIPService returns the service through which the dst is reached; the
+// service reported in the log first, otherwise the service of the dst ip
+func getClusterIPService(log *types.KnoxNetworkLog, services []types.Service) (types.Service, bool) {
+	if log.DstServiceName != "" {
+		for _, svc := range services {
+			if svc.Namespace == log.DstServiceNamespace && svc.ServiceName == log.DstServiceName && isClusterIPService(svc) {
+				return svc, true
+			}
+		}
+	}
+
+	if svc, ok := checkK8sService(*log, services); ok && isClusterIPService(svc) {
+		return svc, true
+	}
+
+	return types.Service{}, false
+}
+
+// getHeadlessService returns the headless service selecting the dst pod on the dst port
+func getHeadlessService(log *types.KnoxNetworkLog, services []types.Service, pods []types.Pod) (types.Service, bool) {
+	podLabels := getLabelsFromPod(log.DstPodName, pods)
+
+	for _, svc := range services {
+		if svc.Namespace != log.DstNamespace || svc.ClusterIP != "None" {
+			continue
+		}
+
+		if svc.TargetPort != log.DstPort && svc.ServicePort != log.DstPort {
+			continue
+		}
+
+		if selectsPod(svc.Selector, podLabels) {
+			return svc, true
+		}
+	}
+
+	return types.Service{}, false
+}
+
+// getEgressDestinations returns the egress rules to the dst pod per the service
+// strategy of the src namespace.
+//   - endpoint: the labels of the dst pod
+//   - service: the service if reached via the ClusterIP, the labels plus the FQDN if
+//     the headless service selects the pod, otherwise the labels of the dst pod
+//   - both: the labels of the dst pod plus the service or the FQDN
+//
+// The headless service is always treated as both since the pod ips are reached
+// without the DNS lookup too; only the ExternalName service maps to the FQDN
+// alone, in the DNS flows (see updateDNSFlows).
+func getEgressDestinations(egress types.Egress, log *types.KnoxNetworkLog, services []types.Service, pods []types.Pod) []types.Egress {
+	strategy := cfg.GetCfgNetworkServiceStrategy(log.SrcNamespace)
+	if strategy != types.ServiceStrategyService && strategy != types.ServiceStrategyBoth {
+		return []types.Egress{egress}
+	}
+
+	// the service in the remote cluster is unknown
+	if isRemoteDst(log) {
+		return []types.Egress{egress}
+	}
+
+	svcEgress := egress
+	svcEgress.MatchLabels = nil
+
+	if svc, ok := getClusterIPService(log, services); ok {
+		svcEgress.ToServices = []types.SpecService{{ServiceName: svc.ServiceName, Namespace: svc.Namespace}}
+	} else if svc, ok := getHeadlessService(log, services, pods); ok {
+		// the headless service resolves to the pod ips, i.e. <pod>.<service>.<namespace>.svc.cluster.local too
+		domainName := getServiceDomain(svc)
+		svcEgress.ToFQDNs = []types.SpecFQDN{{MatchNames: []string{domainName}, MatchPatterns: []string{"*." + domainName}}}
+		strategy = types.ServiceStrategyBoth
+	} else {
+		return []types.Egress{egress}
+	}
+
+	if strategy == types.ServiceStrategyBoth {
+		return []types.Egress{egress, svcEgress}
+	}
+
+	return []types.Egress{svcEgress}
+}
@@ -0,0 +1,100 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertLogWithServiceStrategy(t *testing.T) {
+	saved := cfg.CurrentCfg.ConfigNetPolicy
+	defer func() { cfg.CurrentCfg.ConfigNetPolicy = saved }()
+
+	pods := []types.Pod{
+		{Namespace: "shop", PodName: "web-1", Labels: []string{"app=web"}},
+		{Namespace: "shop", PodName: "cart-1", Labels: []string{"app=cart"}},
+		{Namespace: "shop", PodName: "db-0", Labels: []string{"app=db"}},
+	}
+	services := []types.Service{
+		{Namespace: "shop", ServiceName: "cart", ClusterIP: "10.96.0.10", ServicePort: 80, TargetPort: 8080,
+			Selector: map[string]string{"app": "cart"}},
+		{Namespace: "shop", ServiceName: "db", ClusterIP: "None", ServicePort: 5432, TargetPort: 5432,
+			Selector: map[string]string{"app": "db"}},
+	}
+
+	toCart := types.KnoxNetworkLog{
+		SrcNamespace: "shop", SrcPodName: "web-1", DstNamespace: "shop", DstPodName: "cart-1",
+		DstServiceNamespace: "shop", DstServiceName: "cart", Protocol: libs.IPProtocolTCP, DstPort: 8080,
+	}
+	toDB := types.KnoxNetworkLog{
+		SrcNamespace: "shop", SrcPodName: "web-1", DstNamespace: "shop", DstPodName: "db-0",
+		Protocol: libs.IPProtocolTCP, DstPort: 5432,
+	}
+	toPorts := func(port string) []types.SpecPort {
+		return []types.SpecPort{{Port: port, Protocol: "TCP"}}
+	}
+
+	// endpoint, by default
+	cfg.CurrentCfg.ConfigNetPolicy.ServiceStrategy = types.ServiceStrategyEndpoint
+	_, egress := convertKnoxNetworkLogToKnoxNetworkPolicy(&toCart, services, pods)
+	assert.Equal(t, []types.Egress{{MatchLabels: map[string]string{"app": "web"}, ToPorts: toPorts("8080")}}, egress.Spec.Egress)
+
+	// service, reached via the ClusterIP
+	cfg.CurrentCfg.ConfigNetPolicy.ServiceStrategy = types.ServiceStrategyService
+	_, egress = convertKnoxNetworkLogToKnoxNetworkPolicy(&toCart, services, pods)
+	assert.Equal(t, []types.Egress{{
+		ToServices: []types.SpecService{{ServiceName: "cart", Namespace: "shop"}},
+		ToPorts:    toPorts("8080"),
+	}}, egress.Spec.Egress)
+
+	// service, the headless service keeps the labels plus the FQDN
+	_, egress = convertKnoxNetworkLogToKnoxNetworkPolicy(&toDB, services, pods)
+	assert.Equal(t, []types.Egress{
+		{MatchLabels: map[string]string{"app": "web"}, ToPorts: toPorts("5432")},
+		{
+			ToFQDNs: []types.SpecFQDN{{
+				MatchNames:    []string{"db.shop.svc.cluster.local"},
+				MatchPatterns: []string{"*.db.shop.svc.cluster.local"},
+			}},
+			ToPorts: toPorts("5432"),
+		},
+	}, egress.Spec.Egress)
+
+	// both, overridden in the namespace
+	cfg.CurrentCfg.ConfigNetPolicy.NsServiceStrategies = map[string]string{"shop": types.ServiceStrategyBoth}
+	_, egress = convertKnoxNetworkLogToKnoxNetworkPolicy(&toCart, services, pods)
+	assert.Equal(t, []types.Egress{
+		{MatchLabels: map[string]string{"app": "web"}, ToPorts: toPorts("8080")},
+		{ToServices: []types.SpecService{{ServiceName: "cart", Namespace: "shop"}}, ToPorts: toPorts("8080")},
+	}, egress.Spec.Egress)
+
+	// not reached via any service, the labels only
+	toCart.DstServiceName, toCart.DstServiceNamespace = "", ""
+	_, egress = convertKnoxNetworkLogToKnoxNetworkPolicy(&toCart, services, pods)
+	assert.Equal(t, []types.Egress{{MatchLabels: map[string]string{"app": "web"}, ToPorts: toPorts("8080")}}, egress.Spec.Egress)
+}
+
+func TestUpdateDNSFlowsExternalName(t *testing.T) {
+	saved := DomainToIPs
+	defer func() { DomainToIPs = saved }()
+	DomainToIPs = map[string][]string{}
+
+	services := []types.Service{
+		{Namespace: "shop", ServiceName: "payment", Type: "ExternalName", ExternalName: "api.payment.example.com"},
+	}
+
+	logs := []types.KnoxNetworkLog{
+		{DNSRes: "payment.shop.svc.cluster.local", DNSResIPs: []string{"203.0.113.10"}},
+		{DNSRes: "cart.shop.svc.cluster.local", DNSResIPs: []string{"10.96.0.10"}},
+		{DstIP: "203.0.113.10", DstReservedLabels: []string{ReservedWorld}},
+	}
+
+	updateDNSFlows(logs, services)
+
+	// the internal name resolves to the external name, the other internal names are skipped
+	assert.Equal(t, map[string][]string{"api.payment.example.com": {"203.0.113.10"}}, DomainToIPs)
+	assert.Equal(t, "api.payment.example.com", logs[2].DNSQuery)
+}
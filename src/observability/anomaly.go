@@ -0,0 +1,74 @@
+package observability
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	ppb "github.com/accuknox/auto-policy-discovery/src/protobuf/v1/publisher"
+	"github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ConvertAnomalyRequest converts the request into the filter of the anomalies
+func ConvertAnomalyRequest(req *ppb.AnomalyRequest) types.Anomaly {
+	filter := types.Anomaly{
+		ClusterName: req.GetClusterName(),
+		Namespace:   req.GetNamespace(),
+		PolicyType:  req.GetPolicyType(),
+		Status:      req.GetStatus(),
+	}
+	if req.GetLabels() != "" {
+		filter.Labels = libs.WorkloadLabels(strings.Split(req.GetLabels(), ","))
+	}
+
+	return filter
+}
+
+// ConvertAnomalyToPb converts the anomaly into the protobuf message
+func ConvertAnomalyToPb(anomaly types.Anomaly) *ppb.Anomaly {
+	event, err := json.Marshal(anomaly.Event)
+	if err != nil {
+		log.Error().Msg(err.Error())
+	}
+
+	return &ppb.Anomaly{
+		ClusterName: anomaly.ClusterName,
+		Namespace:   anomaly.Namespace,
+		Labels:      anomaly.Labels,
+		PolicyType:  anomaly.PolicyType,
+		AnomalyId:   anomaly.RuleKey,
+		Rule:        anomaly.Candidate,
+		Event:       string(event),
+		FirstSeen:   anomaly.RuleStats.FirstSeen,
+		LastSeen:    anomaly.RuleStats.LastSeen,
+		HitCount:    anomaly.RuleStats.HitCount,
+		Status:      anomaly.Status,
+	}
+}
+
+// RelayAnomalyEventToGrpcStream sends the recorded anomalies matching the
+// filter of the consumer, and then the new ones
+func RelayAnomalyEventToGrpcStream(stream ppb.Publisher_GetAnomaliesServer, consumer *libs.AnomalyConsumer) error {
+	anomalies, err := libs.GetAnomalies(CfgDB, consumer.Filter)
+	if err != nil {
+		return err
+	}
+
+	for _, anomaly := range anomalies {
+		if err := stream.Send(ConvertAnomalyToPb(anomaly)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			// client disconnected
+			return nil
+		case anomaly := <-consumer.Events:
+			if err := stream.Send(ConvertAnomalyToPb(*anomaly)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -3,8 +3,10 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -100,6 +102,8 @@ func getL4Ports(l4 *cilium.Layer4) (int, int) {
 		return int(l4.GetUDP().SourcePort), int(l4.GetUDP().DestinationPort)
 	} else if l4.GetICMPv4() != nil {
 		return int(l4.GetICMPv4().Type), int(l4.GetICMPv4().Code)
+	} else if l4.GetICMPv6() != nil {
+		return int(l4.GetICMPv6().Type), int(l4.GetICMPv6().Code)
 	} else {
 		return -1, -1
 	}
@@ -140,6 +144,29 @@ func getReservedLabelsIfExist(labels []string) []string {
 	return reservedLabels
 }
 
+// getEndpointLabels returns the k8s labels of the endpoint without the namespace
+// and the cilium labels, and the cluster of the endpoint in the cluster mesh
+func getEndpointLabels(labels []string) ([]string, string) {
+	var endpointLabels []string
+	clusterName := ""
+
+	for _, label := range labels {
+		if !strings.HasPrefix(label, "k8s:") {
+			continue
+		}
+		label = strings.TrimPrefix(label, "k8s:")
+
+		if strings.HasPrefix(label, types.CiliumClusterLabel+"=") {
+			clusterName = strings.TrimPrefix(label, types.CiliumClusterLabel+"=")
+		} else if !strings.HasPrefix(label, "io.cilium.k8s.") && !strings.HasPrefix(label, "io.kubernetes.pod.namespace=") {
+			endpointLabels = append(endpointLabels, label)
+		}
+	}
+
+	sort.Strings(endpointLabels)
+	return endpointLabels, clusterName
+}
+
 func getHTTP(flow *cilium.Flow) (string, string) {
 	if flow.L7 != nil && flow.L7.GetHttp() != nil {
 		if flow.L7.GetType() == 1 { // REQUEST only
@@ -158,6 +185,72 @@ func getHTTP(flow *cilium.Flow) (string, string) {
 	return "", ""
 }
 
+// getHTTPHost returns the host of the request without the port
+func getHTTPHost(flow *cilium.Flow) string {
+	var host string
+
+	for _, header := range flow.L7.GetHttp().GetHeaders() {
+		key := strings.ToLower(header.GetKey())
+		if key == ":authority" || key == "host" {
+			host = header.GetValue()
+			break
+		}
+	}
+
+	if host == "" {
+		if u, err := url.Parse(flow.L7.GetHttp().GetUrl()); err == nil {
+			host = u.Host
+		}
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.Trim(host, "[]")
+}
+
+// getHTTPHostHeaders returns the host (authority) if enabled, and the allowed request headers
+func getHTTPHostHeaders(flow *cilium.Flow) (string, []string) {
+	var host string
+	var headers []string
+
+	if config.GetCfgNetworkHTTPHost() {
+		host = getHTTPHost(flow)
+	}
+
+	for _, header := range flow.L7.GetHttp().GetHeaders() {
+		key := strings.ToLower(header.GetKey())
+		if key == ":authority" || key == "host" {
+			continue
+		}
+
+		for _, allowed := range config.GetCfgNetworkHTTPHeaderAllowlist() {
+			if key == strings.ToLower(allowed) {
+				headers = append(headers, key+": "+header.GetValue())
+			}
+		}
+	}
+
+	sort.Strings(headers)
+
+	return host, headers
+}
+
+// isGRPC checks if the HTTP request is gRPC by the content-type header, or by the
+// HTTP/2 POST request to the /package.Service/Method path without the headers
+func isGRPC(flow *cilium.Flow, method, path string) bool {
+	http := flow.L7.GetHttp()
+
+	for _, header := range http.GetHeaders() {
+		if strings.EqualFold(header.GetKey(), "content-type") {
+			return strings.HasPrefix(header.GetValue(), "application/grpc")
+		}
+	}
+
+	return strings.HasPrefix(http.GetProtocol(), "HTTP/2") && method == "POST" && libs.IsGRPCPath(path)
+}
+
 // ============================ //
 // == Network Flow Convertor == //
 // ============================ //
@@ -165,15 +258,11 @@ func getHTTP(flow *cilium.Flow) (string, string) {
 func ConvertCiliumFlowToKnoxNetworkLog(ciliumFlow *cilium.Flow) (types.KnoxNetworkLog, bool) {
 	log := types.KnoxNetworkLog{}
 
-	// TODO: packet is dropped (flow.Verdict == 2) and drop reason == 181 (Flows denied by deny policy)?
+	// set action, the drop reason tells the flows dropped by the policies
 	// http://github.com/cilium/cilium/blob/f3887bd83f6f7495f5d487fe1002896488b9495f/bpf/lib/common.h#L432s
-	if ciliumFlow.Verdict == cilium.Verdict_DROPPED && ciliumFlow.GetDropReasonDesc() == 181 {
-		return log, false
-	}
-
-	// set action
-	if ciliumFlow.Verdict == 2 {
+	if ciliumFlow.Verdict == cilium.Verdict_DROPPED {
 		log.Action = "deny"
+		log.DropReason = int(ciliumFlow.GetDropReasonDesc())
 	} else {
 		log.Action = "allow"
 	}
@@ -189,16 +278,32 @@ func ConvertCiliumFlowToKnoxNetworkLog(ciliumFlow *cilium.Flow) (types.KnoxNetwo
 	log.SrcPodName = ciliumFlow.Source.GetPodName()
 	log.DstPodName = ciliumFlow.Destination.GetPodName()
 
+	// set node, the relay prefixes the node name with the cluster name
+	nodeName := ciliumFlow.GetNodeName()
+	log.NodeName = nodeName[strings.LastIndex(nodeName, "/")+1:]
+
 	// copy reservedLabels
 	log.DstReservedLabels = getReservedLabelsIfExist(ciliumFlow.Destination.Labels)
 	log.SrcReservedLabels = getReservedLabelsIfExist(ciliumFlow.Source.Labels)
 
+	// copy the labels and the cluster to resolve the endpoints in the cluster mesh
+	log.SrcLabels, log.SrcCluster = getEndpointLabels(ciliumFlow.Source.Labels)
+	log.DstLabels, log.DstCluster = getEndpointLabels(ciliumFlow.Destination.Labels)
+
+	// the service through which the dst is reached
+	log.DstServiceName = ciliumFlow.GetDestinationService().GetName()
+	log.DstServiceNamespace = ciliumFlow.GetDestinationService().GetNamespace()
+
 	log.IsReply = ciliumFlow.GetIsReply().GetValue()
 
 	// get L3
 	if ciliumFlow.IP != nil {
 		log.SrcIP = ciliumFlow.IP.Source
 		log.DstIP = ciliumFlow.IP.Destination
+		log.EtherType = libs.EtherTypeIPv4
+		if ciliumFlow.IP.IpVersion == cilium.IPVersion_IPv6 || libs.IsIPv6(log.SrcIP) {
+			log.EtherType = libs.EtherTypeIPv6
+		}
 	} else {
 		return log, false
 	}
@@ -212,7 +317,7 @@ func ConvertCiliumFlowToKnoxNetworkLog(ciliumFlow *cilium.Flow) (types.KnoxNetwo
 			// Sometimes, ICMP flow for certain `type` (like EchoReply)
 			// does not have the `IsReply` flag set in the Cilium Flow.
 			// So we cannot fully rely on `IsReply` flag in case of ICMP flows.
-			if libs.IsReplyICMP(log.ICMPType) {
+			if libs.IsReplyICMP(log.Protocol, log.ICMPType) {
 				log.IsReply = true
 			}
 		} else { // tcp & udp
@@ -232,18 +337,27 @@ func ConvertCiliumFlowToKnoxNetworkLog(ciliumFlow *cilium.Flow) (types.KnoxNetwo
 		if log.HTTPMethod == "" && log.HTTPPath == "" {
 			return log, false
 		}
+		log.HTTPHost, log.HTTPHeaders = getHTTPHostHeaders(ciliumFlow)
 		log.L7Protocol = libs.L7ProtocolHTTP
+		if isGRPC(ciliumFlow, log.HTTPMethod, log.HTTPPath) {
+			log.L7Protocol = libs.L7ProtocolGRPC
+		}
+	}
+
+	// get L7 Kafka
+	if ciliumFlow.GetL7() != nil && ciliumFlow.L7.GetKafka() != nil {
+		if ciliumFlow.L7.GetType() != cilium.L7FlowType_REQUEST {
+			return log, false
+		}
+		log.KafkaAPIKey = ciliumFlow.L7.GetKafka().GetApiKey()
+		log.KafkaTopic = ciliumFlow.L7.GetKafka().GetTopic()
+		log.L7Protocol = libs.L7ProtocolKafka
 	}
 
 	// get L7 DNS
 	if ciliumFlow.GetL7() != nil && ciliumFlow.L7.GetDns() != nil {
 		// if DSN response includes IPs
 		if ciliumFlow.L7.GetType() == 2 && len(ciliumFlow.L7.GetDns().Ips) > 0 {
-			// if internal services, skip
-			if strings.HasSuffix(ciliumFlow.L7.GetDns().GetQuery(), "svc.cluster.local.") {
-				return log, false
-			}
-
 			query := strings.TrimSuffix(ciliumFlow.L7.GetDns().GetQuery(), ".")
 			ips := ciliumFlow.L7.GetDns().GetIps()
 
@@ -513,7 +627,7 @@ func getCoreDNSEndpoint(services []types.Service) ([]types.CiliumEndpoint, []typ
 	toPorts := []types.CiliumPortList{ciliumPort}
 
 	// matchPattern
-	dnsRules := []types.SubRule{map[string]string{"matchPattern": "*"}}
+	dnsRules := []types.SubRule{{"matchPattern": "*"}}
 	toPorts[0].Rules = map[string][]types.SubRule{"dns": dnsRules}
 
 	return coreDNS, toPorts
@@ -525,7 +639,7 @@ func buildNewCiliumNetworkPolicy(inPolicy types.KnoxNetworkPolicy) types.CiliumN
 	ciliumPolicy.APIVersion = "cilium.io/v2"
 	ciliumPolicy.Metadata = map[string]string{}
 	for k, v := range inPolicy.Metadata {
-		if k == "name" || k == "namespace" {
+		if k == "name" || (k == "namespace" && v != "") {
 			ciliumPolicy.Metadata[k] = v
 		}
 	}
@@ -533,9 +647,20 @@ func buildNewCiliumNetworkPolicy(inPolicy types.KnoxNetworkPolicy) types.CiliumN
 	if inPolicy.Kind == types.KindKnoxHostNetworkPolicy {
 		ciliumPolicy.Kind = cu.ResourceTypeCiliumClusterwideNetworkPolicy
 		ciliumPolicy.Spec.NodeSelector.MatchLabels = inPolicy.Spec.Selector.MatchLabels
+	} else if inPolicy.Kind == types.KindKnoxClusterwideNetworkPolicy {
+		// the endpoints having the labels in all the namespaces
+		ciliumPolicy.Kind = cu.ResourceTypeCiliumClusterwideNetworkPolicy
+		ciliumPolicy.Spec.EndpointSelector.MatchLabels = inPolicy.Spec.Selector.MatchLabels
 	} else {
 		ciliumPolicy.Kind = cu.ResourceTypeCiliumNetworkPolicy
 		ciliumPolicy.Spec.EndpointSelector.MatchLabels = inPolicy.Spec.Selector.MatchLabels
+
+		// an empty selector is omitted, select all the endpoints in the namespace instead
+		if len(inPolicy.Spec.Selector.MatchLabels) == 0 {
+			ciliumPolicy.Spec.EndpointSelector.MatchLabels = map[string]string{
+				types.CiliumNamespaceLabel: inPolicy.Metadata["namespace"],
+			}
+		}
 	}
 
 	return ciliumPolicy
@@ -551,6 +676,11 @@ func ConvertKnoxNetworkPolicyToCiliumPolicy(inPolicy types.KnoxNetworkPolicy) ty
 		ciliumPolicy.Spec.Egress = []types.CiliumEgress{}
 
 		for _, knoxEgress := range inPolicy.Spec.Egress {
+			// stale rule dropped from the policy
+			if knoxEgress.Pruned {
+				continue
+			}
+
 			ciliumEgress := types.CiliumEgress{}
 
 			if knoxEgress.MatchLabels != nil {
@@ -563,9 +693,7 @@ func ConvertKnoxNetworkPolicyToCiliumPolicy(inPolicy types.KnoxNetworkPolicy) ty
 				// build CIDR rule //
 				// =============== //
 				for _, toCIDR := range knoxEgress.ToCIDRs {
-					cidrs := []string{}
-					cidrs = append(cidrs, toCIDR.CIDRs...)
-					ciliumEgress.ToCIDRs = cidrs
+					ciliumEgress.ToCIDRs = append(ciliumEgress.ToCIDRs, toCIDR.CIDRs...)
 				}
 			} else if len(knoxEgress.ToEntities) > 0 {
 				// ================= //
@@ -590,6 +718,9 @@ func ConvertKnoxNetworkPolicyToCiliumPolicy(inPolicy types.KnoxNetworkPolicy) ty
 					for _, matchName := range fqdn.MatchNames {
 						ciliumEgress.ToFQDNs = append(ciliumEgress.ToFQDNs, map[string]string{"matchName": matchName})
 					}
+					for _, matchPattern := range fqdn.MatchPatterns {
+						ciliumEgress.ToFQDNs = append(ciliumEgress.ToFQDNs, map[string]string{"matchPattern": matchPattern})
+					}
 				}
 			} else if len(knoxEgress.ToServices) > 0 {
 				// ================== //
@@ -631,14 +762,19 @@ func ConvertKnoxNetworkPolicyToCiliumPolicy(inPolicy types.KnoxNetworkPolicy) ty
 
 					httpRules := []types.SubRule{}
 					for _, http := range knoxEgress.ToHTTPs {
-						// matchPattern
-						httpRules = append(httpRules, map[string]string{"method": http.Method,
-							"path": http.Path})
+						httpRules = append(httpRules, convertKnoxHTTPRule(http))
 					}
 					ciliumEgress.ToPorts[0].Rules = map[string][]types.SubRule{"http": httpRules}
 				}
 
-				port := types.CiliumPort{Port: toPort.Port, Protocol: strings.ToUpper(toPort.Protocol)}
+				// ================ //
+				// build Kafka rule //
+				// ================ //
+				if len(knoxEgress.ToKafkas) > 0 {
+					ciliumEgress.ToPorts[0].Rules = map[string][]types.SubRule{"kafka": convertKnoxKafkaRules(knoxEgress.ToKafkas)}
+				}
+
+				port := types.CiliumPort{Port: toPort.Port, EndPort: toPort.EndPort, Protocol: strings.ToUpper(toPort.Protocol)}
 				ciliumEgress.ToPorts[0].Ports = append(ciliumEgress.ToPorts[0].Ports, port)
 			}
 
@@ -667,6 +803,11 @@ func ConvertKnoxNetworkPolicyToCiliumPolicy(inPolicy types.KnoxNetworkPolicy) ty
 		ciliumPolicy.Spec.Ingress = []types.CiliumIngress{}
 
 		for _, knoxIngress := range inPolicy.Spec.Ingress {
+			// stale rule dropped from the policy
+			if knoxIngress.Pruned {
+				continue
+			}
+
 			ciliumIngress := types.CiliumIngress{}
 
 			// ================= //
@@ -709,14 +850,19 @@ func ConvertKnoxNetworkPolicyToCiliumPolicy(inPolicy types.KnoxNetworkPolicy) ty
 
 					httpRules := []types.SubRule{}
 					for _, http := range knoxIngress.ToHTTPs {
-						// matchPattern
-						httpRules = append(httpRules, map[string]string{"method": http.Method,
-							"path": http.Path})
+						httpRules = append(httpRules, convertKnoxHTTPRule(http))
 					}
 					ciliumIngress.ToPorts[0].Rules = map[string][]types.SubRule{"http": httpRules}
 				}
 
-				port := types.CiliumPort{Port: toPort.Port, Protocol: strings.ToUpper(toPort.Protocol)}
+				// ================ //
+				// build Kafka rule //
+				// ================ //
+				if len(knoxIngress.ToKafkas) > 0 {
+					ciliumIngress.ToPorts[0].Rules = map[string][]types.SubRule{"kafka": convertKnoxKafkaRules(knoxIngress.ToKafkas)}
+				}
+
+				port := types.CiliumPort{Port: toPort.Port, EndPort: toPort.EndPort, Protocol: strings.ToUpper(toPort.Protocol)}
 				ciliumIngress.ToPorts[0].Ports = append(ciliumIngress.ToPorts[0].Ports, port)
 			}
 
@@ -753,6 +899,270 @@ func ConvertKnoxPoliciesToCiliumPolicies(policies []types.KnoxNetworkPolicy) []t
 	return ciliumPolicies
 }
 
+// buildKnoxPolicyFromCiliumPolicy builds the knox policy of the direction, having
+// the metadata and the selector of the cilium policy
+func buildKnoxPolicyFromCiliumPolicy(ciliumPolicy types.CiliumNetworkPolicy, polType string) types.KnoxNetworkPolicy {
+	policy := types.KnoxNetworkPolicy{
+		APIVersion: "v1",
+		Kind:       types.KindKnoxNetworkPolicy,
+		Metadata: map[string]string{
+			"name":      ciliumPolicy.Metadata["name"],
+			"namespace": ciliumPolicy.Metadata["namespace"],
+			"type":      polType,
+			"status":    types.PolicyStatusImported,
+		},
+		Spec: types.Spec{
+			Selector: types.Selector{MatchLabels: map[string]string{}},
+			Action:   "allow",
+		},
+	}
+
+	selector := ciliumPolicy.Spec.EndpointSelector.MatchLabels
+	if ciliumPolicy.Kind == cu.ResourceTypeCiliumClusterwideNetworkPolicy {
+		if len(ciliumPolicy.Spec.NodeSelector.MatchLabels) > 0 || len(selector) == 0 {
+			policy.Kind = types.KindKnoxHostNetworkPolicy
+			selector = ciliumPolicy.Spec.NodeSelector.MatchLabels
+		} else {
+			policy.Kind = types.KindKnoxClusterwideNetworkPolicy
+		}
+	}
+
+	for k, v := range selector {
+		// the namespace label is implied by the namespace of the policy
+		if k == types.CiliumNamespaceLabel {
+			continue
+		}
+		policy.Spec.Selector.MatchLabels[k] = v
+	}
+
+	return policy
+}
+
+// convertKnoxHTTPRule converts the http rule into the cilium http sub rule
+func convertKnoxHTTPRule(http types.SpecHTTP) types.SubRule {
+	subRule := types.SubRule{"method": http.Method, "path": http.Path}
+	if http.Host != "" {
+		subRule["host"] = http.Host
+	}
+	if len(http.Headers) > 0 {
+		subRule["headers"] = http.Headers
+	}
+	return subRule
+}
+
+// subRuleString returns the string field of the sub rule
+func subRuleString(subRule types.SubRule, key string) string {
+	if val, ok := subRule[key].(string); ok {
+		return val
+	}
+	return ""
+}
+
+// subRuleStrings returns the string list field of the sub rule
+func subRuleStrings(subRule types.SubRule, key string) []string {
+	var results []string
+
+	switch vals := subRule[key].(type) {
+	case []string:
+		results = append(results, vals...)
+	case []interface{}:
+		for _, val := range vals {
+			if str, ok := val.(string); ok {
+				results = append(results, str)
+			}
+		}
+	}
+
+	return results
+}
+
+// convertKnoxKafkaRules converts the kafka rules into the cilium kafka sub rules
+func convertKnoxKafkaRules(kafkaRules []types.SpecKafka) []types.SubRule {
+	subRules := []types.SubRule{}
+	for _, kafka := range kafkaRules {
+		subRule := types.SubRule{}
+		if kafka.Role != "" {
+			subRule["role"] = kafka.Role
+		}
+		if kafka.APIKey != "" {
+			subRule["apiKey"] = kafka.APIKey
+		}
+		if kafka.ClientID != "" {
+			subRule["clientID"] = kafka.ClientID
+		}
+		if kafka.Topic != "" {
+			subRule["topic"] = kafka.Topic
+		}
+		subRules = append(subRules, subRule)
+	}
+	return subRules
+}
+
+// convertCiliumPorts converts the L4, the HTTP and the Kafka rules of the cilium port lists
+func convertCiliumPorts(portLists []types.CiliumPortList) ([]types.SpecPort, []types.SpecHTTP, []types.SpecKafka) {
+	var toPorts []types.SpecPort
+	var toHTTPs []types.SpecHTTP
+	var toKafkas []types.SpecKafka
+
+	for _, portList := range portLists {
+		for _, port := range portList.Ports {
+			toPorts = append(toPorts, types.SpecPort{Port: port.Port, EndPort: port.EndPort, Protocol: strings.ToUpper(port.Protocol)})
+		}
+
+		for _, http := range portList.Rules["http"] {
+			method, path := subRuleString(http, "method"), subRuleString(http, "path")
+			toHTTPs = append(toHTTPs, types.SpecHTTP{Method: method, Path: path,
+				GRPC: method == "POST" && libs.IsGRPCPath(path),
+				Host: subRuleString(http, "host"), Headers: subRuleStrings(http, "headers")})
+		}
+
+		for _, kafka := range portList.Rules["kafka"] {
+			toKafkas = append(toKafkas, types.SpecKafka{Role: subRuleString(kafka, "role"), APIKey: subRuleString(kafka, "apiKey"),
+				ClientID: subRuleString(kafka, "clientID"), Topic: subRuleString(kafka, "topic")})
+		}
+	}
+
+	return toPorts, toHTTPs, toKafkas
+}
+
+// checkCiliumDNSRules checks the dns rules of the cilium port lists, the knox
+// policies have no dns rule but the one allowing all the names with the egress
+// to the kube-dns, so the narrower dns rules can not be converted
+func checkCiliumDNSRules(portLists []types.CiliumPortList) error {
+	for _, portList := range portLists {
+		for _, dns := range portList.Rules["dns"] {
+			if len(dns) != 1 || subRuleString(dns, "matchPattern") != "*" {
+				return fmt.Errorf("unsupported dns rule %v", dns)
+			}
+		}
+	}
+	return nil
+}
+
+// convertCiliumCIDRs converts the cidrs and the cidr sets, the cidr sets with
+// the exceptions are kept in their own cidr rules
+func convertCiliumCIDRs(cidrs []string, cidrSets []types.CiliumCIDRSet) []types.SpecCIDR {
+	var results []types.SpecCIDR
+
+	plain := append([]string{}, cidrs...)
+	for _, cidrSet := range cidrSets {
+		if len(cidrSet.Except) == 0 {
+			plain = append(plain, cidrSet.CIDR)
+			continue
+		}
+		results = append(results, types.SpecCIDR{CIDRs: []string{cidrSet.CIDR}, Except: cidrSet.Except})
+	}
+
+	if len(plain) > 0 {
+		results = append([]types.SpecCIDR{{CIDRs: plain}}, results...)
+	}
+
+	return results
+}
+
+func convertCiliumICMPs(icmps []types.CiliumICMP) []types.SpecICMP {
+	var results []types.SpecICMP
+	for _, icmp := range icmps {
+		for _, field := range icmp.Fields {
+			results = append(results, types.SpecICMP{Family: field.Family, Type: field.Type})
+		}
+	}
+	return results
+}
+
+// ConvertCiliumPolicyToKnoxNetworkPolicies converts the cilium policy back into the
+// knox policies, the ingress and the egress rules are separated into each policy
+func ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy types.CiliumNetworkPolicy) ([]types.KnoxNetworkPolicy, error) {
+	results := []types.KnoxNetworkPolicy{}
+
+	// ====== //
+	// Egress //
+	// ====== //
+	if len(ciliumPolicy.Spec.Egress) > 0 {
+		policy := buildKnoxPolicyFromCiliumPolicy(ciliumPolicy, "egress")
+
+		for _, ciliumEgress := range ciliumPolicy.Spec.Egress {
+			if err := checkCiliumDNSRules(ciliumEgress.ToPorts); err != nil {
+				return nil, err
+			}
+
+			egress := types.Egress{}
+			egress.ToPorts, egress.ToHTTPs, egress.ToKafkas = convertCiliumPorts(ciliumEgress.ToPorts)
+			egress.ICMPs = convertCiliumICMPs(ciliumEgress.ICMPs)
+			egress.ToCIDRs = convertCiliumCIDRs(ciliumEgress.ToCIDRs, ciliumEgress.ToCIDRSet)
+			egress.ToEntities = ciliumEgress.ToEntities
+
+			for _, fqdn := range ciliumEgress.ToFQDNs {
+				if len(egress.ToFQDNs) == 0 {
+					egress.ToFQDNs = []types.SpecFQDN{{}}
+				}
+				if matchName, ok := fqdn["matchName"]; ok {
+					egress.ToFQDNs[0].MatchNames = append(egress.ToFQDNs[0].MatchNames, matchName)
+				}
+				if matchPattern, ok := fqdn["matchPattern"]; ok {
+					egress.ToFQDNs[0].MatchPatterns = append(egress.ToFQDNs[0].MatchPatterns, matchPattern)
+				}
+			}
+
+			for _, service := range ciliumEgress.ToServices {
+				egress.ToServices = append(egress.ToServices, types.SpecService{
+					ServiceName: service.K8sService.ServiceName,
+					Namespace:   service.K8sService.Namespace,
+				})
+			}
+
+			// a label-based rule per endpoint
+			if len(ciliumEgress.ToEndpoints) > 0 {
+				for _, endpoint := range ciliumEgress.ToEndpoints {
+					labelEgress := egress
+					labelEgress.MatchLabels = endpoint.MatchLabels
+					policy.Spec.Egress = append(policy.Spec.Egress, labelEgress)
+				}
+				continue
+			}
+
+			policy.Spec.Egress = append(policy.Spec.Egress, egress)
+		}
+
+		results = append(results, policy)
+	}
+
+	// ======= //
+	// Ingress //
+	// ======= //
+	if len(ciliumPolicy.Spec.Ingress) > 0 {
+		policy := buildKnoxPolicyFromCiliumPolicy(ciliumPolicy, "ingress")
+
+		for _, ciliumIngress := range ciliumPolicy.Spec.Ingress {
+			if err := checkCiliumDNSRules(ciliumIngress.ToPorts); err != nil {
+				return nil, err
+			}
+
+			ingress := types.Ingress{}
+			ingress.ToPorts, ingress.ToHTTPs, ingress.ToKafkas = convertCiliumPorts(ciliumIngress.ToPorts)
+			ingress.ICMPs = convertCiliumICMPs(ciliumIngress.ICMPs)
+			ingress.FromCIDRs = convertCiliumCIDRs(ciliumIngress.FromCIDRs, ciliumIngress.FromCIDRSet)
+			ingress.FromEntities = ciliumIngress.FromEntities
+
+			// a label-based rule per endpoint
+			if len(ciliumIngress.FromEndpoints) > 0 {
+				for _, endpoint := range ciliumIngress.FromEndpoints {
+					labelIngress := ingress
+					labelIngress.MatchLabels = endpoint.MatchLabels
+					policy.Spec.Ingress = append(policy.Spec.Ingress, labelIngress)
+				}
+				continue
+			}
+
+			policy.Spec.Ingress = append(policy.Spec.Ingress, ingress)
+		}
+
+		results = append(results, policy)
+	}
+
+	return results, nil
+}
+
 // ========================= //
 // == Cilium Hubble Relay == //
 // ========================= //
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/accuknox/auto-policy-discovery/src/config"
 	"github.com/accuknox/auto-policy-discovery/src/types"
 	flow "github.com/cilium/cilium/api/v1/flow"
 	"github.com/google/go-cmp/cmp"
+	nv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestConvertCiliumFlowToKnoxLog(t *testing.T) {
@@ -67,11 +70,17 @@ func TestConvertCiliumFlowToKnoxLog(t *testing.T) {
 
 	/*
 		{
+			"node_name": "z100-n39",
 			"src_namespace": "default",
 			"src_pod_name": "redis-cart-74594bd569-gw2xb",
+			"src_cluster": "default",
+			"src_labels": [
+				"app=redis-cart"
+			],
 			"dst_reserved_labels": [
 				"reserved:host"
-			]
+			],
+			"ether_type": 2048,
 			"protocol": 6,
 			"src_ip": "10.0.1.31",
 			"dst_ip": "10.0.1.144",
@@ -81,7 +90,7 @@ func TestConvertCiliumFlowToKnoxLog(t *testing.T) {
 			"action": "allow"
 		}
 	*/
-	logBytes := []byte("{\"src_namespace\":\"default\",\"src_pod_name\":\"redis-cart-74594bd569-gw2xb\",\"dst_reserved_labels\":[\"reserved:host\"],\"protocol\":6,\"src_ip\":\"10.0.1.31\",\"dst_ip\":\"10.0.1.144\",\"src_port\":6379,\"dst_port\":60416,\"direction\":\"INGRESS\",\"action\":\"allow\"}")
+	logBytes := []byte("{\"node_name\":\"z100-n39\",\"src_namespace\":\"default\",\"src_pod_name\":\"redis-cart-74594bd569-gw2xb\",\"src_cluster\":\"default\",\"src_labels\":[\"app=redis-cart\"],\"dst_reserved_labels\":[\"reserved:host\"],\"ether_type\":2048,\"protocol\":6,\"src_ip\":\"10.0.1.31\",\"dst_ip\":\"10.0.1.144\",\"src_port\":6379,\"dst_port\":60416,\"direction\":\"INGRESS\",\"action\":\"allow\"}")
 	flow := &flow.Flow{}
 	json.Unmarshal(flowBytes, flow)
 
@@ -94,6 +103,34 @@ func TestConvertCiliumFlowToKnoxLog(t *testing.T) {
 	}
 }
 
+func TestIsGRPC(t *testing.T) {
+	grpcFlow := &flow.Flow{L7: &flow.Layer7{Record: &flow.Layer7_Http{Http: &flow.HTTP{
+		Method:   "POST",
+		Url:      "http://cart:7070/hipstershop.CartService/GetCart",
+		Protocol: "HTTP/2",
+		Headers:  []*flow.HTTPHeader{{Key: "content-type", Value: "application/grpc"}},
+	}}}}
+	if !isGRPC(grpcFlow, "POST", "/hipstershop.CartService/GetCart") {
+		t.Errorf("the flow with the grpc content-type should be gRPC")
+	}
+
+	// no headers, HTTP/2 POST to the service/method path
+	grpcFlow.L7.GetHttp().Headers = nil
+	if !isGRPC(grpcFlow, "POST", "/hipstershop.CartService/GetCart") {
+		t.Errorf("the HTTP/2 POST to the service/method path should be gRPC")
+	}
+
+	httpFlow := &flow.Flow{L7: &flow.Layer7{Record: &flow.Layer7_Http{Http: &flow.HTTP{
+		Method:   "POST",
+		Url:      "http://api:8080/v1/users",
+		Protocol: "HTTP/2",
+		Headers:  []*flow.HTTPHeader{{Key: "Content-Type", Value: "application/json"}},
+	}}}}
+	if isGRPC(httpFlow, "POST", "/v1/users") {
+		t.Errorf("the flow with the json content-type should not be gRPC")
+	}
+}
+
 func TestConvertKnoxPolicyToCiliumPolicy(t *testing.T) {
 	/*
 		{
@@ -182,3 +219,288 @@ func TestConvertKnoxPolicyToCiliumPolicy(t *testing.T) {
 		t.Errorf("they should be equal %v %v", expected, actual)
 	}
 }
+
+func TestConvertCiliumPolicyToKnoxNetworkPolicies(t *testing.T) {
+	ciliumBytes := []byte("{\"apiVersion\":\"cilium.io/v2\",\"kind\":\"CiliumNetworkPolicy\",\"metadata\":{\"name\":\"web\",\"namespace\":\"default\"},\"spec\":{\"endpointSelector\":{\"matchLabels\":{\"app\":\"web\"}},\"egress\":[{\"toEndpoints\":[{\"matchLabels\":{\"app\":\"redis\"}},{\"matchLabels\":{\"app\":\"db\"}}],\"toPorts\":[{\"ports\":[{\"port\":\"6379\",\"protocol\":\"TCP\"}]}]}],\"ingress\":[{\"fromEntities\":[\"world\"]}]}}")
+
+	ciliumPolicy := types.CiliumNetworkPolicy{}
+	if err := json.Unmarshal(ciliumBytes, &ciliumPolicy); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected the egress and the ingress policies, got %d", len(results))
+	}
+
+	expectedEgress := []types.Egress{
+		{MatchLabels: map[string]string{"app": "redis"}, ToPorts: []types.SpecPort{{Port: "6379", Protocol: "TCP"}}},
+		{MatchLabels: map[string]string{"app": "db"}, ToPorts: []types.SpecPort{{Port: "6379", Protocol: "TCP"}}},
+	}
+	if results[0].Metadata["type"] != "egress" || !cmp.Equal(expectedEgress, results[0].Spec.Egress) {
+		t.Errorf("unexpected egress policy %v", results[0])
+	}
+
+	expectedIngress := []types.Ingress{{FromEntities: []string{"world"}}}
+	if results[1].Metadata["type"] != "ingress" || !cmp.Equal(expectedIngress, results[1].Spec.Ingress) {
+		t.Errorf("unexpected ingress policy %v", results[1])
+	}
+}
+
+func TestConvertCiliumCIDRSetAndDNSRules(t *testing.T) {
+	ciliumBytes := []byte("{\"kind\":\"CiliumNetworkPolicy\",\"metadata\":{\"name\":\"web\",\"namespace\":\"default\"},\"spec\":{\"endpointSelector\":{\"matchLabels\":{\"app\":\"web\"}},\"egress\":[{\"toCIDR\":[\"1.1.1.1/32\"],\"toCIDRSet\":[{\"cidr\":\"8.8.8.8/32\"},{\"cidr\":\"0.0.0.0/0\",\"except\":[\"10.0.0.0/8\"]}]},{\"toEndpoints\":[{\"matchLabels\":{\"k8s-app\":\"kube-dns\"}}],\"toPorts\":[{\"ports\":[{\"port\":\"53\",\"protocol\":\"UDP\"}],\"rules\":{\"dns\":[{\"matchPattern\":\"*\"}]}}]}]}}")
+
+	ciliumPolicy := types.CiliumNetworkPolicy{}
+	if err := json.Unmarshal(ciliumBytes, &ciliumPolicy); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Metadata["status"] != types.PolicyStatusImported {
+		t.Fatalf("unexpected knox policies %v", results)
+	}
+
+	expected := []types.SpecCIDR{
+		{CIDRs: []string{"1.1.1.1/32", "8.8.8.8/32"}},
+		{CIDRs: []string{"0.0.0.0/0"}, Except: []string{"10.0.0.0/8"}},
+	}
+	if !cmp.Equal(expected, results[0].Spec.Egress[0].ToCIDRs) {
+		t.Errorf("unexpected cidrs %v", results[0].Spec.Egress[0].ToCIDRs)
+	}
+
+	// the dns rule narrower than the one knox generates is not converted
+	ciliumPolicy.Spec.Egress[1].ToPorts[0].Rules["dns"] = []types.SubRule{{"matchName": "example.com"}}
+	if _, err := ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy); err == nil {
+		t.Errorf("the narrower dns rule should be rejected")
+	}
+}
+
+func TestConvertK8sNetworkPolicyMatchExpressions(t *testing.T) {
+	k8NetPol := nv1.NetworkPolicy{}
+	k8NetPol.Name, k8NetPol.Namespace = "web", "default"
+	k8NetPol.Spec.PodSelector.MatchLabels = map[string]string{"app": "web"}
+	k8NetPol.Spec.Egress = []nv1.NetworkPolicyEgressRule{{
+		To: []nv1.NetworkPolicyPeer{{
+			PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"redis"}},
+			}},
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: types.K8sNamespaceNameLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"cache"}},
+			}},
+		}},
+	}}
+
+	results, err := ConvertK8sNetworkPolicyToKnoxNetworkPolicies(k8NetPol)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"app": "redis", types.CiliumNamespaceLabel: "cache"}
+	if len(results) != 1 || !cmp.Equal(expected, results[0].Spec.Egress[0].MatchLabels) {
+		t.Errorf("unexpected knox policies %v", results)
+	}
+
+	// the expressions selecting more than a label value are not converted
+	k8NetPol.Spec.Egress[0].To[0].PodSelector.MatchExpressions[0].Operator = metav1.LabelSelectorOpNotIn
+	if _, err := ConvertK8sNetworkPolicyToKnoxNetworkPolicies(k8NetPol); err == nil {
+		t.Errorf("the NotIn expression should be rejected")
+	}
+}
+
+func TestConvertServiceEgressToK8sNetworkPolicy(t *testing.T) {
+	toPorts := []types.SpecPort{{Port: "80", Protocol: "TCP"}}
+	knoxPolicy := types.KnoxNetworkPolicy{Metadata: map[string]string{"name": "web", "namespace": "shop"}}
+	knoxPolicy.Spec.Selector.MatchLabels = map[string]string{"app": "web"}
+	knoxPolicy.Spec.Egress = []types.Egress{
+		{ToServices: []types.SpecService{{ServiceName: "cart", Namespace: "shop"}}, ToPorts: toPorts},
+		{ToFQDNs: []types.SpecFQDN{{MatchNames: []string{"db.shop.svc.cluster.local"}, MatchPatterns: []string{"*.db.shop.svc.cluster.local"}}}, ToPorts: toPorts},
+	}
+
+	// the service rules only, no peer to allow the port to
+	if k8sPolicies := ConvertKnoxNetPolicyToK8sNetworkPolicy("", "", []types.KnoxNetworkPolicy{knoxPolicy}); len(k8sPolicies) != 0 {
+		t.Errorf("unexpected k8s policies %v", k8sPolicies)
+	}
+
+	// both, the endpoint rule is kept
+	knoxPolicy.Spec.Egress = append(knoxPolicy.Spec.Egress, types.Egress{MatchLabels: map[string]string{"app": "cart"}, ToPorts: toPorts})
+	k8sPolicies := ConvertKnoxNetPolicyToK8sNetworkPolicy("", "", []types.KnoxNetworkPolicy{knoxPolicy})
+	if len(k8sPolicies) != 1 || len(k8sPolicies[0].Spec.Egress) != 1 || len(k8sPolicies[0].Spec.Egress[0].To) != 1 {
+		t.Errorf("unexpected k8s policies %v", k8sPolicies)
+	}
+}
+
+func TestConvertPrunedPolicy(t *testing.T) {
+	toPorts := []types.SpecPort{{Port: "80", Protocol: "TCP"}}
+	knoxPolicy := types.KnoxNetworkPolicy{Metadata: map[string]string{"name": "web", "namespace": "shop"}}
+	knoxPolicy.Spec.Selector.MatchLabels = map[string]string{"app": "web"}
+	knoxPolicy.Spec.Ingress = []types.Ingress{
+		{MatchLabels: map[string]string{"app": "client"}, ToPorts: toPorts, RuleStats: types.RuleStats{Stale: true, Pruned: true}},
+	}
+	knoxPolicy.Spec.Egress = []types.Egress{
+		{MatchLabels: map[string]string{"app": "db"}, ToPorts: toPorts, RuleStats: types.RuleStats{Stale: true, Pruned: true}},
+	}
+
+	// all the rules pruned, the traffic is neither allowed nor denied
+	ciliumPolicy := ConvertKnoxNetworkPolicyToCiliumPolicy(knoxPolicy)
+	if len(ciliumPolicy.Spec.Ingress) != 0 || len(ciliumPolicy.Spec.Egress) != 0 {
+		t.Errorf("unexpected cilium policy %v", ciliumPolicy)
+	}
+
+	if k8sPolicies := ConvertKnoxNetPolicyToK8sNetworkPolicy("", "", []types.KnoxNetworkPolicy{knoxPolicy}); len(k8sPolicies) != 0 {
+		t.Errorf("unexpected k8s policies %v", k8sPolicies)
+	}
+}
+
+func TestConvertKafkaRules(t *testing.T) {
+	kafkaRules := []types.SpecKafka{{Role: "produce", Topic: "orders"}, {APIKey: "metadata"}}
+
+	portLists := []types.CiliumPortList{{
+		Ports: []types.CiliumPort{{Port: "9092", Protocol: "TCP"}},
+		Rules: map[string][]types.SubRule{"kafka": convertKnoxKafkaRules(kafkaRules)},
+	}}
+
+	expected := []types.SubRule{{"role": "produce", "topic": "orders"}, {"apiKey": "metadata"}}
+	if !cmp.Equal(expected, portLists[0].Rules["kafka"]) {
+		t.Errorf("unexpected cilium kafka rules %v", portLists[0].Rules["kafka"])
+	}
+
+	_, _, actual := convertCiliumPorts(portLists)
+	if !cmp.Equal(kafkaRules, actual) {
+		t.Errorf("they should be equal %v %v", kafkaRules, actual)
+	}
+}
+
+func TestConvertHTTPHostHeaders(t *testing.T) {
+	httpRules := []types.SpecHTTP{
+		{Method: "GET", Path: "/cart", Host: "shop.example.com", Headers: []string{"x-api-version: 2"}},
+		{Method: "GET", Path: "/health"},
+	}
+
+	subRules := []types.SubRule{}
+	for _, http := range httpRules {
+		subRules = append(subRules, convertKnoxHTTPRule(http))
+	}
+
+	// the sub rules are read back from the yaml/json
+	subRuleBytes, _ := json.Marshal(subRules)
+	portLists := []types.CiliumPortList{{Ports: []types.CiliumPort{{Port: "8080", Protocol: "TCP"}}}}
+	if err := json.Unmarshal(subRuleBytes, &subRules); err != nil {
+		t.Fatal(err)
+	}
+	portLists[0].Rules = map[string][]types.SubRule{"http": subRules}
+
+	_, actual, _ := convertCiliumPorts(portLists)
+	if !cmp.Equal(httpRules, actual) {
+		t.Errorf("they should be equal %v %v", httpRules, actual)
+	}
+}
+
+func TestGetHTTPHostHeaders(t *testing.T) {
+	ciliumFlow := &flow.Flow{L7: &flow.Layer7{Record: &flow.Layer7_Http{Http: &flow.HTTP{
+		Url:     "http://shop.example.com:8080/cart",
+		Headers: []*flow.HTTPHeader{{Key: "X-Api-Version", Value: "2"}},
+	}}}}
+
+	config.CurrentCfg.ConfigNetPolicy.HTTPHeaderAllowlist = []string{"x-api-version"}
+	defer func() {
+		config.CurrentCfg.ConfigNetPolicy.HTTPHeaderAllowlist = nil
+		config.CurrentCfg.ConfigNetPolicy.HTTPHost = false
+	}()
+
+	// the host is not captured by default
+	host, headers := getHTTPHostHeaders(ciliumFlow)
+	if host != "" || !cmp.Equal([]string{"x-api-version: 2"}, headers) {
+		t.Errorf("unexpected host %v headers %v", host, headers)
+	}
+
+	// the port is stripped
+	config.CurrentCfg.ConfigNetPolicy.HTTPHost = true
+	if host, _ = getHTTPHostHeaders(ciliumFlow); host != "shop.example.com" {
+		t.Errorf("unexpected host %v", host)
+	}
+
+	ciliumFlow.L7.GetHttp().Headers = append(ciliumFlow.L7.GetHttp().Headers, &flow.HTTPHeader{Key: ":authority", Value: "[::1]:8443"})
+	if host, _ = getHTTPHostHeaders(ciliumFlow); host != "::1" {
+		t.Errorf("unexpected host %v", host)
+	}
+}
+
+func TestConvertPortRange(t *testing.T) {
+	knoxPolicy := types.KnoxNetworkPolicy{Metadata: map[string]string{"name": "ftp", "namespace": "default"}}
+	knoxPolicy.Spec.Egress = []types.Egress{{
+		MatchLabels: map[string]string{"app": "ftp"},
+		ToPorts:     []types.SpecPort{{Port: "30000", Protocol: "TCP", EndPort: 30100}},
+	}}
+
+	ciliumPolicy := ConvertKnoxNetworkPolicyToCiliumPolicy(knoxPolicy)
+	expected := []types.CiliumPort{{Port: "30000", EndPort: 30100, Protocol: "TCP"}}
+	if !cmp.Equal(expected, ciliumPolicy.Spec.Egress[0].ToPorts[0].Ports) {
+		t.Errorf("unexpected cilium ports %v", ciliumPolicy.Spec.Egress[0].ToPorts[0].Ports)
+	}
+
+	actual, _, _ := convertCiliumPorts(ciliumPolicy.Spec.Egress[0].ToPorts)
+	if !cmp.Equal(knoxPolicy.Spec.Egress[0].ToPorts, actual) {
+		t.Errorf("they should be equal %v %v", knoxPolicy.Spec.Egress[0].ToPorts, actual)
+	}
+
+	k8sPolicies := ConvertKnoxNetPolicyToK8sNetworkPolicy("default", "default", []types.KnoxNetworkPolicy{knoxPolicy})
+	if endPort := k8sPolicies[0].Spec.Egress[0].Ports[0].EndPort; endPort == nil || *endPort != 30100 {
+		t.Errorf("unexpected k8s endPort %v", endPort)
+	}
+}
+
+func TestConvertClusterwidePolicy(t *testing.T) {
+	knoxPolicy := types.KnoxNetworkPolicy{
+		Kind:     types.KindKnoxClusterwideNetworkPolicy,
+		Metadata: map[string]string{"name": "monitoring", "namespace": "", "type": "egress"},
+	}
+	knoxPolicy.Spec.Selector.MatchLabels = map[string]string{"app.kubernetes.io/part-of": "shop"}
+	knoxPolicy.Spec.Egress = []types.Egress{{
+		MatchLabels: map[string]string{"app": "prometheus", "io.kubernetes.pod.namespace": "monitoring"},
+		ToPorts:     []types.SpecPort{{Port: "9090", Protocol: "TCP"}},
+	}}
+
+	ciliumPolicy := ConvertKnoxNetworkPolicyToCiliumPolicy(knoxPolicy)
+	if ciliumPolicy.Kind != types.KindCiliumClusterwideNetworkPolicy {
+		t.Errorf("unexpected kind %s", ciliumPolicy.Kind)
+	}
+	if _, ok := ciliumPolicy.Metadata["namespace"]; ok {
+		t.Errorf("the clusterwide policy should not have the namespace %v", ciliumPolicy.Metadata)
+	}
+	if !cmp.Equal(knoxPolicy.Spec.Selector.MatchLabels, ciliumPolicy.Spec.EndpointSelector.MatchLabels) ||
+		len(ciliumPolicy.Spec.NodeSelector.MatchLabels) > 0 {
+		t.Errorf("unexpected selectors %v", ciliumPolicy.Spec)
+	}
+
+	results, err := ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy)
+	if err != nil || len(results) != 1 || results[0].Kind != types.KindKnoxClusterwideNetworkPolicy ||
+		!cmp.Equal(knoxPolicy.Spec.Selector.MatchLabels, results[0].Spec.Selector.MatchLabels) {
+		t.Errorf("unexpected knox policies %v", results)
+	}
+
+	// the host policy selects the nodes
+	knoxPolicy.Kind = types.KindKnoxHostNetworkPolicy
+	knoxPolicy.Spec.Selector.MatchLabels = map[string]string{"kubernetes.io/hostname": "node-1"}
+
+	ciliumPolicy = ConvertKnoxNetworkPolicyToCiliumPolicy(knoxPolicy)
+	if ciliumPolicy.Kind != types.KindCiliumClusterwideNetworkPolicy ||
+		!cmp.Equal(knoxPolicy.Spec.Selector.MatchLabels, ciliumPolicy.Spec.NodeSelector.MatchLabels) {
+		t.Errorf("unexpected host policy %v", ciliumPolicy)
+	}
+
+	results, err = ConvertCiliumPolicyToKnoxNetworkPolicies(ciliumPolicy)
+	if err != nil || len(results) != 1 || results[0].Kind != types.KindKnoxHostNetworkPolicy {
+		t.Errorf("unexpected knox policies %v", results)
+	}
+
+	// neither is expressed by the k8s network policy
+	if k8sPolicies := ConvertKnoxNetPolicyToK8sNetworkPolicy("", "", []types.KnoxNetworkPolicy{knoxPolicy}); len(k8sPolicies) != 0 {
+		t.Errorf("unexpected k8s policies %v", k8sPolicies)
+	}
+}
@@ -1,8 +1,11 @@
 package plugin
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/accuknox/auto-policy-discovery/src/libs"
 	"github.com/accuknox/auto-policy-discovery/src/types"
 	v1 "k8s.io/api/core/v1"
 	nv1 "k8s.io/api/networking/v1"
@@ -10,6 +13,120 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// k8sNetworkPolicyPorts converts the L4 rules, the ports without both the
+// port number and the protocol are skipped
+func k8sNetworkPolicyPorts(toPorts []types.SpecPort) []nv1.NetworkPolicyPort {
+	ports := []nv1.NetworkPolicyPort{}
+
+	for _, toPort := range toPorts {
+		port := nv1.NetworkPolicyPort{}
+		var protocol v1.Protocol
+
+		if toPort.Protocol == string(v1.ProtocolTCP) {
+			protocol = v1.ProtocolTCP
+		} else if toPort.Protocol == string(v1.ProtocolUDP) {
+			protocol = v1.ProtocolUDP
+		}
+
+		portVal, _ := strconv.ParseInt(toPort.Port, 10, 32)
+
+		if portVal != 0 {
+			port = nv1.NetworkPolicyPort{
+				Port: &intstr.IntOrString{
+					Type:   intstr.Int,
+					IntVal: int32(portVal),
+				},
+			}
+
+			// port range
+			if toPort.EndPort > int(portVal) {
+				endPort := int32(toPort.EndPort)
+				port.EndPort = &endPort
+			}
+		}
+
+		if protocol != "" {
+			port.Protocol = &protocol
+		}
+
+		if portVal == 0 && protocol == "" {
+			continue
+		}
+
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+// k8sNetworkPolicyPeer converts the labels to the pod selector, the cilium
+// namespace label is converted to the namespace selector
+func k8sNetworkPolicyPeer(matchLabels map[string]string) nv1.NetworkPolicyPeer {
+	peer := nv1.NetworkPolicyPeer{}
+	podLabels := map[string]string{}
+
+	for k, v := range matchLabels {
+		if k == types.CiliumNamespaceLabel {
+			peer.NamespaceSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{types.K8sNamespaceNameLabel: v},
+			}
+			continue
+		}
+		podLabels[k] = v
+	}
+
+	if len(podLabels) > 0 || peer.NamespaceSelector == nil {
+		peer.PodSelector = &metav1.LabelSelector{
+			MatchLabels: podLabels,
+		}
+	}
+
+	return peer
+}
+
+// k8sNetworkPolicyIPBlocks converts the cidr rules to the ipBlock peers, both
+// the ipv4 and the ipv6 cidrs are kept as they are
+func k8sNetworkPolicyIPBlocks(cidrs []types.SpecCIDR) []nv1.NetworkPolicyPeer {
+	peers := []nv1.NetworkPolicyPeer{}
+
+	for _, cidr := range cidrs {
+		for _, addr := range cidr.CIDRs {
+			ipBlock := &nv1.IPBlock{CIDR: addr}
+			for _, except := range cidr.Except {
+				// the except cidrs should be the same ip family
+				if libs.IsIPv6(strings.Split(except, "/")[0]) == libs.IsIPv6(strings.Split(addr, "/")[0]) {
+					ipBlock.Except = append(ipBlock.Except, except)
+				}
+			}
+			peers = append(peers, nv1.NetworkPolicyPeer{IPBlock: ipBlock})
+		}
+	}
+
+	return peers
+}
+
+// isServiceEgress checks if the peer of the egress rule is only the service or the
+// domain of the service, which the k8s network policy cannot express
+func isServiceEgress(egress types.Egress) bool {
+	if len(egress.MatchLabels) > 0 || len(egress.ToCIDRs) > 0 || len(egress.ToEntities) > 0 {
+		return false
+	}
+
+	if len(egress.ToServices) > 0 {
+		return true
+	}
+
+	for _, fqdn := range egress.ToFQDNs {
+		for _, name := range fqdn.Names() {
+			if !libs.IsClusterDomain(name) {
+				return false
+			}
+		}
+	}
+
+	return len(egress.ToFQDNs) > 0
+}
+
 func ConvertKnoxNetPolicyToK8sNetworkPolicy(clustername, namespace string, knoxNetPolicies []types.KnoxNetworkPolicy) []nv1.NetworkPolicy {
 
 	log.Info().Msgf("No. of knox network policies - %d", len(knoxNetPolicies))
@@ -21,6 +138,11 @@ func ConvertKnoxNetPolicyToK8sNetworkPolicy(clustername, namespace string, knoxN
 	res := []nv1.NetworkPolicy{}
 
 	for _, knp := range knoxNetPolicies {
+		// the host and the clusterwide policies have no k8s equivalent
+		if knp.Kind == types.KindKnoxHostNetworkPolicy || knp.Kind == types.KindKnoxClusterwideNetworkPolicy {
+			continue
+		}
+
 		k8NetPol := nv1.NetworkPolicy{}
 
 		k8NetPol.APIVersion = types.K8sNwPolicyAPIVersion
@@ -31,102 +153,85 @@ func ConvertKnoxNetPolicyToK8sNetworkPolicy(clustername, namespace string, knoxN
 			MatchLabels: knp.Spec.Selector.MatchLabels,
 		}
 
+		// no rules for both directions, all the traffic is denied
+		if knp.Metadata["rule"] == types.NetworkRuleDefaultDeny {
+			k8NetPol.Spec.PolicyTypes = []nv1.PolicyType{nv1.PolicyTypeIngress, nv1.PolicyTypeEgress}
+			res = append(res, k8NetPol)
+			continue
+		}
+
 		if len(knp.Spec.Egress) > 0 {
+			enforced := false
+
 			for _, eg := range knp.Spec.Egress {
-				var egressRule nv1.NetworkPolicyEgressRule
-				port := nv1.NetworkPolicyPort{}
-				to := nv1.NetworkPolicyPeer{}
-				var protocol v1.Protocol
-
-				if eg.ToPorts[0].Protocol == string(v1.ProtocolTCP) {
-					protocol = v1.ProtocolTCP
-				} else if eg.ToPorts[0].Protocol == string(v1.ProtocolUDP) {
-					protocol = v1.ProtocolUDP
+				// stale rule dropped from the policy
+				if eg.Pruned {
+					continue
 				}
 
-				portVal, _ := strconv.ParseInt(eg.ToPorts[0].Port, 10, 32)
-
-				if portVal != 0 {
-					port = nv1.NetworkPolicyPort{
-						Port: &intstr.IntOrString{
-							Type:   intstr.Int,
-							IntVal: int32(portVal),
-						},
-					}
+				// the rule without the peer would allow the ports to any destination,
+				// the endpoint rule of the both strategy is kept
+				if isServiceEgress(eg) {
+					continue
 				}
+				enforced = true
 
-				if protocol != "" {
-					port.Protocol = &protocol
-				}
+				var egressRule nv1.NetworkPolicyEgressRule
 
-				if len(eg.MatchLabels) > 0 {
-					to = nv1.NetworkPolicyPeer{
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: eg.MatchLabels,
-						},
-					}
-					egressRule.To = append(egressRule.To, to)
-				} else {
-					egressRule.To = nil
+				egressRule.Ports = k8sNetworkPolicyPorts(eg.ToPorts)
+				if len(egressRule.Ports) == 0 {
+					continue
 				}
 
-				if portVal == 0 && protocol == "" {
-					continue
+				if len(eg.MatchLabels) > 0 {
+					egressRule.To = append(egressRule.To, k8sNetworkPolicyPeer(eg.MatchLabels))
+				} else if len(eg.ToCIDRs) > 0 {
+					egressRule.To = append(egressRule.To, k8sNetworkPolicyIPBlocks(eg.ToCIDRs)...)
 				}
 
-				egressRule.Ports = append(egressRule.Ports, port)
 				k8NetPol.Spec.Egress = append(k8NetPol.Spec.Egress, egressRule)
 			}
-			k8NetPol.Spec.PolicyTypes = append(k8NetPol.Spec.PolicyTypes, nv1.PolicyType(nv1.PolicyTypeEgress))
+
+			// only the service or the pruned rules, not enforced rather than denying all the egress
+			if enforced {
+				k8NetPol.Spec.PolicyTypes = append(k8NetPol.Spec.PolicyTypes, nv1.PolicyType(nv1.PolicyTypeEgress))
+			}
 		}
 
 		if len(knp.Spec.Ingress) > 0 {
+			enforced := false
+
 			for _, ing := range knp.Spec.Ingress {
-				var ingressRule nv1.NetworkPolicyIngressRule
-				port := nv1.NetworkPolicyPort{}
-				var protocol v1.Protocol
-				from := nv1.NetworkPolicyPeer{}
-
-				if ing.ToPorts[0].Protocol == string(v1.ProtocolTCP) {
-					protocol = v1.ProtocolTCP
-				} else if ing.ToPorts[0].Protocol == string(v1.ProtocolUDP) {
-					protocol = v1.ProtocolUDP
+				// stale rule dropped from the policy
+				if ing.Pruned {
+					continue
 				}
+				enforced = true
 
-				portVal, _ := strconv.ParseInt(ing.ToPorts[0].Port, 10, 32)
-
-				if portVal != 0 {
-					port = nv1.NetworkPolicyPort{
-						Port: &intstr.IntOrString{
-							Type:   intstr.Int,
-							IntVal: int32(portVal),
-						},
-					}
-				}
+				var ingressRule nv1.NetworkPolicyIngressRule
 
-				if protocol != "" {
-					port.Protocol = &protocol
+				ingressRule.Ports = k8sNetworkPolicyPorts(ing.ToPorts)
+				if len(ingressRule.Ports) == 0 {
+					continue
 				}
 
 				if len(ing.MatchLabels) > 0 {
-					from = nv1.NetworkPolicyPeer{
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: ing.MatchLabels,
-						},
-					}
-					ingressRule.From = append(ingressRule.From, from)
-				} else {
-					ingressRule.From = nil
-				}
-
-				if portVal == 0 && protocol == "" {
-					continue
+					ingressRule.From = append(ingressRule.From, k8sNetworkPolicyPeer(ing.MatchLabels))
+				} else if len(ing.FromCIDRs) > 0 {
+					ingressRule.From = append(ingressRule.From, k8sNetworkPolicyIPBlocks(ing.FromCIDRs)...)
 				}
 
-				ingressRule.Ports = append(ingressRule.Ports, port)
 				k8NetPol.Spec.Ingress = append(k8NetPol.Spec.Ingress, ingressRule)
 			}
-			k8NetPol.Spec.PolicyTypes = append(k8NetPol.Spec.PolicyTypes, nv1.PolicyType(nv1.PolicyTypeIngress))
+
+			// all the rules pruned, not enforced rather than denying all the ingress
+			if enforced {
+				k8NetPol.Spec.PolicyTypes = append(k8NetPol.Spec.PolicyTypes, nv1.PolicyType(nv1.PolicyTypeIngress))
+			}
+		}
+
+		if len(k8NetPol.Spec.PolicyTypes) == 0 {
+			continue
 		}
 
 		res = append(res, k8NetPol)
@@ -134,3 +239,175 @@ func ConvertKnoxNetPolicyToK8sNetworkPolicy(clustername, namespace string, knoxN
 
 	return res
 }
+
+// convertK8sNetworkPolicyPorts converts the ports back into the L4 rules
+func convertK8sNetworkPolicyPorts(ports []nv1.NetworkPolicyPort) []types.SpecPort {
+	var toPorts []types.SpecPort
+
+	for _, port := range ports {
+		toPort := types.SpecPort{}
+		if port.Port != nil {
+			toPort.Port = port.Port.String()
+		}
+		if port.EndPort != nil {
+			toPort.EndPort = int(*port.EndPort)
+		}
+		if port.Protocol != nil {
+			toPort.Protocol = string(*port.Protocol)
+		}
+		toPorts = append(toPorts, toPort)
+	}
+
+	return toPorts
+}
+
+// convertK8sLabelSelector converts the label selector back into the labels, the
+// match expressions are converted only if they select a single label value, since
+// the knox policies have no other representation for them
+func convertK8sLabelSelector(selector *metav1.LabelSelector) (map[string]string, error) {
+	matchLabels := map[string]string{}
+
+	for k, v := range selector.MatchLabels {
+		matchLabels[k] = v
+	}
+
+	for _, expr := range selector.MatchExpressions {
+		if expr.Operator != metav1.LabelSelectorOpIn || len(expr.Values) != 1 {
+			return nil, fmt.Errorf("unsupported match expression %s %s %v", expr.Key, expr.Operator, expr.Values)
+		}
+		if v, ok := matchLabels[expr.Key]; ok && v != expr.Values[0] {
+			return nil, fmt.Errorf("conflicting match expression %s %s %v", expr.Key, expr.Operator, expr.Values)
+		}
+		matchLabels[expr.Key] = expr.Values[0]
+	}
+
+	return matchLabels, nil
+}
+
+// convertK8sNetworkPolicyPeer converts the peer back into the labels, the
+// namespace selector is converted to the cilium namespace label
+func convertK8sNetworkPolicyPeer(peer nv1.NetworkPolicyPeer) (map[string]string, error) {
+	matchLabels := map[string]string{}
+
+	if peer.PodSelector != nil {
+		podLabels, err := convertK8sLabelSelector(peer.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range podLabels {
+			matchLabels[k] = v
+		}
+	}
+
+	if peer.NamespaceSelector != nil {
+		nsLabels, err := convertK8sLabelSelector(peer.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		if ns, ok := nsLabels[types.K8sNamespaceNameLabel]; ok {
+			matchLabels[types.CiliumNamespaceLabel] = ns
+		}
+	}
+
+	return matchLabels, nil
+}
+
+func convertK8sNetworkPolicyIPBlock(ipBlock *nv1.IPBlock) []types.SpecCIDR {
+	return []types.SpecCIDR{{CIDRs: []string{ipBlock.CIDR}, Except: ipBlock.Except}}
+}
+
+func buildKnoxPolicyFromK8sNetworkPolicy(k8NetPol nv1.NetworkPolicy, polType string) (types.KnoxNetworkPolicy, error) {
+	policy := types.KnoxNetworkPolicy{
+		APIVersion: "v1",
+		Kind:       types.KindKnoxNetworkPolicy,
+		Metadata: map[string]string{
+			"name":      k8NetPol.Name,
+			"namespace": k8NetPol.Namespace,
+			"type":      polType,
+			"status":    types.PolicyStatusImported,
+		},
+		Spec: types.Spec{
+			Action: "allow",
+		},
+	}
+
+	selector, err := convertK8sLabelSelector(&k8NetPol.Spec.PodSelector)
+	if err != nil {
+		return policy, err
+	}
+	policy.Spec.Selector.MatchLabels = selector
+
+	return policy, nil
+}
+
+// ConvertK8sNetworkPolicyToKnoxNetworkPolicies converts the k8s network policy back
+// into the knox policies, the ingress and the egress rules are separated into each policy
+func ConvertK8sNetworkPolicyToKnoxNetworkPolicies(k8NetPol nv1.NetworkPolicy) ([]types.KnoxNetworkPolicy, error) {
+	results := []types.KnoxNetworkPolicy{}
+
+	if len(k8NetPol.Spec.Egress) > 0 {
+		policy, err := buildKnoxPolicyFromK8sNetworkPolicy(k8NetPol, "egress")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, egressRule := range k8NetPol.Spec.Egress {
+			toPorts := convertK8sNetworkPolicyPorts(egressRule.Ports)
+
+			if len(egressRule.To) == 0 {
+				policy.Spec.Egress = append(policy.Spec.Egress, types.Egress{ToPorts: toPorts})
+				continue
+			}
+
+			for _, peer := range egressRule.To {
+				egress := types.Egress{ToPorts: toPorts}
+				if peer.IPBlock != nil {
+					egress.ToCIDRs = convertK8sNetworkPolicyIPBlock(peer.IPBlock)
+				} else {
+					matchLabels, err := convertK8sNetworkPolicyPeer(peer)
+					if err != nil {
+						return nil, err
+					}
+					egress.MatchLabels = matchLabels
+				}
+				policy.Spec.Egress = append(policy.Spec.Egress, egress)
+			}
+		}
+
+		results = append(results, policy)
+	}
+
+	if len(k8NetPol.Spec.Ingress) > 0 {
+		policy, err := buildKnoxPolicyFromK8sNetworkPolicy(k8NetPol, "ingress")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ingressRule := range k8NetPol.Spec.Ingress {
+			toPorts := convertK8sNetworkPolicyPorts(ingressRule.Ports)
+
+			if len(ingressRule.From) == 0 {
+				policy.Spec.Ingress = append(policy.Spec.Ingress, types.Ingress{ToPorts: toPorts})
+				continue
+			}
+
+			for _, peer := range ingressRule.From {
+				ingress := types.Ingress{ToPorts: toPorts}
+				if peer.IPBlock != nil {
+					ingress.FromCIDRs = convertK8sNetworkPolicyIPBlock(peer.IPBlock)
+				} else {
+					matchLabels, err := convertK8sNetworkPolicyPeer(peer)
+					if err != nil {
+						return nil, err
+					}
+					ingress.MatchLabels = matchLabels
+				}
+				policy.Spec.Ingress = append(policy.Spec.Ingress, ingress)
+			}
+		}
+
+		results = append(results, policy)
+	}
+
+	return results, nil
+}
@@ -38,6 +38,36 @@ func generateProcessPaths(fromSrc []types.KnoxFromSource) []string {
 	return processpaths
 }
 
+// pruneKnoxSys drops the stale rules pruned from the policy, and clears the rule
+// statistics which are not part of the KubeArmor policy
+func pruneKnoxSys(sys types.KnoxSys) types.KnoxSys {
+	res := types.KnoxSys{}
+
+	for _, mp := range sys.MatchPaths {
+		if mp.Pruned {
+			continue
+		}
+		mp.RuleStats = types.RuleStats{}
+		res.MatchPaths = append(res.MatchPaths, mp)
+	}
+	for _, md := range sys.MatchDirectories {
+		if md.Pruned {
+			continue
+		}
+		md.RuleStats = types.RuleStats{}
+		res.MatchDirectories = append(res.MatchDirectories, md)
+	}
+	for _, mp := range sys.MatchPatterns {
+		if mp.Pruned {
+			continue
+		}
+		mp.RuleStats = types.RuleStats{}
+		res.MatchPatterns = append(res.MatchPatterns, mp)
+	}
+
+	return res
+}
+
 func ConvertKnoxSystemPolicyToKubeArmorPolicy(knoxPolicies []types.KnoxSystemPolicy) []types.KubeArmorPolicy {
 	results := []types.KubeArmorPolicy{}
 
@@ -63,13 +93,21 @@ func ConvertKnoxSystemPolicyToKubeArmorPolicy(knoxPolicies []types.KnoxSystemPol
 		}
 
 		kubePolicy.Spec = policy.Spec
+		kubePolicy.Spec.Process = pruneKnoxSys(policy.Spec.Process)
+		kubePolicy.Spec.File = pruneKnoxSys(policy.Spec.File)
+
+		if kubePolicy.Kind == types.KindKubeArmorHostPolicy {
+			// host policies select the nodes instead of the pods
+			kubePolicy.Spec.NodeSelector = policy.Spec.Selector
+			kubePolicy.Spec.Selector = types.Selector{}
+		}
 
 		if kubePolicy.Kind == types.KindKubeArmorPolicy && policy.Spec.Action == "Allow" {
 			dirRule := types.KnoxMatchDirectories{
 				Dir:       types.PreConfiguredKubearmorRule,
 				Recursive: true,
 			}
-			kubePolicy.Spec.File.MatchDirectories = append(policy.Spec.File.MatchDirectories, dirRule)
+			kubePolicy.Spec.File.MatchDirectories = append(kubePolicy.Spec.File.MatchDirectories, dirRule)
 		}
 
 		for _, procpath := range kubePolicy.Spec.Process.MatchPaths {
@@ -113,6 +151,106 @@ func ConvertKnoxSystemPolicyToKubeArmorPolicy(knoxPolicies []types.KnoxSystemPol
 	return results
 }
 
+// knoxSysFromSource returns the first source path of the rules
+func knoxSysFromSource(sys types.KnoxSys) string {
+	for _, mp := range sys.MatchPaths {
+		if len(mp.FromSource) > 0 {
+			return mp.FromSource[0].Path
+		}
+	}
+	for _, md := range sys.MatchDirectories {
+		if len(md.FromSource) > 0 {
+			return md.FromSource[0].Path
+		}
+	}
+	return ""
+}
+
+func isEmptyKnoxSys(sys types.KnoxSys) bool {
+	return len(sys.MatchPaths) == 0 && len(sys.MatchDirectories) == 0 && len(sys.MatchPatterns) == 0
+}
+
+// ConvertKubeArmorPolicyToKnoxSystemPolicies converts the KubeArmor policy back into
+// the knox policies, the process, file and network rules are separated into each policy
+func ConvertKubeArmorPolicyToKnoxSystemPolicies(kubePolicy types.KubeArmorPolicy) []types.KnoxSystemPolicy {
+	results := []types.KnoxSystemPolicy{}
+
+	spec := kubePolicy.Spec
+
+	// the directory added along with the allow policy
+	fileDirs := []types.KnoxMatchDirectories{}
+	for _, md := range spec.File.MatchDirectories {
+		if md.Dir != types.PreConfiguredKubearmorRule {
+			fileDirs = append(fileDirs, md)
+		}
+	}
+	spec.File.MatchDirectories = fileDirs
+
+	buildPolicy := func(opType string) types.KnoxSystemPolicy {
+		policy := types.KnoxSystemPolicy{
+			APIVersion: "v1",
+			Kind:       "KnoxSystemPolicy",
+			Metadata: map[string]string{
+				"name":      kubePolicy.Metadata["name"],
+				"namespace": kubePolicy.Metadata["namespace"],
+				"type":      opType,
+				"status":    "latest",
+			},
+			Spec: types.KnoxSystemSpec{
+				Severity: spec.Severity,
+				Tags:     spec.Tags,
+				Message:  spec.Message,
+				Selector: spec.Selector,
+				Action:   spec.Action,
+			},
+		}
+
+		if kubePolicy.Kind == types.KindKubeArmorHostPolicy {
+			// host policies are discovered in the vm namespace
+			policy.Metadata["namespace"] = types.PolicyDiscoveryVMNamespace
+			policy.Spec.Selector = spec.NodeSelector
+		}
+
+		if policy.Spec.Selector.MatchLabels == nil {
+			policy.Spec.Selector.MatchLabels = map[string]string{}
+		}
+
+		return policy
+	}
+
+	if !isEmptyKnoxSys(spec.Process) {
+		policy := buildPolicy("Process")
+		policy.Spec.Process = spec.Process
+		if src := knoxSysFromSource(spec.Process); src != "" {
+			policy.Metadata["fromSource"] = src
+		}
+		results = append(results, policy)
+	}
+
+	if !isEmptyKnoxSys(spec.File) {
+		policy := buildPolicy("File")
+		policy.Spec.File = spec.File
+		if src := knoxSysFromSource(spec.File); src != "" {
+			policy.Metadata["fromSource"] = src
+		}
+		results = append(results, policy)
+	}
+
+	if len(spec.Network.MatchProtocols) > 0 {
+		policy := buildPolicy("Network")
+		policy.Spec.Network = spec.Network
+		for _, mp := range spec.Network.MatchProtocols {
+			if len(mp.FromSource) > 0 {
+				policy.Metadata["fromSource"] = mp.FromSource[0].Path
+				break
+			}
+		}
+		results = append(results, policy)
+	}
+
+	return results
+}
+
 func ConvertSQLiteKubeArmorLogsToKnoxSystemLogs(docs []map[string]interface{}) []types.KnoxSystemLog {
 	results := []types.KnoxSystemLog{}
 
@@ -147,19 +285,25 @@ func ConvertSQLiteKubeArmorLogsToKnoxSystemLogs(docs []map[string]interface{}) [
 		}
 
 		knoxSysLog := types.KnoxSystemLog{
-			ClusterName:    syslog.ClusterName,
-			HostName:       syslog.HostName,
-			Namespace:      syslog.NamespaceName,
-			ContainerName:  syslog.ContainerName,
-			PodName:        syslog.PodName,
-			Source:         source,
-			SourceOrigin:   syslog.Source,
-			Operation:      syslog.Operation,
-			ResourceOrigin: syslog.Resource,
-			Resource:       resource,
-			Data:           syslog.Data,
-			ReadOnly:       readOnly,
-			Result:         syslog.Result,
+			ClusterName:       syslog.ClusterName,
+			HostName:          syslog.HostName,
+			Namespace:         syslog.NamespaceName,
+			ContainerName:     syslog.ContainerName,
+			PodName:           syslog.PodName,
+			Source:            source,
+			SourceOrigin:      syslog.Source,
+			HostPPID:          int32(syslog.HostPPID),
+			HostPID:           int32(syslog.HostPID),
+			PPID:              int32(syslog.PPID),
+			PID:               int32(syslog.PID),
+			ParentProcessName: syslog.ParentProcessName,
+			ProcessName:       syslog.ProcessName,
+			Operation:         syslog.Operation,
+			ResourceOrigin:    syslog.Resource,
+			Resource:          resource,
+			Data:              syslog.Data,
+			ReadOnly:          readOnly,
+			Result:            syslog.Result,
 		}
 
 		results = append(results, knoxSysLog)
@@ -202,19 +346,25 @@ func ConvertMySQLKubeArmorLogsToKnoxSystemLogs(docs []map[string]interface{}) []
 		}
 
 		knoxSysLog := types.KnoxSystemLog{
-			ClusterName:    syslog.ClusterName,
-			HostName:       syslog.HostName,
-			Namespace:      syslog.NamespaceName,
-			ContainerName:  syslog.ContainerName,
-			PodName:        syslog.PodName,
-			Source:         source,
-			SourceOrigin:   syslog.Source,
-			Operation:      syslog.Operation,
-			ResourceOrigin: syslog.Resource,
-			Resource:       resource,
-			Data:           syslog.Data,
-			ReadOnly:       readOnly,
-			Result:         syslog.Result,
+			ClusterName:       syslog.ClusterName,
+			HostName:          syslog.HostName,
+			Namespace:         syslog.NamespaceName,
+			ContainerName:     syslog.ContainerName,
+			PodName:           syslog.PodName,
+			Source:            source,
+			SourceOrigin:      syslog.Source,
+			HostPPID:          int32(syslog.HostPPID),
+			HostPID:           int32(syslog.HostPID),
+			PPID:              int32(syslog.PPID),
+			PID:               int32(syslog.PID),
+			ParentProcessName: syslog.ParentProcessName,
+			ProcessName:       syslog.ProcessName,
+			Operation:         syslog.Operation,
+			ResourceOrigin:    syslog.Resource,
+			Resource:          resource,
+			Data:              syslog.Data,
+			ReadOnly:          readOnly,
+			Result:            syslog.Result,
 		}
 
 		results = append(results, knoxSysLog)
@@ -277,19 +427,25 @@ func ConvertKubeArmorLogToKnoxSystemLog(relayLog *pb.Alert) (types.KnoxSystemLog
 	}
 
 	knoxSystemLog := types.KnoxSystemLog{
-		ClusterName:    relayLog.ClusterName,
-		HostName:       relayLog.HostName,
-		Namespace:      relayLog.NamespaceName,
-		ContainerName:  relayLog.ContainerName,
-		PodName:        relayLog.PodName,
-		Source:         source,
-		SourceOrigin:   relayLog.Source,
-		Operation:      relayLog.Operation,
-		ResourceOrigin: relayLog.Resource,
-		Resource:       resource,
-		Data:           relayLog.Data,
-		ReadOnly:       readOnly,
-		Result:         relayLog.Result,
+		ClusterName:       relayLog.ClusterName,
+		HostName:          relayLog.HostName,
+		Namespace:         relayLog.NamespaceName,
+		ContainerName:     relayLog.ContainerName,
+		PodName:           relayLog.PodName,
+		Source:            source,
+		SourceOrigin:      relayLog.Source,
+		HostPPID:          relayLog.HostPPID,
+		HostPID:           relayLog.HostPID,
+		PPID:              relayLog.PPID,
+		PID:               relayLog.PID,
+		ParentProcessName: relayLog.ParentProcessName,
+		ProcessName:       relayLog.ProcessName,
+		Operation:         relayLog.Operation,
+		ResourceOrigin:    relayLog.Resource,
+		Resource:          resource,
+		Data:              relayLog.Data,
+		ReadOnly:          readOnly,
+		Result:            relayLog.Result,
 	}
 
 	if relayLog.Type == "HostLog" {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	types "github.com/accuknox/auto-policy-discovery/src/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,6 +19,7 @@ func TestConvertMySQLKubeArmorLogsToKnoxSystemLogs(t *testing.T) {
 		"Operation":"File",
 		"PID":1385017,
 		"PPID":1373459,
+		"ParentProcessName":"/bin/bash",
 		"PodName":"recommendationservice-cb98b57c-6255h",
 		"Resource":"SYS_CLOSE",
 		"Result":"Bad file descriptor",
@@ -35,6 +37,8 @@ func TestConvertMySQLKubeArmorLogsToKnoxSystemLogs(t *testing.T) {
 
 	results := ConvertMySQLKubeArmorLogsToKnoxSystemLogs([]map[string]interface{}{doc})
 	assert.Equal(t, "fd=6", results[0].Data)
+	assert.Equal(t, int32(1373459), results[0].PPID)
+	assert.Equal(t, "/bin/bash", results[0].ParentProcessName)
 }
 
 func TestConvertSQLiteKubeArmorLogsToKnoxSystemLogs(t *testing.T) {
@@ -66,3 +70,72 @@ func TestConvertSQLiteKubeArmorLogsToKnoxSystemLogs(t *testing.T) {
         results := ConvertSQLiteKubeArmorLogsToKnoxSystemLogs([]map[string]interface{}{doc})
         assert.Equal(t, "fd=6", results[0].Data)
 }
+
+func TestConvertKnoxSystemPolicyToKubeArmorHostPolicy(t *testing.T) {
+	policy := types.KnoxSystemPolicy{
+		Kind:     "KnoxSystemPolicy",
+		Metadata: map[string]string{"name": "autopol-system-1", "namespace": types.PolicyDiscoveryVMNamespace},
+		Spec: types.KnoxSystemSpec{
+			Selector: types.Selector{MatchLabels: map[string]string{"kubernetes.io/hostname": "node-1"}},
+			Action:   "Allow",
+		},
+	}
+
+	results := ConvertKnoxSystemPolicyToKubeArmorPolicy([]types.KnoxSystemPolicy{policy})
+
+	assert.Equal(t, types.KindKubeArmorHostPolicy, results[0].Kind)
+	assert.Equal(t, "node-1", results[0].Spec.NodeSelector.MatchLabels["kubernetes.io/hostname"])
+	assert.Empty(t, results[0].Spec.Selector.MatchLabels)
+}
+
+func TestConvertKnoxSystemPolicyToKubeArmorPolicy_Pruned(t *testing.T) {
+	policy := types.KnoxSystemPolicy{
+		Kind:     "KnoxSystemPolicy",
+		Metadata: map[string]string{"name": "autopol-system-web", "namespace": "default"},
+		Spec: types.KnoxSystemSpec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "web"}},
+			File: types.KnoxSys{
+				MatchPaths: []types.KnoxMatchPaths{
+					{Path: "/etc/hosts", RuleStats: types.RuleStats{LastSeen: 100, HitCount: 2}},
+					{Path: "/etc/passwd", RuleStats: types.RuleStats{LastSeen: 50, HitCount: 1, Stale: true, Pruned: true}},
+				},
+			},
+			Action: "Allow",
+		},
+	}
+
+	results := ConvertKnoxSystemPolicyToKubeArmorPolicy([]types.KnoxSystemPolicy{policy})
+
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, []types.KnoxMatchPaths{{Path: "/etc/hosts"}}, results[0].Spec.File.MatchPaths)
+
+	// the rule stats are not part of the KubeArmor policy
+	b, _ := json.Marshal(results[0])
+	assert.NotContains(t, string(b), "hitCount")
+}
+
+func TestConvertKubeArmorPolicyToKnoxSystemPolicies(t *testing.T) {
+	policy := types.KnoxSystemPolicy{
+		Kind:     "KnoxSystemPolicy",
+		Metadata: map[string]string{"name": "autopol-system-web", "namespace": "default"},
+		Spec: types.KnoxSystemSpec{
+			Selector: types.Selector{MatchLabels: map[string]string{"app": "web"}},
+			Process:  types.KnoxSys{MatchPaths: []types.KnoxMatchPaths{{Path: "/bin/sh"}}},
+			File:     types.KnoxSys{MatchPaths: []types.KnoxMatchPaths{{Path: "/etc/hosts"}}},
+			Action:   "Allow",
+		},
+	}
+
+	kubePolicy := ConvertKnoxSystemPolicyToKubeArmorPolicy([]types.KnoxSystemPolicy{policy})[0]
+	results := ConvertKubeArmorPolicyToKnoxSystemPolicies(kubePolicy)
+
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, "Process", results[0].Metadata["type"])
+	assert.Equal(t, []types.KnoxMatchPaths{{Path: "/bin/sh"}}, results[0].Spec.Process.MatchPaths)
+	assert.Equal(t, "File", results[1].Metadata["type"])
+	assert.Equal(t, []types.KnoxMatchPaths{{Path: "/etc/hosts"}}, results[1].Spec.File.MatchPaths)
+
+	// the pre-configured directory is not imported
+	assert.Empty(t, results[1].Spec.File.MatchDirectories)
+	assert.Equal(t, "web", results[1].Spec.Selector.MatchLabels["app"])
+}
@@ -0,0 +1,372 @@
+package policydrift
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/cluster"
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	logger "github.com/accuknox/auto-policy-discovery/src/logging"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/robfig/cron"
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/yaml"
+)
+
+var log *zerolog.Logger
+
+// DriftCronJob for reconciling the applied policies periodically
+var DriftCronJob *cron.Cron
+
+// driftMutex guards the latest reconciliation result
+var driftMutex = &sync.Mutex{}
+var latestDrifts []types.PolicyDrift
+var latestReconciled time.Time
+
+// reconcileMutex serializes the on-demand reconciliations
+var reconcileMutex = &sync.Mutex{}
+
+// clusterScopedKinds are the policy kinds without a namespace
+var clusterScopedKinds = map[string]bool{
+	"KubeArmorHostPolicy":            true,
+	"CiliumClusterwideNetworkPolicy": true,
+}
+
+var policyDriftGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "discovery_engine_policy_drift",
+		Help: "Number of the policies drifted between the discovered and the applied ones",
+	},
+	[]string{"cluster", "namespace", "status"},
+)
+
+func init() {
+	log = logger.GetInstance()
+	prometheus.MustRegister(policyDriftGauge)
+}
+
+// StartDriftDetection starts the periodic reconciliation and the metrics server
+func StartDriftDetection() {
+	if !cfg.GetCfgDriftDetectionEnable() {
+		return
+	}
+
+	go startMetricsServer(cfg.GetCfgDriftDetectionMetricsPort())
+
+	ReconcilePolicies()
+
+	DriftCronJob = cron.New()
+	err := DriftCronJob.AddFunc(cfg.GetCfgDriftDetectionCronJobTime(), ReconcilePolicies)
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return
+	}
+	DriftCronJob.Start()
+
+	log.Info().Msg("Policy drift detection cron job started")
+}
+
+func startMetricsServer(port string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:              ":" + port,
+		ReadHeaderTimeout: 90 * time.Second,
+		ReadTimeout:       90 * time.Second,
+		WriteTimeout:      90 * time.Second,
+		Handler:           mux,
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		log.Error().Msgf("metrics server failed: %s", err.Error())
+	}
+}
+
+// ReconcilePolicies matches the policies applied in the cluster to the discovered ones
+func ReconcilePolicies() {
+	applied, err := cluster.GetAppliedPoliciesFromK8sClient()
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return
+	}
+
+	clusterName := cfg.GetCfgClusterName()
+	discovered := []types.PolicyYaml{}
+	for _, policyType := range []string{types.PolicyTypeNetwork, types.PolicyTypeSystem, types.PolicyTypeAdmissionController} {
+		policies, err := libs.GetPolicyYamls(cfg.GetCfgDB(), policyType, types.PolicyFilter{Cluster: clusterName})
+		if err != nil {
+			log.Error().Msg(err.Error())
+			return
+		}
+		discovered = append(discovered, policies...)
+	}
+
+	drifts := ComputePolicyDrifts(clusterName, discovered, applied)
+
+	driftMutex.Lock()
+	latestDrifts = drifts
+	latestReconciled = time.Now()
+	driftMutex.Unlock()
+
+	updateDriftMetrics(drifts)
+}
+
+// reconcileInterval returns the interval of the reconciliation
+func reconcileInterval() time.Duration {
+	interval, err := time.ParseDuration(strings.TrimPrefix(cfg.GetCfgDriftDetectionCronJobTime(), "@every "))
+	if err != nil {
+		return 0
+	}
+	return interval
+}
+
+// reconcileIfExpired reconciles on demand if the latest result is older than
+// the reconciliation interval
+func reconcileIfExpired() {
+	reconcileMutex.Lock()
+	defer reconcileMutex.Unlock()
+
+	driftMutex.Lock()
+	reconciled := latestReconciled
+	driftMutex.Unlock()
+
+	if !reconciled.IsZero() && time.Since(reconciled) < reconcileInterval() {
+		return
+	}
+
+	ReconcilePolicies()
+}
+
+// GetPolicyDrifts returns the drifted policies of the latest reconciliation;
+// if the periodic reconciliation is disabled, it reconciles on demand once the
+// latest result is older than the reconciliation interval
+func GetPolicyDrifts(namespace, labels string) []types.PolicyDrift {
+	if DriftCronJob == nil {
+		reconcileIfExpired()
+	}
+
+	if labels != "" {
+		labels = libs.WorkloadLabels(strings.Split(labels, ","))
+	}
+
+	driftMutex.Lock()
+	defer driftMutex.Unlock()
+
+	results := []types.PolicyDrift{}
+	for _, drift := range latestDrifts {
+		if namespace != "" && drift.Namespace != namespace {
+			continue
+		}
+		if labels != "" && drift.Labels != labels {
+			continue
+		}
+		results = append(results, drift)
+	}
+
+	return results
+}
+
+func updateDriftMetrics(drifts []types.PolicyDrift) {
+	policyDriftGauge.Reset()
+	for _, drift := range drifts {
+		policyDriftGauge.WithLabelValues(drift.Cluster, drift.Namespace, drift.Status).Inc()
+	}
+}
+
+// ================== //
+// == Policy Drift == //
+// ================== //
+
+func policyKey(kind, namespace, name string) string {
+	if clusterScopedKinds[kind] {
+		namespace = ""
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// policyBaseName strips the numeric suffix appended to the name on the
+// collision, e.g. autopol-egress-web-2
+func policyBaseName(name string) string {
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+func labelMapToString(labels types.LabelMap) string {
+	strs := []string{}
+	for k, v := range labels {
+		strs = append(strs, k+"="+v)
+	}
+	return libs.WorkloadLabels(strs)
+}
+
+// normalizeSpec converts the spec into its generic json representation
+func normalizeSpec(spec interface{}) interface{} {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil
+	}
+
+	var res interface{}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil
+	}
+
+	return res
+}
+
+// specCovers checks if all the fields of the discovered spec are in the applied
+// spec; the fields defaulted by the api server are not regarded as the drift
+func specCovers(applied, discovered interface{}) bool {
+	switch d := discovered.(type) {
+	case map[string]interface{}:
+		a, ok := applied.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range d {
+			if !specCovers(a[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := applied.([]interface{})
+		if !ok || len(a) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !specCovers(a[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(applied, discovered)
+	}
+}
+
+func discoveredSpec(policy types.PolicyYaml) (interface{}, error) {
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal(policy.Yaml, &obj); err != nil {
+		return nil, err
+	}
+
+	return normalizeSpec(obj["spec"]), nil
+}
+
+// matchAppliedPolicy returns the key of the applied policy of the discovered
+// one. The numeric suffixes of the names depend on the order the policies are
+// named in, so the applied policy of the same selector and the same base name
+// is preferred to the one of the same name only.
+func matchAppliedPolicy(policy types.PolicyYaml, appliedMap map[string]types.AppliedPolicy, matched map[string]bool) (string, bool) {
+	key := policyKey(policy.Kind, policy.Namespace, policy.Name)
+	labels := labelMapToString(policy.Labels)
+
+	if applied, ok := appliedMap[key]; ok && !matched[key] && labelMapToString(applied.Labels) == labels {
+		return key, true
+	}
+
+	candidates := []string{}
+	for appliedKey, applied := range appliedMap {
+		if matched[appliedKey] || applied.Kind != policy.Kind ||
+			policyKey(applied.Kind, applied.Namespace, "") != policyKey(policy.Kind, policy.Namespace, "") {
+			continue
+		}
+		if policyBaseName(applied.Name) == policyBaseName(policy.Name) && labelMapToString(applied.Labels) == labels {
+			candidates = append(candidates, appliedKey)
+		}
+	}
+	if len(candidates) > 0 {
+		sort.Strings(candidates)
+		return candidates[0], true
+	}
+
+	if _, ok := appliedMap[key]; ok && !matched[key] {
+		return key, true
+	}
+
+	return "", false
+}
+
+// ComputePolicyDrifts reports the discovered policies not applied, the applied
+// policies outdated by a newer discovered revision and the unknown applied policies
+func ComputePolicyDrifts(clusterName string, discovered []types.PolicyYaml, applied []types.AppliedPolicy) []types.PolicyDrift {
+	drifts := []types.PolicyDrift{}
+
+	appliedMap := map[string]types.AppliedPolicy{}
+	for _, policy := range applied {
+		appliedMap[policyKey(policy.Kind, policy.Namespace, policy.Name)] = policy
+	}
+
+	matched := map[string]bool{}
+	for _, policy := range discovered {
+		drift := types.PolicyDrift{
+			Cluster:   clusterName,
+			Namespace: policy.Namespace,
+			Labels:    labelMapToString(policy.Labels),
+			Kind:      policy.Kind,
+			Name:      policy.Name,
+		}
+
+		key, ok := matchAppliedPolicy(policy, appliedMap, matched)
+		if !ok {
+			drift.Status = types.DriftStatusNotApplied
+			drifts = append(drifts, drift)
+			continue
+		}
+		appliedPolicy := appliedMap[key]
+		matched[key] = true
+
+		spec, err := discoveredSpec(policy)
+		if err != nil {
+			log.Warn().Msgf("failed to parse the policy %s: %s", key, err.Error())
+			continue
+		}
+
+		if !specCovers(normalizeSpec(appliedPolicy.Spec), spec) {
+			drift.Status = types.DriftStatusOutdated
+			drifts = append(drifts, drift)
+		}
+	}
+
+	for key, policy := range appliedMap {
+		if matched[key] {
+			continue
+		}
+
+		drifts = append(drifts, types.PolicyDrift{
+			Cluster:   clusterName,
+			Namespace: policy.Namespace,
+			Labels:    labelMapToString(policy.Labels),
+			Kind:      policy.Kind,
+			Name:      policy.Name,
+			Status:    types.DriftStatusUnknown,
+		})
+	}
+
+	sort.SliceStable(drifts, func(i, j int) bool {
+		if drifts[i].Namespace != drifts[j].Namespace {
+			return drifts[i].Namespace < drifts[j].Namespace
+		}
+		if drifts[i].Labels != drifts[j].Labels {
+			return drifts[i].Labels < drifts[j].Labels
+		}
+		return drifts[i].Name < drifts[j].Name
+	})
+
+	return drifts
+}
@@ -0,0 +1,90 @@
+package policydrift
+
+import (
+	"testing"
+
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePolicyDrifts(t *testing.T) {
+	yaml := []byte(`apiVersion: cilium.io/v2
+kind: CiliumNetworkPolicy
+metadata:
+  name: autopol-egress-1
+  namespace: default
+spec:
+  endpointSelector:
+    matchLabels:
+      app: web
+  egress:
+  - toPorts:
+    - ports:
+      - port: "80"
+        protocol: TCP
+`)
+	discovered := []types.PolicyYaml{
+		{Kind: "CiliumNetworkPolicy", Name: "autopol-egress-1", Namespace: "default", Labels: types.LabelMap{"app": "web"}, Yaml: yaml},
+		{Kind: "CiliumNetworkPolicy", Name: "autopol-egress-2", Namespace: "default", Labels: types.LabelMap{"app": "db"}, Yaml: yaml},
+		{Kind: "KubeArmorHostPolicy", Name: "autopol-host", Namespace: "", Yaml: []byte("spec:\n  action: Allow\n")},
+	}
+
+	appliedSpec := map[string]interface{}{
+		"endpointSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+		"egress": []interface{}{
+			map[string]interface{}{"toPorts": []interface{}{
+				// the protocol and the port defaulted by the api server
+				map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": "80", "protocol": "TCP"}}, "rules": nil},
+			}},
+		},
+	}
+
+	applied := []types.AppliedPolicy{
+		{Kind: "CiliumNetworkPolicy", Name: "autopol-egress-1", Namespace: "default", Spec: appliedSpec},
+		{Kind: "KubeArmorHostPolicy", Name: "autopol-host", Spec: map[string]interface{}{"action": "Block"}},
+		{Kind: "NetworkPolicy", Name: "manual", Namespace: "default", Labels: types.LabelMap{"app": "web"}},
+	}
+
+	drifts := ComputePolicyDrifts("default", discovered, applied)
+
+	statuses := map[string]string{}
+	for _, drift := range drifts {
+		statuses[drift.Name] = drift.Status
+	}
+
+	assert.Equal(t, map[string]string{
+		"autopol-egress-2": types.DriftStatusNotApplied,
+		"autopol-host":     types.DriftStatusOutdated,
+		"manual":           types.DriftStatusUnknown,
+	}, statuses)
+}
+
+func TestSpecCovers(t *testing.T) {
+	applied := map[string]interface{}{"a": "1", "b": []interface{}{"x"}, "c": float64(2)}
+
+	assert.True(t, specCovers(applied, map[string]interface{}{"a": "1"}))
+	assert.True(t, specCovers(applied, map[string]interface{}{"b": []interface{}{"x"}, "c": float64(2)}))
+	assert.False(t, specCovers(applied, map[string]interface{}{"a": "2"}))
+	assert.False(t, specCovers(applied, map[string]interface{}{"b": []interface{}{"x", "y"}}))
+	assert.False(t, specCovers(applied, map[string]interface{}{"d": "1"}))
+}
+
+func TestComputePolicyDriftsNameSuffix(t *testing.T) {
+	yaml := []byte("spec:\n  action: Allow\n")
+
+	// the suffixes of the names are swapped since the policies are applied
+	discovered := []types.PolicyYaml{
+		{Kind: "KubeArmorPolicy", Name: "autopol-system-web", Namespace: "default", Labels: types.LabelMap{"app": "db"}, Yaml: yaml},
+		{Kind: "KubeArmorPolicy", Name: "autopol-system-web-2", Namespace: "default", Labels: types.LabelMap{"app": "web"}, Yaml: yaml},
+	}
+	applied := []types.AppliedPolicy{
+		{Kind: "KubeArmorPolicy", Name: "autopol-system-web", Namespace: "default", Labels: types.LabelMap{"app": "web"},
+			Spec: map[string]interface{}{"action": "Allow"}},
+		{Kind: "KubeArmorPolicy", Name: "autopol-system-web-2", Namespace: "default", Labels: types.LabelMap{"app": "db"},
+			Spec: map[string]interface{}{"action": "Allow"}},
+	}
+
+	assert.Empty(t, ComputePolicyDrifts("default", discovered, applied))
+	assert.Equal(t, "autopol-system-web", policyBaseName("autopol-system-web-2"))
+	assert.Equal(t, "autopol-system-web", policyBaseName("autopol-system-web"))
+}
@@ -154,6 +154,7 @@ type InsightResponse struct {
 	Labels          string                `protobuf:"bytes,3,opt,name=Labels,proto3" json:"Labels,omitempty"`
 	SystemResource  []*SystemInsightData  `protobuf:"bytes,4,rep,name=SystemResource,proto3" json:"SystemResource,omitempty"`
 	NetworkResource []*NetworkInsightData `protobuf:"bytes,5,rep,name=NetworkResource,proto3" json:"NetworkResource,omitempty"`
+	WorkloadStates  []*WorkloadState      `protobuf:"bytes,6,rep,name=WorkloadStates,proto3" json:"WorkloadStates,omitempty"`
 }
 
 func (x *InsightResponse) Reset() {
@@ -223,6 +224,13 @@ func (x *InsightResponse) GetNetworkResource() []*NetworkInsightData {
 	return nil
 }
 
+func (x *InsightResponse) GetWorkloadStates() []*WorkloadState {
+	if x != nil {
+		return x.WorkloadStates
+	}
+	return nil
+}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -355,10 +363,11 @@ type SystemData struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FromSource      string   `protobuf:"bytes,1,opt,name=fromSource,proto3" json:"fromSource,omitempty"`
-	ProcessPaths    []string `protobuf:"bytes,2,rep,name=processPaths,proto3" json:"processPaths,omitempty"`
-	FilePaths       []string `protobuf:"bytes,3,rep,name=filePaths,proto3" json:"filePaths,omitempty"`
-	NetworkProtocol []string `protobuf:"bytes,4,rep,name=networkProtocol,proto3" json:"networkProtocol,omitempty"`
+	FromSource      string       `protobuf:"bytes,1,opt,name=fromSource,proto3" json:"fromSource,omitempty"`
+	ProcessPaths    []string     `protobuf:"bytes,2,rep,name=processPaths,proto3" json:"processPaths,omitempty"`
+	FilePaths       []string     `protobuf:"bytes,3,rep,name=filePaths,proto3" json:"filePaths,omitempty"`
+	NetworkProtocol []string     `protobuf:"bytes,4,rep,name=networkProtocol,proto3" json:"networkProtocol,omitempty"`
+	RuleStats       []*RuleStats `protobuf:"bytes,5,rep,name=ruleStats,proto3" json:"ruleStats,omitempty"`
 }
 
 func (x *SystemData) Reset() {
@@ -421,6 +430,101 @@ func (x *SystemData) GetNetworkProtocol() []string {
 	return nil
 }
 
+func (x *SystemData) GetRuleStats() []*RuleStats {
+	if x != nil {
+		return x.RuleStats
+	}
+	return nil
+}
+
+// the last-seen tracking of a rule, and the pruning decision
+type RuleStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule      string `protobuf:"bytes,1,opt,name=Rule,proto3" json:"Rule,omitempty"`
+	FirstSeen int64  `protobuf:"varint,2,opt,name=FirstSeen,proto3" json:"FirstSeen,omitempty"`
+	LastSeen  int64  `protobuf:"varint,3,opt,name=LastSeen,proto3" json:"LastSeen,omitempty"`
+	HitCount  int64  `protobuf:"varint,4,opt,name=HitCount,proto3" json:"HitCount,omitempty"`
+	Stale     bool   `protobuf:"varint,5,opt,name=Stale,proto3" json:"Stale,omitempty"`
+	Pruned    bool   `protobuf:"varint,6,opt,name=Pruned,proto3" json:"Pruned,omitempty"`
+}
+
+func (x *RuleStats) Reset() {
+	*x = RuleStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuleStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleStats) ProtoMessage() {}
+
+func (x *RuleStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleStats.ProtoReflect.Descriptor instead.
+func (*RuleStats) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RuleStats) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RuleStats) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *RuleStats) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *RuleStats) GetHitCount() int64 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *RuleStats) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *RuleStats) GetPruned() bool {
+	if x != nil {
+		return x.Pruned
+	}
+	return false
+}
+
 // Network
 type NetworkInsightData struct {
 	state         protoimpl.MessageState
@@ -438,7 +542,7 @@ type NetworkInsightData struct {
 func (x *NetworkInsightData) Reset() {
 	*x = NetworkInsightData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[5]
+		mi := &file_v1_insight_insight_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -451,7 +555,7 @@ func (x *NetworkInsightData) String() string {
 func (*NetworkInsightData) ProtoMessage() {}
 
 func (x *NetworkInsightData) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[5]
+	mi := &file_v1_insight_insight_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +568,7 @@ func (x *NetworkInsightData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkInsightData.ProtoReflect.Descriptor instead.
 func (*NetworkInsightData) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{5}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *NetworkInsightData) GetClusterName() string {
@@ -522,7 +626,7 @@ type NetworkData struct {
 func (x *NetworkData) Reset() {
 	*x = NetworkData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[6]
+		mi := &file_v1_insight_insight_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -535,7 +639,7 @@ func (x *NetworkData) String() string {
 func (*NetworkData) ProtoMessage() {}
 
 func (x *NetworkData) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[6]
+	mi := &file_v1_insight_insight_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -548,7 +652,7 @@ func (x *NetworkData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkData.ProtoReflect.Descriptor instead.
 func (*NetworkData) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{6}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *NetworkData) GetLabels() string {
@@ -584,12 +688,15 @@ type Egress struct {
 	ToServices  []*SpecService    `protobuf:"bytes,5,rep,name=ToServices,proto3" json:"ToServices,omitempty"`
 	ToFQDNs     []*SpecFQDN       `protobuf:"bytes,6,rep,name=ToFQDNs,proto3" json:"ToFQDNs,omitempty"`
 	ToHTTPs     []*SpecHTTP       `protobuf:"bytes,7,rep,name=ToHTTPs,proto3" json:"ToHTTPs,omitempty"`
+	RuleStats   *RuleStats        `protobuf:"bytes,8,opt,name=RuleStats,proto3" json:"RuleStats,omitempty"`
+	ToGRPCs     []*SpecGRPC       `protobuf:"bytes,9,rep,name=ToGRPCs,proto3" json:"ToGRPCs,omitempty"`
+	ToKafkas    []*SpecKafka      `protobuf:"bytes,10,rep,name=ToKafkas,proto3" json:"ToKafkas,omitempty"`
 }
 
 func (x *Egress) Reset() {
 	*x = Egress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[7]
+		mi := &file_v1_insight_insight_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -602,7 +709,7 @@ func (x *Egress) String() string {
 func (*Egress) ProtoMessage() {}
 
 func (x *Egress) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[7]
+	mi := &file_v1_insight_insight_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +722,7 @@ func (x *Egress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Egress.ProtoReflect.Descriptor instead.
 func (*Egress) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{7}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Egress) GetMatchLabels() map[string]string {
@@ -667,6 +774,27 @@ func (x *Egress) GetToHTTPs() []*SpecHTTP {
 	return nil
 }
 
+func (x *Egress) GetRuleStats() *RuleStats {
+	if x != nil {
+		return x.RuleStats
+	}
+	return nil
+}
+
+func (x *Egress) GetToGRPCs() []*SpecGRPC {
+	if x != nil {
+		return x.ToGRPCs
+	}
+	return nil
+}
+
+func (x *Egress) GetToKafkas() []*SpecKafka {
+	if x != nil {
+		return x.ToKafkas
+	}
+	return nil
+}
+
 type SpecPort struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -674,12 +802,13 @@ type SpecPort struct {
 
 	Port     string `protobuf:"bytes,1,opt,name=Port,proto3" json:"Port,omitempty"`
 	Protocol string `protobuf:"bytes,2,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
+	EndPort  int32  `protobuf:"varint,3,opt,name=EndPort,proto3" json:"EndPort,omitempty"`
 }
 
 func (x *SpecPort) Reset() {
 	*x = SpecPort{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[8]
+		mi := &file_v1_insight_insight_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -692,7 +821,7 @@ func (x *SpecPort) String() string {
 func (*SpecPort) ProtoMessage() {}
 
 func (x *SpecPort) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[8]
+	mi := &file_v1_insight_insight_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,7 +834,7 @@ func (x *SpecPort) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecPort.ProtoReflect.Descriptor instead.
 func (*SpecPort) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{8}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SpecPort) GetPort() string {
@@ -722,6 +851,13 @@ func (x *SpecPort) GetProtocol() string {
 	return ""
 }
 
+func (x *SpecPort) GetEndPort() int32 {
+	if x != nil {
+		return x.EndPort
+	}
+	return 0
+}
+
 type SpecCIDR struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -734,7 +870,7 @@ type SpecCIDR struct {
 func (x *SpecCIDR) Reset() {
 	*x = SpecCIDR{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[9]
+		mi := &file_v1_insight_insight_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -747,7 +883,7 @@ func (x *SpecCIDR) String() string {
 func (*SpecCIDR) ProtoMessage() {}
 
 func (x *SpecCIDR) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[9]
+	mi := &file_v1_insight_insight_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -760,7 +896,7 @@ func (x *SpecCIDR) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecCIDR.ProtoReflect.Descriptor instead.
 func (*SpecCIDR) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{9}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SpecCIDR) GetCIDRs() []string {
@@ -789,7 +925,7 @@ type SpecService struct {
 func (x *SpecService) Reset() {
 	*x = SpecService{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[10]
+		mi := &file_v1_insight_insight_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -802,7 +938,7 @@ func (x *SpecService) String() string {
 func (*SpecService) ProtoMessage() {}
 
 func (x *SpecService) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[10]
+	mi := &file_v1_insight_insight_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +951,7 @@ func (x *SpecService) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecService.ProtoReflect.Descriptor instead.
 func (*SpecService) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{10}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SpecService) GetServiceName() string {
@@ -837,13 +973,14 @@ type SpecFQDN struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MatchNames []string `protobuf:"bytes,1,rep,name=MatchNames,proto3" json:"MatchNames,omitempty"`
+	MatchNames    []string `protobuf:"bytes,1,rep,name=MatchNames,proto3" json:"MatchNames,omitempty"`
+	MatchPatterns []string `protobuf:"bytes,2,rep,name=MatchPatterns,proto3" json:"MatchPatterns,omitempty"` // e.g. *.s3.amazonaws.com
 }
 
 func (x *SpecFQDN) Reset() {
 	*x = SpecFQDN{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[11]
+		mi := &file_v1_insight_insight_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -856,7 +993,7 @@ func (x *SpecFQDN) String() string {
 func (*SpecFQDN) ProtoMessage() {}
 
 func (x *SpecFQDN) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[11]
+	mi := &file_v1_insight_insight_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,7 +1006,7 @@ func (x *SpecFQDN) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecFQDN.ProtoReflect.Descriptor instead.
 func (*SpecFQDN) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{11}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SpecFQDN) GetMatchNames() []string {
@@ -879,20 +1016,29 @@ func (x *SpecFQDN) GetMatchNames() []string {
 	return nil
 }
 
+func (x *SpecFQDN) GetMatchPatterns() []string {
+	if x != nil {
+		return x.MatchPatterns
+	}
+	return nil
+}
+
 type SpecHTTP struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Method     string `protobuf:"bytes,1,opt,name=Method,proto3" json:"Method,omitempty"`
-	Path       string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
-	Aggregated bool   `protobuf:"varint,3,opt,name=Aggregated,proto3" json:"Aggregated,omitempty"`
+	Method     string   `protobuf:"bytes,1,opt,name=Method,proto3" json:"Method,omitempty"`
+	Path       string   `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
+	Aggregated bool     `protobuf:"varint,3,opt,name=Aggregated,proto3" json:"Aggregated,omitempty"`
+	Host       string   `protobuf:"bytes,4,opt,name=Host,proto3" json:"Host,omitempty"`
+	Headers    []string `protobuf:"bytes,5,rep,name=Headers,proto3" json:"Headers,omitempty"`
 }
 
 func (x *SpecHTTP) Reset() {
 	*x = SpecHTTP{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[12]
+		mi := &file_v1_insight_insight_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -905,7 +1051,7 @@ func (x *SpecHTTP) String() string {
 func (*SpecHTTP) ProtoMessage() {}
 
 func (x *SpecHTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[12]
+	mi := &file_v1_insight_insight_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -918,7 +1064,7 @@ func (x *SpecHTTP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpecHTTP.ProtoReflect.Descriptor instead.
 func (*SpecHTTP) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{12}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SpecHTTP) GetMethod() string {
@@ -942,6 +1088,146 @@ func (x *SpecHTTP) GetAggregated() bool {
 	return false
 }
 
+func (x *SpecHTTP) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SpecHTTP) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type SpecGRPC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service string `protobuf:"bytes,1,opt,name=Service,proto3" json:"Service,omitempty"`
+	Method  string `protobuf:"bytes,2,opt,name=Method,proto3" json:"Method,omitempty"`
+}
+
+func (x *SpecGRPC) Reset() {
+	*x = SpecGRPC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpecGRPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecGRPC) ProtoMessage() {}
+
+func (x *SpecGRPC) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecGRPC.ProtoReflect.Descriptor instead.
+func (*SpecGRPC) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SpecGRPC) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *SpecGRPC) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type SpecKafka struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role     string `protobuf:"bytes,1,opt,name=Role,proto3" json:"Role,omitempty"` // produce|consume
+	APIKey   string `protobuf:"bytes,2,opt,name=APIKey,proto3" json:"APIKey,omitempty"`
+	ClientID string `protobuf:"bytes,3,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	Topic    string `protobuf:"bytes,4,opt,name=Topic,proto3" json:"Topic,omitempty"`
+}
+
+func (x *SpecKafka) Reset() {
+	*x = SpecKafka{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpecKafka) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecKafka) ProtoMessage() {}
+
+func (x *SpecKafka) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecKafka.ProtoReflect.Descriptor instead.
+func (*SpecKafka) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SpecKafka) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SpecKafka) GetAPIKey() string {
+	if x != nil {
+		return x.APIKey
+	}
+	return ""
+}
+
+func (x *SpecKafka) GetClientID() string {
+	if x != nil {
+		return x.ClientID
+	}
+	return ""
+}
+
+func (x *SpecKafka) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
 type Ingress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -952,12 +1238,15 @@ type Ingress struct {
 	ToHTTPs      []*SpecHTTP       `protobuf:"bytes,3,rep,name=ToHTTPs,proto3" json:"ToHTTPs,omitempty"`
 	FromCIDRs    []*SpecCIDR       `protobuf:"bytes,4,rep,name=FromCIDRs,proto3" json:"FromCIDRs,omitempty"`
 	FromEntities []string          `protobuf:"bytes,5,rep,name=FromEntities,proto3" json:"FromEntities,omitempty"`
+	RuleStats    *RuleStats        `protobuf:"bytes,6,opt,name=RuleStats,proto3" json:"RuleStats,omitempty"`
+	ToGRPCs      []*SpecGRPC       `protobuf:"bytes,7,rep,name=ToGRPCs,proto3" json:"ToGRPCs,omitempty"`
+	ToKafkas     []*SpecKafka      `protobuf:"bytes,8,rep,name=ToKafkas,proto3" json:"ToKafkas,omitempty"`
 }
 
 func (x *Ingress) Reset() {
 	*x = Ingress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_insight_insight_proto_msgTypes[13]
+		mi := &file_v1_insight_insight_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -970,7 +1259,7 @@ func (x *Ingress) String() string {
 func (*Ingress) ProtoMessage() {}
 
 func (x *Ingress) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_insight_insight_proto_msgTypes[13]
+	mi := &file_v1_insight_insight_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,7 +1272,7 @@ func (x *Ingress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ingress.ProtoReflect.Descriptor instead.
 func (*Ingress) Descriptor() ([]byte, []int) {
-	return file_v1_insight_insight_proto_rawDescGZIP(), []int{13}
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Ingress) GetMatchLabels() map[string]string {
@@ -1021,18 +1310,1452 @@ func (x *Ingress) GetFromEntities() []string {
 	return nil
 }
 
-var File_v1_insight_insight_proto protoreflect.FileDescriptor
+func (x *Ingress) GetRuleStats() *RuleStats {
+	if x != nil {
+		return x.RuleStats
+	}
+	return nil
+}
 
-var file_v1_insight_insight_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2f, 0x69, 0x6e, 0x73,
-	0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x76, 0x31, 0x2e, 0x69,
-	0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x22, 0x9d, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+func (x *Ingress) GetToGRPCs() []*SpecGRPC {
+	if x != nil {
+		return x.ToGRPCs
+	}
+	return nil
+}
+
+func (x *Ingress) GetToKafkas() []*SpecKafka {
+	if x != nil {
+		return x.ToKafkas
+	}
+	return nil
+}
+
+// Evidence
+type EvidenceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PolicyName string `protobuf:"bytes,1,opt,name=policyName,proto3" json:"policyName,omitempty"`
+	// network: egress rules followed by ingress rules
+	// system: process matchPaths, matchDirectories, matchPatterns, then file ones
+	RuleIndex int32 `protobuf:"varint,2,opt,name=ruleIndex,proto3" json:"ruleIndex,omitempty"`
+}
+
+func (x *EvidenceRequest) Reset() {
+	*x = EvidenceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceRequest) ProtoMessage() {}
+
+func (x *EvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceRequest.ProtoReflect.Descriptor instead.
+func (*EvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EvidenceRequest) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *EvidenceRequest) GetRuleIndex() int32 {
+	if x != nil {
+		return x.RuleIndex
+	}
+	return 0
+}
+
+type Evidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FlowId    int64             `protobuf:"varint,1,opt,name=flowId,proto3" json:"flowId,omitempty"`
+	Timestamp int64             `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Namespace string            `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PodName   string            `protobuf:"bytes,4,opt,name=podName,proto3" json:"podName,omitempty"`
+	Fields    map[string]string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Evidence) GetFlowId() int64 {
+	if x != nil {
+		return x.FlowId
+	}
+	return 0
+}
+
+func (x *Evidence) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Evidence) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Evidence) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *Evidence) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type EvidenceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PolicyName string      `protobuf:"bytes,1,opt,name=policyName,proto3" json:"policyName,omitempty"`
+	PolicyType string      `protobuf:"bytes,2,opt,name=policyType,proto3" json:"policyType,omitempty"`
+	RuleIndex  int32       `protobuf:"varint,3,opt,name=ruleIndex,proto3" json:"ruleIndex,omitempty"`
+	Rule       string      `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	RuleStats  *RuleStats  `protobuf:"bytes,5,opt,name=ruleStats,proto3" json:"ruleStats,omitempty"`
+	Evidence   []*Evidence `protobuf:"bytes,6,rep,name=evidence,proto3" json:"evidence,omitempty"`
+}
+
+func (x *EvidenceResponse) Reset() {
+	*x = EvidenceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvidenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceResponse) ProtoMessage() {}
+
+func (x *EvidenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceResponse.ProtoReflect.Descriptor instead.
+func (*EvidenceResponse) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EvidenceResponse) GetPolicyName() string {
+	if x != nil {
+		return x.PolicyName
+	}
+	return ""
+}
+
+func (x *EvidenceResponse) GetPolicyType() string {
+	if x != nil {
+		return x.PolicyType
+	}
+	return ""
+}
+
+func (x *EvidenceResponse) GetRuleIndex() int32 {
+	if x != nil {
+		return x.RuleIndex
+	}
+	return 0
+}
+
+func (x *EvidenceResponse) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *EvidenceResponse) GetRuleStats() *RuleStats {
+	if x != nil {
+		return x.RuleStats
+	}
+	return nil
+}
+
+func (x *EvidenceResponse) GetEvidence() []*Evidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+// Workload State
+type WorkloadStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string `protobuf:"bytes,1,opt,name=clusterName,proto3" json:"clusterName,omitempty"`
+	Namespace   string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Labels      string `protobuf:"bytes,3,opt,name=labels,proto3" json:"labels,omitempty"`
+	PolicyType  string `protobuf:"bytes,4,opt,name=policyType,proto3" json:"policyType,omitempty"` // network|system, any if empty
+	State       string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`           // learning|stable|enforce-ready, any if empty
+}
+
+func (x *WorkloadStateRequest) Reset() {
+	*x = WorkloadStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadStateRequest) ProtoMessage() {}
+
+func (x *WorkloadStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadStateRequest.ProtoReflect.Descriptor instead.
+func (*WorkloadStateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WorkloadStateRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *WorkloadStateRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WorkloadStateRequest) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+func (x *WorkloadStateRequest) GetPolicyType() string {
+	if x != nil {
+		return x.PolicyType
+	}
+	return ""
+}
+
+func (x *WorkloadStateRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// the learning state of the discovered policy of a workload
+type WorkloadState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName     string `protobuf:"bytes,1,opt,name=ClusterName,proto3" json:"ClusterName,omitempty"`
+	Namespace       string `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	Labels          string `protobuf:"bytes,3,opt,name=Labels,proto3" json:"Labels,omitempty"`
+	PolicyType      string `protobuf:"bytes,4,opt,name=PolicyType,proto3" json:"PolicyType,omitempty"`
+	State           string `protobuf:"bytes,5,opt,name=State,proto3" json:"State,omitempty"`
+	StateSince      int64  `protobuf:"varint,6,opt,name=StateSince,proto3" json:"StateSince,omitempty"`
+	FirstSeen       int64  `protobuf:"varint,7,opt,name=FirstSeen,proto3" json:"FirstSeen,omitempty"`
+	LastChanged     int64  `protobuf:"varint,8,opt,name=LastChanged,proto3" json:"LastChanged,omitempty"`
+	ChangesInWindow int32  `protobuf:"varint,9,opt,name=ChangesInWindow,proto3" json:"ChangesInWindow,omitempty"`
+}
+
+func (x *WorkloadState) Reset() {
+	*x = WorkloadState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadState) ProtoMessage() {}
+
+func (x *WorkloadState) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadState.ProtoReflect.Descriptor instead.
+func (*WorkloadState) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WorkloadState) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *WorkloadState) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WorkloadState) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+func (x *WorkloadState) GetPolicyType() string {
+	if x != nil {
+		return x.PolicyType
+	}
+	return ""
+}
+
+func (x *WorkloadState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *WorkloadState) GetStateSince() int64 {
+	if x != nil {
+		return x.StateSince
+	}
+	return 0
+}
+
+func (x *WorkloadState) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *WorkloadState) GetLastChanged() int64 {
+	if x != nil {
+		return x.LastChanged
+	}
+	return 0
+}
+
+func (x *WorkloadState) GetChangesInWindow() int32 {
+	if x != nil {
+		return x.ChangesInWindow
+	}
+	return 0
+}
+
+type WorkloadStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States []*WorkloadState `protobuf:"bytes,1,rep,name=States,proto3" json:"States,omitempty"`
+}
+
+func (x *WorkloadStateResponse) Reset() {
+	*x = WorkloadStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadStateResponse) ProtoMessage() {}
+
+func (x *WorkloadStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadStateResponse.ProtoReflect.Descriptor instead.
+func (*WorkloadStateResponse) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WorkloadStateResponse) GetStates() []*WorkloadState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type PolicyGapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string `protobuf:"bytes,1,opt,name=clusterName,proto3" json:"clusterName,omitempty"`
+	Namespace   string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Labels      string `protobuf:"bytes,3,opt,name=labels,proto3" json:"labels,omitempty"`
+	PolicyType  string `protobuf:"bytes,4,opt,name=policyType,proto3" json:"policyType,omitempty"` // network|system, any if empty
+}
+
+func (x *PolicyGapRequest) Reset() {
+	*x = PolicyGapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyGapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyGapRequest) ProtoMessage() {}
+
+func (x *PolicyGapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyGapRequest.ProtoReflect.Descriptor instead.
+func (*PolicyGapRequest) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PolicyGapRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *PolicyGapRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PolicyGapRequest) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+func (x *PolicyGapRequest) GetPolicyType() string {
+	if x != nil {
+		return x.PolicyType
+	}
+	return ""
+}
+
+// the minimal rule which would have allowed the dropped or blocked traffic
+type PolicyGap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GapId     string   `protobuf:"bytes,1,opt,name=GapId,proto3" json:"GapId,omitempty"`
+	Rule      string   `protobuf:"bytes,2,opt,name=Rule,proto3" json:"Rule,omitempty"`
+	Event     string   `protobuf:"bytes,3,opt,name=Event,proto3" json:"Event,omitempty"` // the latest dropped flow or blocked event
+	FirstSeen int64    `protobuf:"varint,4,opt,name=FirstSeen,proto3" json:"FirstSeen,omitempty"`
+	LastSeen  int64    `protobuf:"varint,5,opt,name=LastSeen,proto3" json:"LastSeen,omitempty"`
+	HitCount  int64    `protobuf:"varint,6,opt,name=HitCount,proto3" json:"HitCount,omitempty"`
+	Pods      []string `protobuf:"bytes,7,rep,name=Pods,proto3" json:"Pods,omitempty"`
+}
+
+func (x *PolicyGap) Reset() {
+	*x = PolicyGap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyGap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyGap) ProtoMessage() {}
+
+func (x *PolicyGap) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyGap.ProtoReflect.Descriptor instead.
+func (*PolicyGap) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PolicyGap) GetGapId() string {
+	if x != nil {
+		return x.GapId
+	}
+	return ""
+}
+
+func (x *PolicyGap) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *PolicyGap) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *PolicyGap) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *PolicyGap) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PolicyGap) GetHitCount() int64 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *PolicyGap) GetPods() []string {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+type WorkloadPolicyGaps struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string       `protobuf:"bytes,1,opt,name=ClusterName,proto3" json:"ClusterName,omitempty"`
+	Namespace   string       `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	Labels      string       `protobuf:"bytes,3,opt,name=Labels,proto3" json:"Labels,omitempty"`
+	PolicyType  string       `protobuf:"bytes,4,opt,name=PolicyType,proto3" json:"PolicyType,omitempty"`
+	Gaps        []*PolicyGap `protobuf:"bytes,5,rep,name=Gaps,proto3" json:"Gaps,omitempty"`
+}
+
+func (x *WorkloadPolicyGaps) Reset() {
+	*x = WorkloadPolicyGaps{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadPolicyGaps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadPolicyGaps) ProtoMessage() {}
+
+func (x *WorkloadPolicyGaps) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadPolicyGaps.ProtoReflect.Descriptor instead.
+func (*WorkloadPolicyGaps) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WorkloadPolicyGaps) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyGaps) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyGaps) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyGaps) GetPolicyType() string {
+	if x != nil {
+		return x.PolicyType
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyGaps) GetGaps() []*PolicyGap {
+	if x != nil {
+		return x.Gaps
+	}
+	return nil
+}
+
+type PolicyGapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workloads []*WorkloadPolicyGaps `protobuf:"bytes,1,rep,name=Workloads,proto3" json:"Workloads,omitempty"`
+}
+
+func (x *PolicyGapResponse) Reset() {
+	*x = PolicyGapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyGapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyGapResponse) ProtoMessage() {}
+
+func (x *PolicyGapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyGapResponse.ProtoReflect.Descriptor instead.
+func (*PolicyGapResponse) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PolicyGapResponse) GetWorkloads() []*WorkloadPolicyGaps {
+	if x != nil {
+		return x.Workloads
+	}
+	return nil
+}
+
+type PolicyDriftRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Labels    string `protobuf:"bytes,2,opt,name=labels,proto3" json:"labels,omitempty"`
+}
+
+func (x *PolicyDriftRequest) Reset() {
+	*x = PolicyDriftRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyDriftRequest) ProtoMessage() {}
+
+func (x *PolicyDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyDriftRequest.ProtoReflect.Descriptor instead.
+func (*PolicyDriftRequest) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PolicyDriftRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PolicyDriftRequest) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+// a policy differing between the discovered and the applied ones
+type DriftedPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   string `protobuf:"bytes,1,opt,name=Kind,proto3" json:"Kind,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Status string `protobuf:"bytes,3,opt,name=Status,proto3" json:"Status,omitempty"` // not-applied|outdated|unknown
+}
+
+func (x *DriftedPolicy) Reset() {
+	*x = DriftedPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DriftedPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftedPolicy) ProtoMessage() {}
+
+func (x *DriftedPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftedPolicy.ProtoReflect.Descriptor instead.
+func (*DriftedPolicy) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DriftedPolicy) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DriftedPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DriftedPolicy) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type WorkloadPolicyDrift struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string           `protobuf:"bytes,1,opt,name=ClusterName,proto3" json:"ClusterName,omitempty"`
+	Namespace   string           `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	Labels      string           `protobuf:"bytes,3,opt,name=Labels,proto3" json:"Labels,omitempty"`
+	Policies    []*DriftedPolicy `protobuf:"bytes,4,rep,name=Policies,proto3" json:"Policies,omitempty"`
+}
+
+func (x *WorkloadPolicyDrift) Reset() {
+	*x = WorkloadPolicyDrift{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadPolicyDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadPolicyDrift) ProtoMessage() {}
+
+func (x *WorkloadPolicyDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadPolicyDrift.ProtoReflect.Descriptor instead.
+func (*WorkloadPolicyDrift) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WorkloadPolicyDrift) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyDrift) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyDrift) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+func (x *WorkloadPolicyDrift) GetPolicies() []*DriftedPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+type PolicyDriftResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workloads []*WorkloadPolicyDrift `protobuf:"bytes,1,rep,name=Workloads,proto3" json:"Workloads,omitempty"`
+}
+
+func (x *PolicyDriftResponse) Reset() {
+	*x = PolicyDriftResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyDriftResponse) ProtoMessage() {}
+
+func (x *PolicyDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyDriftResponse.ProtoReflect.Descriptor instead.
+func (*PolicyDriftResponse) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PolicyDriftResponse) GetWorkloads() []*WorkloadPolicyDrift {
+	if x != nil {
+		return x.Workloads
+	}
+	return nil
+}
+
+type CoverageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string `protobuf:"bytes,1,opt,name=clusterName,proto3" json:"clusterName,omitempty"`
+	Namespace   string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Window      string `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"` // e.g. 24h
+	Export      string `protobuf:"bytes,4,opt,name=export,proto3" json:"export,omitempty"` // json|csv
+}
+
+func (x *CoverageRequest) Reset() {
+	*x = CoverageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CoverageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoverageRequest) ProtoMessage() {}
+
+func (x *CoverageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoverageRequest.ProtoReflect.Descriptor instead.
+func (*CoverageRequest) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CoverageRequest) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *CoverageRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CoverageRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *CoverageRequest) GetExport() string {
+	if x != nil {
+		return x.Export
+	}
+	return ""
+}
+
+// the observed flows/events and the ones under some rule
+type CoverageCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ObservedFlows  int64   `protobuf:"varint,1,opt,name=ObservedFlows,proto3" json:"ObservedFlows,omitempty"`
+	CoveredFlows   int64   `protobuf:"varint,2,opt,name=CoveredFlows,proto3" json:"CoveredFlows,omitempty"`
+	FlowCoverage   float64 `protobuf:"fixed64,3,opt,name=FlowCoverage,proto3" json:"FlowCoverage,omitempty"`
+	ObservedEvents int64   `protobuf:"varint,4,opt,name=ObservedEvents,proto3" json:"ObservedEvents,omitempty"`
+	CoveredEvents  int64   `protobuf:"varint,5,opt,name=CoveredEvents,proto3" json:"CoveredEvents,omitempty"`
+	EventCoverage  float64 `protobuf:"fixed64,6,opt,name=EventCoverage,proto3" json:"EventCoverage,omitempty"`
+}
+
+func (x *CoverageCount) Reset() {
+	*x = CoverageCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CoverageCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoverageCount) ProtoMessage() {}
+
+func (x *CoverageCount) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoverageCount.ProtoReflect.Descriptor instead.
+func (*CoverageCount) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CoverageCount) GetObservedFlows() int64 {
+	if x != nil {
+		return x.ObservedFlows
+	}
+	return 0
+}
+
+func (x *CoverageCount) GetCoveredFlows() int64 {
+	if x != nil {
+		return x.CoveredFlows
+	}
+	return 0
+}
+
+func (x *CoverageCount) GetFlowCoverage() float64 {
+	if x != nil {
+		return x.FlowCoverage
+	}
+	return 0
+}
+
+func (x *CoverageCount) GetObservedEvents() int64 {
+	if x != nil {
+		return x.ObservedEvents
+	}
+	return 0
+}
+
+func (x *CoverageCount) GetCoveredEvents() int64 {
+	if x != nil {
+		return x.CoveredEvents
+	}
+	return 0
+}
+
+func (x *CoverageCount) GetEventCoverage() float64 {
+	if x != nil {
+		return x.EventCoverage
+	}
+	return 0
+}
+
+type WorkloadCoverage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName    string         `protobuf:"bytes,1,opt,name=ClusterName,proto3" json:"ClusterName,omitempty"`
+	Namespace      string         `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	Labels         string         `protobuf:"bytes,3,opt,name=Labels,proto3" json:"Labels,omitempty"`
+	NetworkIngress string         `protobuf:"bytes,4,opt,name=NetworkIngress,proto3" json:"NetworkIngress,omitempty"` // applied|discovered|none
+	NetworkEgress  string         `protobuf:"bytes,5,opt,name=NetworkEgress,proto3" json:"NetworkEgress,omitempty"`
+	SystemProcess  string         `protobuf:"bytes,6,opt,name=SystemProcess,proto3" json:"SystemProcess,omitempty"`
+	SystemFile     string         `protobuf:"bytes,7,opt,name=SystemFile,proto3" json:"SystemFile,omitempty"`
+	Count          *CoverageCount `protobuf:"bytes,8,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *WorkloadCoverage) Reset() {
+	*x = WorkloadCoverage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadCoverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadCoverage) ProtoMessage() {}
+
+func (x *WorkloadCoverage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadCoverage.ProtoReflect.Descriptor instead.
+func (*WorkloadCoverage) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WorkloadCoverage) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetLabels() string {
+	if x != nil {
+		return x.Labels
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetNetworkIngress() string {
+	if x != nil {
+		return x.NetworkIngress
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetNetworkEgress() string {
+	if x != nil {
+		return x.NetworkEgress
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetSystemProcess() string {
+	if x != nil {
+		return x.SystemProcess
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetSystemFile() string {
+	if x != nil {
+		return x.SystemFile
+	}
+	return ""
+}
+
+func (x *WorkloadCoverage) GetCount() *CoverageCount {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+type NamespaceCoverage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName      string         `protobuf:"bytes,1,opt,name=ClusterName,proto3" json:"ClusterName,omitempty"`
+	Namespace        string         `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	Workloads        int32          `protobuf:"varint,3,opt,name=Workloads,proto3" json:"Workloads,omitempty"`
+	CoveredWorkloads int32          `protobuf:"varint,4,opt,name=CoveredWorkloads,proto3" json:"CoveredWorkloads,omitempty"`
+	Count            *CoverageCount `protobuf:"bytes,5,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *NamespaceCoverage) Reset() {
+	*x = NamespaceCoverage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamespaceCoverage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceCoverage) ProtoMessage() {}
+
+func (x *NamespaceCoverage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceCoverage.ProtoReflect.Descriptor instead.
+func (*NamespaceCoverage) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NamespaceCoverage) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *NamespaceCoverage) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespaceCoverage) GetWorkloads() int32 {
+	if x != nil {
+		return x.Workloads
+	}
+	return 0
+}
+
+func (x *NamespaceCoverage) GetCoveredWorkloads() int32 {
+	if x != nil {
+		return x.CoveredWorkloads
+	}
+	return 0
+}
+
+func (x *NamespaceCoverage) GetCount() *CoverageCount {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+type CoverageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClusterName string               `protobuf:"bytes,1,opt,name=ClusterName,proto3" json:"ClusterName,omitempty"`
+	Window      int64                `protobuf:"varint,2,opt,name=Window,proto3" json:"Window,omitempty"`
+	Count       *CoverageCount       `protobuf:"bytes,3,opt,name=Count,proto3" json:"Count,omitempty"`
+	Namespaces  []*NamespaceCoverage `protobuf:"bytes,4,rep,name=Namespaces,proto3" json:"Namespaces,omitempty"`
+	Workloads   []*WorkloadCoverage  `protobuf:"bytes,5,rep,name=Workloads,proto3" json:"Workloads,omitempty"`
+}
+
+func (x *CoverageResponse) Reset() {
+	*x = CoverageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_insight_insight_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CoverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoverageResponse) ProtoMessage() {}
+
+func (x *CoverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_insight_insight_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoverageResponse.ProtoReflect.Descriptor instead.
+func (*CoverageResponse) Descriptor() ([]byte, []int) {
+	return file_v1_insight_insight_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CoverageResponse) GetClusterName() string {
+	if x != nil {
+		return x.ClusterName
+	}
+	return ""
+}
+
+func (x *CoverageResponse) GetWindow() int64 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+func (x *CoverageResponse) GetCount() *CoverageCount {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+func (x *CoverageResponse) GetNamespaces() []*NamespaceCoverage {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *CoverageResponse) GetWorkloads() []*WorkloadCoverage {
+	if x != nil {
+		return x.Workloads
+	}
+	return nil
+}
+
+var File_v1_insight_insight_proto protoreflect.FileDescriptor
+
+var file_v1_insight_insight_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2f, 0x69, 0x6e, 0x73,
+	0x69, 0x67, 0x68, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x76, 0x31, 0x2e, 0x69,
+	0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x22, 0x9d, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
 	0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
 	0x70, 0x61, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
 	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
@@ -1044,7 +2767,7 @@ var file_v1_insight_insight_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
 	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
 	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0xfa, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x69, 0x67,
+	0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0xbd, 0x02, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x69, 0x67,
 	0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c,
 	0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
@@ -1060,86 +2783,115 @@ var file_v1_insight_insight_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e,
 	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x44, 0x61,
 	0x74, 0x61, 0x52, 0x0f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x22, 0x39, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2d, 0x0a, 0x03, 0x52, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x76,
-	0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x52, 0x65, 0x73, 0x22, 0xcb,
-	0x01, 0x0a, 0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74,
-	0x44, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0d,
-	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x53, 0x79, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73,
-	0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x0b, 0x53, 0x79, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x98, 0x01, 0x0a,
-	0x0a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x66,
-	0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x70,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12,
-	0x1c, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x28, 0x0a,
-	0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0xcf, 0x01, 0x0a, 0x12, 0x4e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x20,
-	0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x75,
-	0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x39,
-	0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74,
-	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x4e, 0x65,
-	0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0b, 0x4e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x12, 0x30, 0x0a, 0x09, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68,
-	0x74, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73,
-	0x65, 0x73, 0x73, 0x12, 0x33, 0x0a, 0x0a, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73,
-	0x69, 0x67, 0x68, 0x74, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0a, 0x49, 0x6e,
-	0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x73, 0x22, 0xaa, 0x03, 0x0a, 0x06, 0x45, 0x67, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x45, 0x0a, 0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e,
-	0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x4d,
-	0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f,
-	0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31,
-	0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x50, 0x6f, 0x72,
-	0x74, 0x52, 0x07, 0x54, 0x6f, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f,
-	0x43, 0x49, 0x44, 0x52, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31,
-	0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x43, 0x49, 0x44,
-	0x52, 0x52, 0x07, 0x54, 0x6f, 0x43, 0x49, 0x44, 0x52, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x6f,
-	0x45, 0x6e, 0x64, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0b, 0x54, 0x6f, 0x45, 0x6e, 0x64, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0a,
-	0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70,
-	0x65, 0x63, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0a, 0x54, 0x6f, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x46, 0x51, 0x44, 0x4e, 0x73,
-	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69,
-	0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x46, 0x51, 0x44, 0x4e, 0x52, 0x07, 0x54, 0x6f,
-	0x46, 0x51, 0x44, 0x4e, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x48, 0x54, 0x54, 0x50, 0x73,
-	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69,
-	0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x48, 0x54, 0x54, 0x50, 0x52, 0x07, 0x54, 0x6f,
-	0x48, 0x54, 0x54, 0x50, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3a, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x50, 0x6f, 0x72,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x22, 0x38, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x43, 0x49, 0x44, 0x52, 0x12, 0x14, 0x0a,
+	0x72, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31,
+	0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x39, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2d, 0x0a, 0x03, 0x52, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x49, 0x6e, 0x73,
+	0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x03, 0x52, 0x65,
+	0x73, 0x22, 0xcb, 0x01, 0x0a, 0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x53, 0x79, 0x73, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31, 0x2e,
+	0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x0b, 0x53, 0x79, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22,
+	0xcd, 0x01, 0x0a, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1e,
+	0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x50, 0x61, 0x74,
+	0x68, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73,
+	0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x75,
+	0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22,
+	0xa3, 0x01, 0x0a, 0x09, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x52, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x75, 0x6c,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x46, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x48,
+	0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x48,
+	0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x6c, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x50,
+	0x72, 0x75, 0x6e, 0x65, 0x64, 0x22, 0xcf, 0x01, 0x0a, 0x12, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x0b,
+	0x4e, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x4e, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x30, 0x0a, 0x09, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e,
+	0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x73, 0x12, 0x33, 0x0a, 0x0a, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67,
+	0x68, 0x74, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0a, 0x49, 0x6e, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x73, 0x22, 0xc2, 0x04, 0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x45, 0x0a, 0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x50, 0x6f,
+	0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69,
+	0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x52,
+	0x07, 0x54, 0x6f, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x43, 0x49,
+	0x44, 0x52, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69,
+	0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x43, 0x49, 0x44, 0x52, 0x52,
+	0x07, 0x54, 0x6f, 0x43, 0x49, 0x44, 0x52, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x6f, 0x45, 0x6e,
+	0x64, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x54,
+	0x6f, 0x45, 0x6e, 0x64, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x54, 0x6f,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0a, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x46, 0x51, 0x44, 0x4e, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68,
+	0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x46, 0x51, 0x44, 0x4e, 0x52, 0x07, 0x54, 0x6f, 0x46, 0x51,
+	0x44, 0x4e, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x48, 0x54, 0x54, 0x50, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68,
+	0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x48, 0x54, 0x54, 0x50, 0x52, 0x07, 0x54, 0x6f, 0x48, 0x54,
+	0x54, 0x50, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x52,
+	0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x47, 0x52,
+	0x50, 0x43, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69,
+	0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x47, 0x52, 0x50, 0x43, 0x52,
+	0x07, 0x54, 0x6f, 0x47, 0x52, 0x50, 0x43, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x54, 0x6f, 0x4b, 0x61,
+	0x66, 0x6b, 0x61, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e,
+	0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x4b, 0x61, 0x66, 0x6b,
+	0x61, 0x52, 0x08, 0x54, 0x6f, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x54, 0x0a, 0x08, 0x53,
+	0x70, 0x65, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6e, 0x64, 0x50, 0x6f,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x45, 0x6e, 0x64, 0x50, 0x6f, 0x72,
+	0x74, 0x22, 0x38, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x43, 0x49, 0x44, 0x52, 0x12, 0x14, 0x0a,
 	0x05, 0x43, 0x49, 0x44, 0x52, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x43, 0x49,
 	0x44, 0x52, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x45, 0x78, 0x63, 0x65, 0x70, 0x74, 0x18, 0x02, 0x20,
 	0x03, 0x28, 0x09, 0x52, 0x06, 0x45, 0x78, 0x63, 0x65, 0x70, 0x74, 0x22, 0x4d, 0x0a, 0x0b, 0x53,
@@ -1147,45 +2899,304 @@ var file_v1_insight_insight_proto_rawDesc = []byte{
 	0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x0b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
 	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x08, 0x53, 0x70,
+	0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x50, 0x0a, 0x08, 0x53, 0x70,
 	0x65, 0x63, 0x46, 0x51, 0x44, 0x4e, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4e,
 	0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x56, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x48, 0x54,
-	0x54, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61,
-	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e,
-	0x0a, 0x0a, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0a, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x22, 0xc9,
-	0x02, 0x0a, 0x07, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x46, 0x0a, 0x0b, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x24, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x49, 0x6e, 0x67,
-	0x72, 0x65, 0x73, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74,
-	0x2e, 0x53, 0x70, 0x65, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x54, 0x6f, 0x50, 0x6f, 0x72,
-	0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x48, 0x54, 0x54, 0x50, 0x73, 0x18, 0x03, 0x20,
+	0x68, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x22, 0x84, 0x01, 0x0a,
+	0x08, 0x53, 0x70, 0x65, 0x63, 0x48, 0x54, 0x54, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x47, 0x52, 0x50, 0x43, 0x12,
+	0x18, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x22, 0x69, 0x0a, 0x09, 0x53, 0x70, 0x65, 0x63, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x12, 0x12,
+	0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x6f,
+	0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x22, 0xe1, 0x03, 0x0a,
+	0x07, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x46, 0x0a, 0x0b, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x54, 0x6f, 0x50, 0x6f, 0x72, 0x74, 0x73,
+	0x12, 0x2e, 0x0a, 0x07, 0x54, 0x6f, 0x48, 0x54, 0x54, 0x50, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x48, 0x54, 0x54, 0x50, 0x52, 0x07, 0x54, 0x6f, 0x48, 0x54, 0x54, 0x50, 0x73,
+	0x12, 0x32, 0x0a, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x49, 0x44, 0x52, 0x73, 0x18, 0x04, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74,
-	0x2e, 0x53, 0x70, 0x65, 0x63, 0x48, 0x54, 0x54, 0x50, 0x52, 0x07, 0x54, 0x6f, 0x48, 0x54, 0x54,
-	0x50, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x49, 0x44, 0x52, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67,
-	0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x43, 0x49, 0x44, 0x52, 0x52, 0x09, 0x46, 0x72, 0x6f,
-	0x6d, 0x43, 0x49, 0x44, 0x52, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x45, 0x6e,
-	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x46, 0x72,
-	0x6f, 0x6d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x46, 0x0a, 0x07, 0x49, 0x6e,
-	0x73, 0x69, 0x67, 0x68, 0x74, 0x12, 0x3b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x69,
-	0x67, 0x68, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73,
-	0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76,
-	0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x61, 0x63, 0x63, 0x75, 0x6b, 0x6e, 0x6f, 0x78, 0x2f, 0x61, 0x75, 0x74, 0x6f, 0x2d, 0x70,
-	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2d, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f,
-	0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x31, 0x2f,
-	0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x53, 0x70, 0x65, 0x63, 0x43, 0x49, 0x44, 0x52, 0x52, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x43,
+	0x49, 0x44, 0x52, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x46, 0x72, 0x6f, 0x6d,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x52, 0x75, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31,
+	0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x09, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a,
+	0x07, 0x54, 0x6f, 0x47, 0x52, 0x50, 0x43, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65, 0x63,
+	0x47, 0x52, 0x50, 0x43, 0x52, 0x07, 0x54, 0x6f, 0x47, 0x52, 0x50, 0x43, 0x73, 0x12, 0x31, 0x0a,
+	0x08, 0x54, 0x6f, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x53, 0x70, 0x65,
+	0x63, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x52, 0x08, 0x54, 0x6f, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x73,
+	0x1a, 0x3e, 0x0a, 0x10, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x4f, 0x0a, 0x0f, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x22, 0xed, 0x01, 0x0a, 0x08, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76,
+	0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xeb, 0x01, 0x0a, 0x10, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x75, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31,
+	0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x09, 0x72, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x30, 0x0a,
+	0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x45, 0x76, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22,
+	0xa4, 0x01, 0x0a, 0x14, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xa7, 0x02, 0x0a, 0x0d, 0x57, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x72, 0x73, 0x74, 0x53,
+	0x65, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x46, 0x69, 0x72, 0x73, 0x74,
+	0x53, 0x65, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x49, 0x6e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x49, 0x6e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x22, 0x4a, 0x0a, 0x15, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x69,
+	0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a,
+	0x10, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x47, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x22, 0xb5, 0x01, 0x0a, 0x09, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x47, 0x61, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x47, 0x61, 0x70, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x47, 0x61, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x52, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x75, 0x6c,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x69, 0x72, 0x73, 0x74,
+	0x53, 0x65, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x46, 0x69, 0x72, 0x73,
+	0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x48, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x50, 0x6f, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x50, 0x6f, 0x64,
+	0x73, 0x22, 0xb7, 0x01, 0x0a, 0x12, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x47, 0x61, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x29, 0x0a, 0x04, 0x47, 0x61, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x47, 0x61, 0x70, 0x52, 0x04, 0x47, 0x61, 0x70, 0x73, 0x22, 0x51, 0x0a, 0x11, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x47, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3c, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74,
+	0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x47,
+	0x61, 0x70, 0x73, 0x52, 0x09, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x22, 0x4a,
+	0x0a, 0x12, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x4f, 0x0a, 0x0d, 0x44, 0x72,
+	0x69, 0x66, 0x74, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x13,
+	0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x72,
+	0x69, 0x66, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x44, 0x72, 0x69, 0x66, 0x74,
+	0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x22, 0x54, 0x0a, 0x13, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x72, 0x69, 0x66,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x57, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x76,
+	0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x09, 0x57,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xf1, 0x01, 0x0a,
+	0x0d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24,
+	0x0a, 0x0d, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46, 0x6c, 0x6f, 0x77, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x46,
+	0x6c, 0x6f, 0x77, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x46,
+	0x6c, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x43, 0x6f, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x46, 0x6c, 0x6f, 0x77, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x46, 0x6c, 0x6f, 0x77,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x46, 0x6c, 0x6f, 0x77, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e,
+	0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x22, 0xaf, 0x02, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x6e,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x2f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x43, 0x6f,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xce, 0x01, 0x0a, 0x11, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x57, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x65,
+	0x64, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xf8, 0x01, 0x0a, 0x10, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x12, 0x2f, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x3d, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73,
+	0x69, 0x67, 0x68, 0x74, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x73, 0x12, 0x3a, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67,
+	0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x52, 0x09, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x32, 0xaf,
+	0x04, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x12, 0x3b, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x13, 0x2e, 0x76,
+	0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x75,
+	0x6c, 0x65, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e,
+	0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73,
+	0x69, 0x67, 0x68, 0x74, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x76, 0x31, 0x2e,
+	0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x76,
+	0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x54, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e,
+	0x73, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x47, 0x61, 0x70, 0x73, 0x12, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x47, 0x61, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68,
+	0x74, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x47, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x1e, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67,
+	0x68, 0x74, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67,
+	0x68, 0x74, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67,
+	0x68, 0x74, 0x2e, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x2e,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x63, 0x63, 0x75, 0x6b, 0x6e, 0x6f, 0x78, 0x2f, 0x61, 0x75, 0x74, 0x6f, 0x2d, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x2d, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x73, 0x72,
+	0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e,
+	0x73, 0x69, 0x67, 0x68, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1200,50 +3211,106 @@ func file_v1_insight_insight_proto_rawDescGZIP() []byte {
 	return file_v1_insight_insight_proto_rawDescData
 }
 
-var file_v1_insight_insight_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_v1_insight_insight_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_v1_insight_insight_proto_goTypes = []interface{}{
-	(*Request)(nil),            // 0: v1.insight.Request
-	(*InsightResponse)(nil),    // 1: v1.insight.InsightResponse
-	(*Response)(nil),           // 2: v1.insight.Response
-	(*SystemInsightData)(nil),  // 3: v1.insight.SystemInsightData
-	(*SystemData)(nil),         // 4: v1.insight.SystemData
-	(*NetworkInsightData)(nil), // 5: v1.insight.NetworkInsightData
-	(*NetworkData)(nil),        // 6: v1.insight.NetworkData
-	(*Egress)(nil),             // 7: v1.insight.Egress
-	(*SpecPort)(nil),           // 8: v1.insight.SpecPort
-	(*SpecCIDR)(nil),           // 9: v1.insight.SpecCIDR
-	(*SpecService)(nil),        // 10: v1.insight.SpecService
-	(*SpecFQDN)(nil),           // 11: v1.insight.SpecFQDN
-	(*SpecHTTP)(nil),           // 12: v1.insight.SpecHTTP
-	(*Ingress)(nil),            // 13: v1.insight.Ingress
-	nil,                        // 14: v1.insight.Egress.MatchLabelsEntry
-	nil,                        // 15: v1.insight.Ingress.MatchLabelsEntry
+	(*Request)(nil),               // 0: v1.insight.Request
+	(*InsightResponse)(nil),       // 1: v1.insight.InsightResponse
+	(*Response)(nil),              // 2: v1.insight.Response
+	(*SystemInsightData)(nil),     // 3: v1.insight.SystemInsightData
+	(*SystemData)(nil),            // 4: v1.insight.SystemData
+	(*RuleStats)(nil),             // 5: v1.insight.RuleStats
+	(*NetworkInsightData)(nil),    // 6: v1.insight.NetworkInsightData
+	(*NetworkData)(nil),           // 7: v1.insight.NetworkData
+	(*Egress)(nil),                // 8: v1.insight.Egress
+	(*SpecPort)(nil),              // 9: v1.insight.SpecPort
+	(*SpecCIDR)(nil),              // 10: v1.insight.SpecCIDR
+	(*SpecService)(nil),           // 11: v1.insight.SpecService
+	(*SpecFQDN)(nil),              // 12: v1.insight.SpecFQDN
+	(*SpecHTTP)(nil),              // 13: v1.insight.SpecHTTP
+	(*SpecGRPC)(nil),              // 14: v1.insight.SpecGRPC
+	(*SpecKafka)(nil),             // 15: v1.insight.SpecKafka
+	(*Ingress)(nil),               // 16: v1.insight.Ingress
+	(*EvidenceRequest)(nil),       // 17: v1.insight.EvidenceRequest
+	(*Evidence)(nil),              // 18: v1.insight.Evidence
+	(*EvidenceResponse)(nil),      // 19: v1.insight.EvidenceResponse
+	(*WorkloadStateRequest)(nil),  // 20: v1.insight.WorkloadStateRequest
+	(*WorkloadState)(nil),         // 21: v1.insight.WorkloadState
+	(*WorkloadStateResponse)(nil), // 22: v1.insight.WorkloadStateResponse
+	(*PolicyGapRequest)(nil),      // 23: v1.insight.PolicyGapRequest
+	(*PolicyGap)(nil),             // 24: v1.insight.PolicyGap
+	(*WorkloadPolicyGaps)(nil),    // 25: v1.insight.WorkloadPolicyGaps
+	(*PolicyGapResponse)(nil),     // 26: v1.insight.PolicyGapResponse
+	(*PolicyDriftRequest)(nil),    // 27: v1.insight.PolicyDriftRequest
+	(*DriftedPolicy)(nil),         // 28: v1.insight.DriftedPolicy
+	(*WorkloadPolicyDrift)(nil),   // 29: v1.insight.WorkloadPolicyDrift
+	(*PolicyDriftResponse)(nil),   // 30: v1.insight.PolicyDriftResponse
+	(*CoverageRequest)(nil),       // 31: v1.insight.CoverageRequest
+	(*CoverageCount)(nil),         // 32: v1.insight.CoverageCount
+	(*WorkloadCoverage)(nil),      // 33: v1.insight.WorkloadCoverage
+	(*NamespaceCoverage)(nil),     // 34: v1.insight.NamespaceCoverage
+	(*CoverageResponse)(nil),      // 35: v1.insight.CoverageResponse
+	nil,                           // 36: v1.insight.Egress.MatchLabelsEntry
+	nil,                           // 37: v1.insight.Ingress.MatchLabelsEntry
+	nil,                           // 38: v1.insight.Evidence.FieldsEntry
 }
 var file_v1_insight_insight_proto_depIdxs = []int32{
 	3,  // 0: v1.insight.InsightResponse.SystemResource:type_name -> v1.insight.SystemInsightData
-	5,  // 1: v1.insight.InsightResponse.NetworkResource:type_name -> v1.insight.NetworkInsightData
-	1,  // 2: v1.insight.Response.Res:type_name -> v1.insight.InsightResponse
-	4,  // 3: v1.insight.SystemInsightData.SysResource:type_name -> v1.insight.SystemData
-	6,  // 4: v1.insight.NetworkInsightData.NetResource:type_name -> v1.insight.NetworkData
-	7,  // 5: v1.insight.NetworkData.Egressess:type_name -> v1.insight.Egress
-	13, // 6: v1.insight.NetworkData.Ingressess:type_name -> v1.insight.Ingress
-	14, // 7: v1.insight.Egress.MatchLabels:type_name -> v1.insight.Egress.MatchLabelsEntry
-	8,  // 8: v1.insight.Egress.ToPorts:type_name -> v1.insight.SpecPort
-	9,  // 9: v1.insight.Egress.ToCIDRs:type_name -> v1.insight.SpecCIDR
-	10, // 10: v1.insight.Egress.ToServices:type_name -> v1.insight.SpecService
-	11, // 11: v1.insight.Egress.ToFQDNs:type_name -> v1.insight.SpecFQDN
-	12, // 12: v1.insight.Egress.ToHTTPs:type_name -> v1.insight.SpecHTTP
-	15, // 13: v1.insight.Ingress.MatchLabels:type_name -> v1.insight.Ingress.MatchLabelsEntry
-	8,  // 14: v1.insight.Ingress.ToPorts:type_name -> v1.insight.SpecPort
-	12, // 15: v1.insight.Ingress.ToHTTPs:type_name -> v1.insight.SpecHTTP
-	9,  // 16: v1.insight.Ingress.FromCIDRs:type_name -> v1.insight.SpecCIDR
-	0,  // 17: v1.insight.Insight.GetInsightData:input_type -> v1.insight.Request
-	2,  // 18: v1.insight.Insight.GetInsightData:output_type -> v1.insight.Response
-	18, // [18:19] is the sub-list for method output_type
-	17, // [17:18] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	6,  // 1: v1.insight.InsightResponse.NetworkResource:type_name -> v1.insight.NetworkInsightData
+	21, // 2: v1.insight.InsightResponse.WorkloadStates:type_name -> v1.insight.WorkloadState
+	1,  // 3: v1.insight.Response.Res:type_name -> v1.insight.InsightResponse
+	4,  // 4: v1.insight.SystemInsightData.SysResource:type_name -> v1.insight.SystemData
+	5,  // 5: v1.insight.SystemData.ruleStats:type_name -> v1.insight.RuleStats
+	7,  // 6: v1.insight.NetworkInsightData.NetResource:type_name -> v1.insight.NetworkData
+	8,  // 7: v1.insight.NetworkData.Egressess:type_name -> v1.insight.Egress
+	16, // 8: v1.insight.NetworkData.Ingressess:type_name -> v1.insight.Ingress
+	36, // 9: v1.insight.Egress.MatchLabels:type_name -> v1.insight.Egress.MatchLabelsEntry
+	9,  // 10: v1.insight.Egress.ToPorts:type_name -> v1.insight.SpecPort
+	10, // 11: v1.insight.Egress.ToCIDRs:type_name -> v1.insight.SpecCIDR
+	11, // 12: v1.insight.Egress.ToServices:type_name -> v1.insight.SpecService
+	12, // 13: v1.insight.Egress.ToFQDNs:type_name -> v1.insight.SpecFQDN
+	13, // 14: v1.insight.Egress.ToHTTPs:type_name -> v1.insight.SpecHTTP
+	5,  // 15: v1.insight.Egress.RuleStats:type_name -> v1.insight.RuleStats
+	14, // 16: v1.insight.Egress.ToGRPCs:type_name -> v1.insight.SpecGRPC
+	15, // 17: v1.insight.Egress.ToKafkas:type_name -> v1.insight.SpecKafka
+	37, // 18: v1.insight.Ingress.MatchLabels:type_name -> v1.insight.Ingress.MatchLabelsEntry
+	9,  // 19: v1.insight.Ingress.ToPorts:type_name -> v1.insight.SpecPort
+	13, // 20: v1.insight.Ingress.ToHTTPs:type_name -> v1.insight.SpecHTTP
+	10, // 21: v1.insight.Ingress.FromCIDRs:type_name -> v1.insight.SpecCIDR
+	5,  // 22: v1.insight.Ingress.RuleStats:type_name -> v1.insight.RuleStats
+	14, // 23: v1.insight.Ingress.ToGRPCs:type_name -> v1.insight.SpecGRPC
+	15, // 24: v1.insight.Ingress.ToKafkas:type_name -> v1.insight.SpecKafka
+	38, // 25: v1.insight.Evidence.fields:type_name -> v1.insight.Evidence.FieldsEntry
+	5,  // 26: v1.insight.EvidenceResponse.ruleStats:type_name -> v1.insight.RuleStats
+	18, // 27: v1.insight.EvidenceResponse.evidence:type_name -> v1.insight.Evidence
+	21, // 28: v1.insight.WorkloadStateResponse.States:type_name -> v1.insight.WorkloadState
+	24, // 29: v1.insight.WorkloadPolicyGaps.Gaps:type_name -> v1.insight.PolicyGap
+	25, // 30: v1.insight.PolicyGapResponse.Workloads:type_name -> v1.insight.WorkloadPolicyGaps
+	28, // 31: v1.insight.WorkloadPolicyDrift.Policies:type_name -> v1.insight.DriftedPolicy
+	29, // 32: v1.insight.PolicyDriftResponse.Workloads:type_name -> v1.insight.WorkloadPolicyDrift
+	32, // 33: v1.insight.WorkloadCoverage.Count:type_name -> v1.insight.CoverageCount
+	32, // 34: v1.insight.NamespaceCoverage.Count:type_name -> v1.insight.CoverageCount
+	32, // 35: v1.insight.CoverageResponse.Count:type_name -> v1.insight.CoverageCount
+	34, // 36: v1.insight.CoverageResponse.Namespaces:type_name -> v1.insight.NamespaceCoverage
+	33, // 37: v1.insight.CoverageResponse.Workloads:type_name -> v1.insight.WorkloadCoverage
+	0,  // 38: v1.insight.Insight.GetInsightData:input_type -> v1.insight.Request
+	17, // 39: v1.insight.Insight.GetRuleEvidence:input_type -> v1.insight.EvidenceRequest
+	20, // 40: v1.insight.Insight.GetWorkloadStates:input_type -> v1.insight.WorkloadStateRequest
+	20, // 41: v1.insight.Insight.WatchWorkloadStates:input_type -> v1.insight.WorkloadStateRequest
+	23, // 42: v1.insight.Insight.GetPolicyGaps:input_type -> v1.insight.PolicyGapRequest
+	27, // 43: v1.insight.Insight.GetPolicyDrift:input_type -> v1.insight.PolicyDriftRequest
+	31, // 44: v1.insight.Insight.GetCoverage:input_type -> v1.insight.CoverageRequest
+	2,  // 45: v1.insight.Insight.GetInsightData:output_type -> v1.insight.Response
+	19, // 46: v1.insight.Insight.GetRuleEvidence:output_type -> v1.insight.EvidenceResponse
+	22, // 47: v1.insight.Insight.GetWorkloadStates:output_type -> v1.insight.WorkloadStateResponse
+	21, // 48: v1.insight.Insight.WatchWorkloadStates:output_type -> v1.insight.WorkloadState
+	26, // 49: v1.insight.Insight.GetPolicyGaps:output_type -> v1.insight.PolicyGapResponse
+	30, // 50: v1.insight.Insight.GetPolicyDrift:output_type -> v1.insight.PolicyDriftResponse
+	35, // 51: v1.insight.Insight.GetCoverage:output_type -> v1.insight.CoverageResponse
+	45, // [45:52] is the sub-list for method output_type
+	38, // [38:45] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_v1_insight_insight_proto_init() }
@@ -1313,7 +3380,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkInsightData); i {
+			switch v := v.(*RuleStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1325,7 +3392,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkData); i {
+			switch v := v.(*NetworkInsightData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1337,7 +3404,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Egress); i {
+			switch v := v.(*NetworkData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1349,7 +3416,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecPort); i {
+			switch v := v.(*Egress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1361,7 +3428,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecCIDR); i {
+			switch v := v.(*SpecPort); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1373,7 +3440,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecService); i {
+			switch v := v.(*SpecCIDR); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1385,7 +3452,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecFQDN); i {
+			switch v := v.(*SpecService); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1397,7 +3464,7 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SpecHTTP); i {
+			switch v := v.(*SpecFQDN); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1409,6 +3476,42 @@ func file_v1_insight_insight_proto_init() {
 			}
 		}
 		file_v1_insight_insight_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpecHTTP); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpecGRPC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpecKafka); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Ingress); i {
 			case 0:
 				return &v.state
@@ -1420,6 +3523,234 @@ func file_v1_insight_insight_proto_init() {
 				return nil
 			}
 		}
+		file_v1_insight_insight_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvidenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Evidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvidenceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyGapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyGap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadPolicyGaps); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyGapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyDriftRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DriftedPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadPolicyDrift); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyDriftResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CoverageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CoverageCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadCoverage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamespaceCoverage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_insight_insight_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CoverageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1427,7 +3758,7 @@ func file_v1_insight_insight_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_insight_insight_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
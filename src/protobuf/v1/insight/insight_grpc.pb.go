@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Insight_GetInsightData_FullMethodName = "/v1.insight.Insight/GetInsightData"
+	Insight_GetInsightData_FullMethodName      = "/v1.insight.Insight/GetInsightData"
+	Insight_GetRuleEvidence_FullMethodName     = "/v1.insight.Insight/GetRuleEvidence"
+	Insight_GetWorkloadStates_FullMethodName   = "/v1.insight.Insight/GetWorkloadStates"
+	Insight_WatchWorkloadStates_FullMethodName = "/v1.insight.Insight/WatchWorkloadStates"
+	Insight_GetPolicyGaps_FullMethodName       = "/v1.insight.Insight/GetPolicyGaps"
+	Insight_GetPolicyDrift_FullMethodName      = "/v1.insight.Insight/GetPolicyDrift"
+	Insight_GetCoverage_FullMethodName         = "/v1.insight.Insight/GetCoverage"
 )
 
 // InsightClient is the client API for Insight service.
@@ -27,6 +33,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type InsightClient interface {
 	GetInsightData(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	GetRuleEvidence(ctx context.Context, in *EvidenceRequest, opts ...grpc.CallOption) (*EvidenceResponse, error)
+	GetWorkloadStates(ctx context.Context, in *WorkloadStateRequest, opts ...grpc.CallOption) (*WorkloadStateResponse, error)
+	WatchWorkloadStates(ctx context.Context, in *WorkloadStateRequest, opts ...grpc.CallOption) (Insight_WatchWorkloadStatesClient, error)
+	GetPolicyGaps(ctx context.Context, in *PolicyGapRequest, opts ...grpc.CallOption) (*PolicyGapResponse, error)
+	GetPolicyDrift(ctx context.Context, in *PolicyDriftRequest, opts ...grpc.CallOption) (*PolicyDriftResponse, error)
+	GetCoverage(ctx context.Context, in *CoverageRequest, opts ...grpc.CallOption) (*CoverageResponse, error)
 }
 
 type insightClient struct {
@@ -46,11 +58,94 @@ func (c *insightClient) GetInsightData(ctx context.Context, in *Request, opts ..
 	return out, nil
 }
 
+func (c *insightClient) GetRuleEvidence(ctx context.Context, in *EvidenceRequest, opts ...grpc.CallOption) (*EvidenceResponse, error) {
+	out := new(EvidenceResponse)
+	err := c.cc.Invoke(ctx, Insight_GetRuleEvidence_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *insightClient) GetWorkloadStates(ctx context.Context, in *WorkloadStateRequest, opts ...grpc.CallOption) (*WorkloadStateResponse, error) {
+	out := new(WorkloadStateResponse)
+	err := c.cc.Invoke(ctx, Insight_GetWorkloadStates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *insightClient) WatchWorkloadStates(ctx context.Context, in *WorkloadStateRequest, opts ...grpc.CallOption) (Insight_WatchWorkloadStatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Insight_ServiceDesc.Streams[0], Insight_WatchWorkloadStates_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &insightWatchWorkloadStatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Insight_WatchWorkloadStatesClient interface {
+	Recv() (*WorkloadState, error)
+	grpc.ClientStream
+}
+
+type insightWatchWorkloadStatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *insightWatchWorkloadStatesClient) Recv() (*WorkloadState, error) {
+	m := new(WorkloadState)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *insightClient) GetPolicyGaps(ctx context.Context, in *PolicyGapRequest, opts ...grpc.CallOption) (*PolicyGapResponse, error) {
+	out := new(PolicyGapResponse)
+	err := c.cc.Invoke(ctx, Insight_GetPolicyGaps_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *insightClient) GetPolicyDrift(ctx context.Context, in *PolicyDriftRequest, opts ...grpc.CallOption) (*PolicyDriftResponse, error) {
+	out := new(PolicyDriftResponse)
+	err := c.cc.Invoke(ctx, Insight_GetPolicyDrift_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *insightClient) GetCoverage(ctx context.Context, in *CoverageRequest, opts ...grpc.CallOption) (*CoverageResponse, error) {
+	out := new(CoverageResponse)
+	err := c.cc.Invoke(ctx, Insight_GetCoverage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InsightServer is the server API for Insight service.
 // All implementations must embed UnimplementedInsightServer
 // for forward compatibility
 type InsightServer interface {
 	GetInsightData(context.Context, *Request) (*Response, error)
+	GetRuleEvidence(context.Context, *EvidenceRequest) (*EvidenceResponse, error)
+	GetWorkloadStates(context.Context, *WorkloadStateRequest) (*WorkloadStateResponse, error)
+	WatchWorkloadStates(*WorkloadStateRequest, Insight_WatchWorkloadStatesServer) error
+	GetPolicyGaps(context.Context, *PolicyGapRequest) (*PolicyGapResponse, error)
+	GetPolicyDrift(context.Context, *PolicyDriftRequest) (*PolicyDriftResponse, error)
+	GetCoverage(context.Context, *CoverageRequest) (*CoverageResponse, error)
 	mustEmbedUnimplementedInsightServer()
 }
 
@@ -61,6 +156,24 @@ type UnimplementedInsightServer struct {
 func (UnimplementedInsightServer) GetInsightData(context.Context, *Request) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInsightData not implemented")
 }
+func (UnimplementedInsightServer) GetRuleEvidence(context.Context, *EvidenceRequest) (*EvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRuleEvidence not implemented")
+}
+func (UnimplementedInsightServer) GetWorkloadStates(context.Context, *WorkloadStateRequest) (*WorkloadStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkloadStates not implemented")
+}
+func (UnimplementedInsightServer) WatchWorkloadStates(*WorkloadStateRequest, Insight_WatchWorkloadStatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchWorkloadStates not implemented")
+}
+func (UnimplementedInsightServer) GetPolicyGaps(context.Context, *PolicyGapRequest) (*PolicyGapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolicyGaps not implemented")
+}
+func (UnimplementedInsightServer) GetPolicyDrift(context.Context, *PolicyDriftRequest) (*PolicyDriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolicyDrift not implemented")
+}
+func (UnimplementedInsightServer) GetCoverage(context.Context, *CoverageRequest) (*CoverageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCoverage not implemented")
+}
 func (UnimplementedInsightServer) mustEmbedUnimplementedInsightServer() {}
 
 // UnsafeInsightServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +205,117 @@ func _Insight_GetInsightData_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Insight_GetRuleEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InsightServer).GetRuleEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Insight_GetRuleEvidence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InsightServer).GetRuleEvidence(ctx, req.(*EvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Insight_GetWorkloadStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkloadStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InsightServer).GetWorkloadStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Insight_GetWorkloadStates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InsightServer).GetWorkloadStates(ctx, req.(*WorkloadStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Insight_WatchWorkloadStates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WorkloadStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InsightServer).WatchWorkloadStates(m, &insightWatchWorkloadStatesServer{stream})
+}
+
+type Insight_WatchWorkloadStatesServer interface {
+	Send(*WorkloadState) error
+	grpc.ServerStream
+}
+
+type insightWatchWorkloadStatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *insightWatchWorkloadStatesServer) Send(m *WorkloadState) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Insight_GetPolicyGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyGapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InsightServer).GetPolicyGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Insight_GetPolicyGaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InsightServer).GetPolicyGaps(ctx, req.(*PolicyGapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Insight_GetPolicyDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InsightServer).GetPolicyDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Insight_GetPolicyDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InsightServer).GetPolicyDrift(ctx, req.(*PolicyDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Insight_GetCoverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CoverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InsightServer).GetCoverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Insight_GetCoverage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InsightServer).GetCoverage(ctx, req.(*CoverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Insight_ServiceDesc is the grpc.ServiceDesc for Insight service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,7 +327,33 @@ var Insight_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInsightData",
 			Handler:    _Insight_GetInsightData_Handler,
 		},
+		{
+			MethodName: "GetRuleEvidence",
+			Handler:    _Insight_GetRuleEvidence_Handler,
+		},
+		{
+			MethodName: "GetWorkloadStates",
+			Handler:    _Insight_GetWorkloadStates_Handler,
+		},
+		{
+			MethodName: "GetPolicyGaps",
+			Handler:    _Insight_GetPolicyGaps_Handler,
+		},
+		{
+			MethodName: "GetPolicyDrift",
+			Handler:    _Insight_GetPolicyDrift_Handler,
+		},
+		{
+			MethodName: "GetCoverage",
+			Handler:    _Insight_GetCoverage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchWorkloadStates",
+			Handler:       _Insight_WatchWorkloadStates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/insight/insight.proto",
 }
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Publisher_GetSummary_FullMethodName = "/v1.publisher.Publisher/GetSummary"
+	Publisher_GetSummary_FullMethodName   = "/v1.publisher.Publisher/GetSummary"
+	Publisher_GetAnomalies_FullMethodName = "/v1.publisher.Publisher/GetAnomalies"
+	Publisher_AckAnomaly_FullMethodName   = "/v1.publisher.Publisher/AckAnomaly"
 )
 
 // PublisherClient is the client API for Publisher service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PublisherClient interface {
 	GetSummary(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (Publisher_GetSummaryClient, error)
+	GetAnomalies(ctx context.Context, in *AnomalyRequest, opts ...grpc.CallOption) (Publisher_GetAnomaliesClient, error)
+	AckAnomaly(ctx context.Context, in *AckAnomalyRequest, opts ...grpc.CallOption) (*AckAnomalyResponse, error)
 }
 
 type publisherClient struct {
@@ -69,11 +73,54 @@ func (x *publisherGetSummaryClient) Recv() (*SummaryResponse, error) {
 	return m, nil
 }
 
+func (c *publisherClient) GetAnomalies(ctx context.Context, in *AnomalyRequest, opts ...grpc.CallOption) (Publisher_GetAnomaliesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Publisher_ServiceDesc.Streams[1], Publisher_GetAnomalies_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &publisherGetAnomaliesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Publisher_GetAnomaliesClient interface {
+	Recv() (*Anomaly, error)
+	grpc.ClientStream
+}
+
+type publisherGetAnomaliesClient struct {
+	grpc.ClientStream
+}
+
+func (x *publisherGetAnomaliesClient) Recv() (*Anomaly, error) {
+	m := new(Anomaly)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *publisherClient) AckAnomaly(ctx context.Context, in *AckAnomalyRequest, opts ...grpc.CallOption) (*AckAnomalyResponse, error) {
+	out := new(AckAnomalyResponse)
+	err := c.cc.Invoke(ctx, Publisher_AckAnomaly_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PublisherServer is the server API for Publisher service.
 // All implementations must embed UnimplementedPublisherServer
 // for forward compatibility
 type PublisherServer interface {
 	GetSummary(*SummaryRequest, Publisher_GetSummaryServer) error
+	GetAnomalies(*AnomalyRequest, Publisher_GetAnomaliesServer) error
+	AckAnomaly(context.Context, *AckAnomalyRequest) (*AckAnomalyResponse, error)
 	mustEmbedUnimplementedPublisherServer()
 }
 
@@ -84,6 +131,12 @@ type UnimplementedPublisherServer struct {
 func (UnimplementedPublisherServer) GetSummary(*SummaryRequest, Publisher_GetSummaryServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetSummary not implemented")
 }
+func (UnimplementedPublisherServer) GetAnomalies(*AnomalyRequest, Publisher_GetAnomaliesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAnomalies not implemented")
+}
+func (UnimplementedPublisherServer) AckAnomaly(context.Context, *AckAnomalyRequest) (*AckAnomalyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckAnomaly not implemented")
+}
 func (UnimplementedPublisherServer) mustEmbedUnimplementedPublisherServer() {}
 
 // UnsafePublisherServer may be embedded to opt out of forward compatibility for this service.
@@ -118,19 +171,68 @@ func (x *publisherGetSummaryServer) Send(m *SummaryResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Publisher_GetAnomalies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AnomalyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PublisherServer).GetAnomalies(m, &publisherGetAnomaliesServer{stream})
+}
+
+type Publisher_GetAnomaliesServer interface {
+	Send(*Anomaly) error
+	grpc.ServerStream
+}
+
+type publisherGetAnomaliesServer struct {
+	grpc.ServerStream
+}
+
+func (x *publisherGetAnomaliesServer) Send(m *Anomaly) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Publisher_AckAnomaly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckAnomalyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublisherServer).AckAnomaly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Publisher_AckAnomaly_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublisherServer).AckAnomaly(ctx, req.(*AckAnomalyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Publisher_ServiceDesc is the grpc.ServiceDesc for Publisher service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Publisher_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.publisher.Publisher",
 	HandlerType: (*PublisherServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AckAnomaly",
+			Handler:    _Publisher_AckAnomaly_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetSummary",
 			Handler:       _Publisher_GetSummary_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetAnomalies",
+			Handler:       _Publisher_GetAnomalies_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "v1/publisher/publisher.proto",
 }
@@ -204,10 +204,10 @@ func createPolicy(ms types.MatchSpec, name, namespace string, labels LabelMap) (
 
 func addPolicyRule(policy *types.KnoxSystemPolicy, r *types.KnoxSystemSpec) {
 
-	if r.File.MatchDirectories != nil || r.File.MatchPaths != nil {
+	if r.File.MatchDirectories != nil || r.File.MatchPaths != nil || r.File.MatchPatterns != nil {
 		policy.Spec.File = r.File
 	}
-	if r.Process.MatchPaths != nil || r.Process.MatchDirectories != nil {
+	if r.Process.MatchPaths != nil || r.Process.MatchDirectories != nil || r.Process.MatchPatterns != nil {
 		policy.Spec.Process = r.Process
 	}
 	if r.Network.MatchProtocols != nil {
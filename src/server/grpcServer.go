@@ -14,6 +14,7 @@ import (
 	logger "github.com/accuknox/auto-policy-discovery/src/logging"
 	network "github.com/accuknox/auto-policy-discovery/src/networkpolicy"
 	obs "github.com/accuknox/auto-policy-discovery/src/observability"
+	"github.com/accuknox/auto-policy-discovery/src/policydrift"
 	recommend "github.com/accuknox/auto-policy-discovery/src/recommendpolicy"
 	system "github.com/accuknox/auto-policy-discovery/src/systempolicy"
 
@@ -253,6 +254,44 @@ func (s *insightServer) GetInsightData(ctx context.Context, in *ipb.Request) (*i
 	return &resp, err
 }
 
+func (s *insightServer) GetRuleEvidence(ctx context.Context, in *ipb.EvidenceRequest) (*ipb.EvidenceResponse, error) {
+	return insight.GetRuleEvidence(in.GetPolicyName(), int(in.GetRuleIndex()))
+}
+
+func (s *insightServer) GetWorkloadStates(ctx context.Context, in *ipb.WorkloadStateRequest) (*ipb.WorkloadStateResponse, error) {
+	return insight.GetWorkloadStates(in)
+}
+
+func (s *insightServer) GetPolicyGaps(ctx context.Context, in *ipb.PolicyGapRequest) (*ipb.PolicyGapResponse, error) {
+	return insight.GetPolicyGaps(in)
+}
+
+func (s *insightServer) GetPolicyDrift(ctx context.Context, in *ipb.PolicyDriftRequest) (*ipb.PolicyDriftResponse, error) {
+	return insight.GetPolicyDrift(in)
+}
+
+func (s *insightServer) GetCoverage(ctx context.Context, in *ipb.CoverageRequest) (*ipb.CoverageResponse, error) {
+	return insight.GetCoverage(in)
+}
+
+func (s *insightServer) WatchWorkloadStates(in *ipb.WorkloadStateRequest, srv ipb.Insight_WatchWorkloadStatesServer) error {
+	consumer := libs.NewWorkloadStateConsumer(insight.ConvertWorkloadStateRequest(in))
+	libs.WorkloadStateEvents.AddConsumer(consumer)
+	defer libs.WorkloadStateEvents.RemoveConsumer(consumer)
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			// client disconnected
+			return nil
+		case state := <-consumer.Events:
+			if err := srv.Send(insight.ConvertWorkloadStateToPb(*state)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // =================== //
 // == Observability == //
 // =================== //
@@ -287,6 +326,43 @@ func (ps *publisherServer) GetSummary(req *ppb.SummaryRequest, srv ppb.Publisher
 	return obs.SysSummary.RelaySummaryEventToGrpcStream(srv, consumer)
 }
 
+func (ps *publisherServer) GetAnomalies(req *ppb.AnomalyRequest, srv ppb.Publisher_GetAnomaliesServer) error {
+	consumer := libs.NewAnomalyConsumer(obs.ConvertAnomalyRequest(req))
+	libs.AnomalyEvents.AddConsumer(consumer)
+	defer libs.AnomalyEvents.RemoveConsumer(consumer)
+
+	return obs.RelayAnomalyEventToGrpcStream(srv, consumer)
+}
+
+func (ps *publisherServer) AckAnomaly(ctx context.Context, req *ppb.AckAnomalyRequest) (*ppb.AckAnomalyResponse, error) {
+	anomalies, err := libs.GetAnomalies(core.GetCfgDB(), types.Anomaly{PolicyType: req.GetPolicyType(), RuleKey: req.GetAnomalyId()})
+	if err != nil {
+		return nil, err
+	}
+	if len(anomalies) == 0 {
+		return nil, errors.New("no anomaly " + req.GetAnomalyId())
+	}
+
+	anomaly := anomalies[0]
+	if anomaly.Status != types.AnomalyStatusAcknowledged {
+		if anomaly.PolicyType == types.PolicyTypeNetwork {
+			err = network.AckNetworkAnomaly(anomaly)
+		} else {
+			err = system.AckSysAnomaly(anomaly)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		anomaly.Status = types.AnomalyStatusAcknowledged
+		if err := libs.UpdateAnomaly(core.GetCfgDB(), anomaly); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ppb.AckAnomalyResponse{Anomaly: obs.ConvertAnomalyToPb(anomaly)}, nil
+}
+
 // ================= //
 // == gRPC server == //
 // ================= //
@@ -334,5 +410,8 @@ func GetNewServer() *grpc.Server {
 	//start recommendation
 	recommend.StartRecommendWorker()
 
+	// start policy drift detection
+	policydrift.StartDriftDetection()
+
 	return s
 }
@@ -0,0 +1,100 @@
+package systempolicy
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+)
+
+// =============== //
+// == Anomalies == //
+// =============== //
+
+// applySysAnomalyMode records the new resources of the file set of the stable
+// workloads as anomalies rather than widening their policies, and returns the
+// resources to be merged. The resources covered by the existing file set are
+// kept.
+func applySysAnomalyMode(wpfs types.WorkloadProcessFileSet, fs, existFs []string,
+	observed map[string]types.RuleStats, workloads map[string]bool) []string {
+	labels := libs.WorkloadLabels(strings.Split(wpfs.Labels, ","))
+	if !workloads[labels] {
+		return fs
+	}
+
+	proposed := []string{}
+
+	for _, resource := range fs {
+		known := false
+		for _, rule := range existFs {
+			if ruleCoversResource(rule, resource) {
+				known = true
+				break
+			}
+		}
+		if known {
+			proposed = append(proposed, resource)
+			continue
+		}
+
+		key, candidate := sysRuleKey(wpfs, resource)
+		libs.RecordAnomaly(CfgDB, types.Anomaly{
+			ClusterName: wpfs.ClusterName,
+			Namespace:   wpfs.Namespace,
+			Labels:      labels,
+			PolicyType:  types.PolicyTypeSystem,
+			RuleKey:     key,
+			Candidate:   candidate,
+			RuleStats:   observed[resource],
+		})
+		delete(observed, resource)
+	}
+
+	return proposed
+}
+
+// AckSysAnomaly merges the resource of the acknowledged anomaly into the file
+// set of the workload, and regenerates the system policies
+func AckSysAnomaly(anomaly types.Anomaly) error {
+	SystemPolicyLock.Lock()
+	defer SystemPolicyLock.Unlock()
+
+	candidate := map[string]string{}
+	if err := json.Unmarshal([]byte(anomaly.Candidate), &candidate); err != nil {
+		return err
+	}
+	if candidate["resource"] == "" {
+		return errors.New("no resource in the anomaly " + anomaly.RuleKey)
+	}
+
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName:   candidate["clusterName"],
+		Namespace:     candidate["namespace"],
+		ContainerName: candidate["containerName"],
+		Labels:        candidate["labels"],
+		FromSource:    candidate["fromSource"],
+		SetType:       candidate["setType"],
+	}
+
+	out, _, err := libs.GetWorkloadProcessFileSet(CfgDB, wpfs)
+	if err != nil {
+		return err
+	}
+
+	fs := removeDuplicates(append(out[wpfs], candidate["resource"]))
+	if err := saveWorkloadProcessFileSet(wpfs, fs, len(out[wpfs]) > 0); err != nil {
+		return err
+	}
+
+	// matchProtocols do not keep the rule statistics
+	if wpfs.SetType != SYS_OP_NETWORK {
+		updateSysRuleStats(wpfs, fs, map[string]types.RuleStats{candidate["resource"]: anomaly.RuleStats})
+	}
+
+	UpdateSysPolicies([]types.KnoxSystemPolicy{})
+
+	log.Info().Msgf("anomaly of the workload %s/%s is merged into the policy", anomaly.Namespace, anomaly.Labels)
+	return nil
+}
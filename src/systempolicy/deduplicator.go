@@ -1,7 +1,9 @@
 package systempolicy
 
 import (
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,39 +34,91 @@ func IsExistingPolicy(existingPolicies []types.KnoxSystemPolicy, newPolicy types
 // == Policy Name Check == //
 // ======================= //
 
-func existPolicyName(policyNamesMap map[string]bool, name string) bool {
-	if _, ok := policyNamesMap[name]; ok {
-		return true
-	}
-
-	return false
-}
-
 func GeneratePolicyName(policyNamesMap map[string]bool, policy types.KnoxSystemPolicy, clusterName string) types.KnoxSystemPolicy {
-	procPrefix := "autopol-process-"
-	filePrefix := "autopol-file-"
-	netPrefix := "autopol-network-"
-
 	polType := strings.ToLower(policy.Metadata["type"])
-	name := "autopol-" + polType + "-" + libs.RandSeq(15)
-
-	for existPolicyName(policyNamesMap, name) {
-		if polType == "file" {
-			name = filePrefix + libs.RandSeq(15)
-		} else if polType == "process" {
-			name = procPrefix + libs.RandSeq(15)
-		} else { // network
-			name = netPrefix + libs.RandSeq(15)
-		}
+
+	labels := []string{}
+	for k, v := range policy.Spec.Selector.MatchLabels {
+		labels = append(labels, k+"="+v)
 	}
+	sort.Strings(labels)
+
+	name := libs.GeneratePolicyNameFromTemplate(PolicyNameTemplate, libs.PolicyNameFields{
+		Type:      polType,
+		Workload:  libs.GetWorkloadNameFromLabels(policy.Spec.Selector.MatchLabels),
+		Namespace: policy.Metadata["namespace"],
+		Cluster:   clusterName,
+		Hash: libs.PolicyNameHash(polType, strings.Join(labels, ","), policy.Metadata["namespace"],
+			clusterName, policy.Metadata["fromSource"]),
+	})
 
+	// the same name generated for another policy
+	name = libs.ResolvePolicyNameCollision(policyNamesMap, name)
 	policyNamesMap[name] = true
+
 	policy.Metadata["name"] = name
 	policy.Metadata["clusterName"] = clusterName
 
 	return policy
 }
 
+// legacyPolicyName matches the random names generated before the name template
+var legacyPolicyName = regexp.MustCompile(`^autopol-(file|process|network)-[a-z]{15}$`)
+
+// legacyWPFSPolicyName returns the name given to the policy generated from the
+// WPFS before the name template
+func legacyWPFSPolicyName(pol types.KnoxSystemPolicy) string {
+	hashInt := common.HashInt(pol.Metadata["labels"] + pol.Metadata["namespace"] + pol.Metadata["containername"])
+	return "autopol-system-" + strconv.FormatUint(uint64(hashInt), 10)
+}
+
+// MigratePolicyNames renames the system policies having the legacy names in the
+// db with the name template. Every version of a policy is renamed to the same name.
+func MigratePolicyNames() {
+	renamed := map[string]string{}
+
+	// step 1: the policies generated from the WPFS
+	for _, pol := range populateKnoxSysPolicyFromWPFSDb("", "", "", "") {
+		oldName := legacyWPFSPolicyName(pol)
+		if oldName != pol.Metadata["name"] {
+			renamed[oldName] = pol.Metadata["name"]
+		}
+	}
+
+	// step 2: the policies discovered from the system logs, the outdated versions
+	// are renamed along with the latest one
+	policies := libs.GetSystemPolicies(CfgDB, "", "latest")
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].GeneratedTime < policies[j].GeneratedTime
+	})
+
+	policyNamesMap := map[string]bool{}
+	for _, policy := range policies {
+		policyNamesMap[policy.Metadata["name"]] = true
+	}
+
+	for _, policy := range policies {
+		oldName := policy.Metadata["name"]
+		if !legacyPolicyName.MatchString(oldName) {
+			continue
+		}
+		if _, ok := renamed[oldName]; ok {
+			continue
+		}
+
+		delete(policyNamesMap, oldName)
+		renamed[oldName] = GeneratePolicyName(policyNamesMap, policy, policy.Metadata["clusterName"]).Metadata["name"]
+	}
+
+	for oldName, newName := range renamed {
+		if err := libs.RenamePolicy(CfgDB, types.PolicyTypeSystem, oldName, newName); err != nil {
+			log.Error().Msgf("failed to rename system policy %s to %s err=%s", oldName, newName, err.Error())
+		}
+	}
+
+	log.Info().Msgf("migrated %d system policy names", len(renamed))
+}
+
 // ======================= //
 // == Process Operation == //
 // ======================= //
@@ -114,8 +168,12 @@ func UpdateProcessOperation(newPolicy types.KnoxSystemPolicy, existingPolicies [
 
 	mergedSysPaths := common.MergeAndAggregatePaths(dirs, paths)
 
+	// matchPatterns are not aggregated, just carried forward from the latest one
+	patterns := newPolicy.Spec.Process.MatchPatterns
+	mergeMatchPatterns(latestPolicy.Spec.Process.MatchPatterns, &patterns)
+
 	// step 4: init and updated proecss spec
-	newPolicy.Spec.Process = types.KnoxSys{} // init
+	newPolicy.Spec.Process = types.KnoxSys{MatchPatterns: patterns} // init
 	for _, pathSpec := range mergedSysPaths {
 		if pathSpec.IsDir {
 			matchDirs := types.KnoxMatchDirectories{
@@ -254,8 +312,12 @@ func UpdateFileOperation(newPolicy types.KnoxSystemPolicy, existingPolicies []ty
 
 	mergedSysPaths := common.MergeAndAggregatePaths(dirs, paths)
 
+	// matchPatterns are not aggregated, just carried forward from the latest one
+	patterns := newPolicy.Spec.File.MatchPatterns
+	mergeMatchPatterns(latestPolicy.Spec.File.MatchPatterns, &patterns)
+
 	// step 4: init and updated file spec
-	newPolicy.Spec.File = types.KnoxSys{} // init
+	newPolicy.Spec.File = types.KnoxSys{MatchPatterns: patterns} // init
 	for _, pathSpec := range mergedSysPaths {
 		if pathSpec.IsDir {
 			matchDirs := types.KnoxMatchDirectories{
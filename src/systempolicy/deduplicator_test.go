@@ -1,6 +1,7 @@
 package systempolicy
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/accuknox/auto-policy-discovery/src/libs"
@@ -54,3 +55,29 @@ func TestGeneratePolicyName(t *testing.T) {
 
 	assert.Equal(t, updated.Metadata["clusterName"], "testcluster")
 }
+
+func TestGeneratePolicyName_Stable(t *testing.T) {
+	policy := types.KnoxSystemPolicy{
+		Metadata: map[string]string{
+			"type":      SYS_OP_FILE,
+			"namespace": "default",
+		},
+		Spec: types.KnoxSystemSpec{
+			Selector: types.Selector{
+				MatchLabels: map[string]string{
+					"app": "test1",
+				},
+			},
+		},
+	}
+
+	first := GeneratePolicyName(map[string]bool{}, policy, "testcluster").Metadata["name"]
+	assert.True(t, strings.HasPrefix(first, "autopol-file-test1-"))
+
+	second := GeneratePolicyName(map[string]bool{}, policy, "testcluster").Metadata["name"]
+	assert.Equal(t, first, second)
+
+	// collision with an existing policy
+	third := GeneratePolicyName(map[string]bool{first: true}, policy, "testcluster").Metadata["name"]
+	assert.Equal(t, first+"-2", third)
+}
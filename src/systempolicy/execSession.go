@@ -0,0 +1,207 @@
+package systempolicy
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// =================== //
+// == Exec Sessions == //
+// =================== //
+
+// the processes not seen within the ttl are removed from the process trees
+const processTreeTTL = int64(3600)
+
+// the container runtime processes spawning the exec sessions
+var execSessionParents = []string{"runc", "containerd-shim", "conmon", "crun"}
+
+// procNode is a process of the container process tree
+type procNode struct {
+	ppid     int32
+	root     bool // spawned by the container runtime, the entrypoint or an exec session
+	lastSeen int64
+}
+
+// the process trees are kept across the discovery cycles, since the parent of
+// a process could be logged in the previous log batch
+var processTrees = map[string]map[int32]*procNode{} // key: container - val: pid -> process
+var processTreesLock = sync.Mutex{}
+
+func containerKey(slog types.KnoxSystemLog) string {
+	return strings.Join([]string{slog.ClusterName, slog.Namespace, slog.PodName, slog.ContainerName}, "/")
+}
+
+// isExecSessionParent returns true if the parent process is the container runtime
+func isExecSessionParent(parentProcessName string) bool {
+	parent := parentProcessName
+	if idx := strings.LastIndex(parent, "/"); idx >= 0 {
+		parent = parent[idx+1:]
+	}
+
+	for _, runtime := range execSessionParents {
+		if strings.HasPrefix(parent, runtime) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateProcessTree adds the process of the log to the container process tree.
+// The process having no parent in the container pid namespace, or having the
+// container runtime as the parent, is the root of the container entrypoint or of
+// an exec session.
+func updateProcessTree(tree map[int32]*procNode, slog types.KnoxSystemLog, now int64) {
+	if slog.PID == 0 {
+		return
+	}
+
+	root := slog.PID == 1 || slog.PPID == 0 || isExecSessionParent(slog.ParentProcessName)
+
+	node, ok := tree[slog.PID]
+	if !ok || node.ppid != slog.PPID {
+		// new process, or the pid is reused
+		tree[slog.PID] = &procNode{ppid: slog.PPID, root: root, lastSeen: now}
+		return
+	}
+
+	node.root = node.root || root
+	node.lastSeen = now
+}
+
+// containerEntrypoint returns the pid of the container entrypoint, i.e. the root
+// started first. It is pid 1 unless the pid namespace is shared in the pod
+// (shareProcessNamespace), where the entrypoints of all the containers have no
+// parent in the namespace, and the exec sessions are started after them.
+func containerEntrypoint(tree map[int32]*procNode) int32 {
+	entrypoint := int32(0)
+
+	for pid, node := range tree {
+		if node.root && (entrypoint == 0 || pid < entrypoint) {
+			entrypoint = pid
+		}
+	}
+
+	return entrypoint
+}
+
+// isExecSessionProcess returns true if the process descends from a root other
+// than the container entrypoint
+func isExecSessionProcess(tree map[int32]*procNode, entrypoint, pid int32) bool {
+	visited := map[int32]bool{}
+
+	for pid > 0 && !visited[pid] {
+		visited[pid] = true
+
+		node, ok := tree[pid]
+		if !ok {
+			// the lineage is unknown
+			return false
+		}
+		if node.root {
+			return pid != entrypoint
+		}
+		pid = node.ppid
+	}
+
+	return false
+}
+
+// pruneProcessTrees removes the processes not seen within the ttl. The entrypoint
+// is kept as long as the container is active, not to take an exec session for it.
+func pruneProcessTrees(now int64) {
+	for key, tree := range processTrees {
+		entrypoint := containerEntrypoint(tree)
+
+		for pid, node := range tree {
+			if pid != entrypoint && now-node.lastSeen > processTreeTTL {
+				delete(tree, pid)
+			}
+		}
+
+		if node, ok := tree[entrypoint]; ok && len(tree) == 1 && now-node.lastSeen > processTreeTTL {
+			delete(tree, entrypoint)
+		}
+		if len(tree) == 0 {
+			delete(processTrees, key)
+		}
+	}
+}
+
+// tagExecSessionLogs reconstructs the process trees per container, and tags the
+// logs of the processes descending from the exec sessions. The host logs are
+// not tagged.
+func tagExecSessionLogs(logs []types.KnoxSystemLog) {
+	processTreesLock.Lock()
+	defer processTreesLock.Unlock()
+
+	now := time.Now().Unix()
+
+	for _, slog := range logs {
+		if slog.Namespace == types.PolicyDiscoveryVMNamespace {
+			continue
+		}
+
+		key := containerKey(slog)
+		if processTrees[key] == nil {
+			processTrees[key] = map[int32]*procNode{}
+		}
+		updateProcessTree(processTrees[key], slog, now)
+	}
+
+	entrypoints := map[string]int32{}
+	for key, tree := range processTrees {
+		entrypoints[key] = containerEntrypoint(tree)
+	}
+
+	for i := range logs {
+		if logs[i].Namespace == types.PolicyDiscoveryVMNamespace {
+			continue
+		}
+		key := containerKey(logs[i])
+		logs[i].ExecSession = isExecSessionProcess(processTrees[key], entrypoints[key], logs[i].PID)
+	}
+
+	pruneProcessTrees(now)
+}
+
+// filterExecSessionLogs tags the exec session logs, and excludes them or keeps
+// them for the debug policy depending on the mode of the namespace
+func filterExecSessionLogs(logs []types.KnoxSystemLog) []types.KnoxSystemLog {
+	tagExecSessionLogs(logs)
+
+	filteredLogs := []types.KnoxSystemLog{}
+
+	for _, slog := range logs {
+		if slog.ExecSession {
+			switch cfg.GetCfgSystemExecSessionMode(slog.Namespace) {
+			case types.ExecSessionModeExclude:
+				continue
+			case types.ExecSessionModeDebug:
+			default:
+				// learned along with the entrypoint activity
+				slog.ExecSession = false
+			}
+		}
+
+		filteredLogs = append(filteredLogs, slog)
+	}
+
+	return filteredLogs
+}
+
+// excludeExecSessionLogs removes the exec session logs kept for the debug policy
+func excludeExecSessionLogs(logs []types.KnoxSystemLog) []types.KnoxSystemLog {
+	filteredLogs := []types.KnoxSystemLog{}
+
+	for _, slog := range logs {
+		if !slog.ExecSession {
+			filteredLogs = append(filteredLogs, slog)
+		}
+	}
+
+	return filteredLogs
+}
@@ -104,6 +104,11 @@ func FilterSystemLogsByConfig(logs []types.KnoxSystemLog, pods []types.Pod) []ty
 			continue
 		}
 
+		// basic check 4: if the container is excluded (e.g., sidecars), skip it
+		if libs.ContainsElement(ExcludeContainers, log.ContainerName) {
+			continue
+		}
+
 		for _, filter := range SystemLogFilters {
 			checkItems := getHaveToCheckItems(filter)
 
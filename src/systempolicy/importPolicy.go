@@ -0,0 +1,286 @@
+package systempolicy
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/cluster"
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	"github.com/accuknox/auto-policy-discovery/src/plugin"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ======================= //
+// == Policy Import == //
+// ======================= //
+
+// ConvertAppliedSystemPolicy converts the KubeArmor policy into the knox policies
+func ConvertAppliedSystemPolicy(policy types.AppliedPolicy) ([]types.KnoxSystemPolicy, error) {
+	if policy.Kind != types.KindKubeArmorPolicy && policy.Kind != types.KindKubeArmorHostPolicy {
+		return nil, nil
+	}
+
+	spec, err := json.Marshal(policy.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	kubePolicy := types.KubeArmorPolicy{
+		Kind:     policy.Kind,
+		Metadata: map[string]string{"name": policy.Name, "namespace": policy.Namespace},
+	}
+	if err := json.Unmarshal(spec, &kubePolicy.Spec); err != nil {
+		return nil, err
+	}
+
+	return plugin.ConvertKubeArmorPolicyToKnoxSystemPolicies(kubePolicy), nil
+}
+
+// ImportSystemPolicies imports the existing KubeArmor policies as the baseline of
+// the deduplication, so that only the new behaviour is proposed. The imported
+// policies keep their names and are never proposed themselves.
+func ImportSystemPolicies() {
+	policies, err := cluster.GetPoliciesToImport(cfg.GetCfgSystemImportPoliciesFrom(), cfg.GetCfgSystemImportPoliciesDir())
+	if err != nil {
+		log.Error().Msgf("failed to read the system policies to import err=%s", err.Error())
+		return
+	}
+
+	clusterName := cfg.GetCfgClusterName()
+	generatedTime := time.Now().Unix()
+
+	imported := []types.KnoxSystemPolicy{}
+	for _, policy := range policies {
+		knoxPolicies, err := ConvertAppliedSystemPolicy(policy)
+		if err != nil {
+			log.Warn().Msgf("failed to convert the %s %s/%s err=%s", policy.Kind, policy.Namespace, policy.Name, err.Error())
+			continue
+		}
+
+		for _, knoxPolicy := range knoxPolicies {
+			knoxPolicy.Metadata["clusterName"] = clusterName
+			knoxPolicy.Metadata["status"] = types.PolicyStatusImported
+			knoxPolicy.GeneratedTime = generatedTime
+			imported = append(imported, knoxPolicy)
+		}
+	}
+
+	if !strings.Contains(SystemPolicyTo, "db") {
+		return
+	}
+
+	if err := libs.DeleteImportedPolicies(CfgDB, types.PolicyTypeSystem, clusterName); err != nil {
+		log.Error().Msgf("failed to remove the previously imported system policies err=%s", err.Error())
+		return
+	}
+	libs.InsertSystemPolicies(CfgDB, imported)
+
+	log.Info().Msgf("-> System policy import done, [%d] policies imported", len(imported))
+}
+
+// ========================== //
+// == Imported Rule Filter == //
+// ========================== //
+
+// getImportedSysPolicies returns the imported system policies in db
+func getImportedSysPolicies() []types.KnoxSystemPolicy {
+	if !strings.Contains(SystemPolicyTo, "db") {
+		return nil
+	}
+	return libs.GetSystemPolicies(CfgDB, "", types.PolicyStatusImported)
+}
+
+// importedSelects checks if the imported policy applies to the workload of the
+// policy, i.e. the imported selector labels are included in the policy selector
+func importedSelects(imported, policy types.KnoxSystemPolicy) bool {
+	return imported.Metadata["namespace"] == policy.Metadata["namespace"] &&
+		strings.EqualFold(imported.Spec.Action, policy.Spec.Action) &&
+		includeSelectorLabels(imported.Spec.Selector.MatchLabels, policy.Spec.Selector.MatchLabels)
+}
+
+// importedSourceCovers checks if the sources of the imported rule cover the sources
+// of the rule; the imported rule without the source allows any source
+func importedSourceCovers(imported, sources []types.KnoxFromSource) bool {
+	if len(imported) == 0 {
+		return true
+	}
+	if len(sources) == 0 {
+		return false
+	}
+
+	for _, src := range sources {
+		if !libs.ContainsElement(imported, src) {
+			return false
+		}
+	}
+	return true
+}
+
+// importedAccessCovers checks if the imported rule is not narrower than the rule
+func importedAccessCovers(importedReadOnly, importedOwnerOnly, readOnly, ownerOnly bool) bool {
+	return (!importedReadOnly || readOnly) && (!importedOwnerOnly || ownerOnly)
+}
+
+// importedDirCovers checks if the imported directory covers the path, the sub
+// directories only if recursive
+func importedDirCovers(dir types.KnoxMatchDirectories, path string) bool {
+	if !strings.HasPrefix(path, dir.Dir) {
+		return false
+	}
+	return dir.Recursive || filepath.Dir(path)+"/" == dir.Dir
+}
+
+// importedCoversPath checks if the imported rules allow the path
+func importedCoversPath(imported types.KnoxSys, matchPath types.KnoxMatchPaths) bool {
+	for _, p := range imported.MatchPaths {
+		if p.Path == matchPath.Path && importedAccessCovers(p.ReadOnly, p.OwnerOnly, matchPath.ReadOnly, matchPath.OwnerOnly) &&
+			importedSourceCovers(p.FromSource, matchPath.FromSource) {
+			return true
+		}
+	}
+
+	for _, d := range imported.MatchDirectories {
+		if importedDirCovers(d, matchPath.Path) && importedAccessCovers(d.ReadOnly, d.OwnerOnly, matchPath.ReadOnly, matchPath.OwnerOnly) &&
+			importedSourceCovers(d.FromSource, matchPath.FromSource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// importedCoversDir checks if the imported rules allow the directory
+func importedCoversDir(imported types.KnoxSys, matchDir types.KnoxMatchDirectories) bool {
+	for _, d := range imported.MatchDirectories {
+		covered := d.Dir == matchDir.Dir && (d.Recursive || !matchDir.Recursive)
+		if !covered && d.Recursive {
+			covered = strings.HasPrefix(matchDir.Dir, d.Dir)
+		}
+
+		if covered && importedAccessCovers(d.ReadOnly, d.OwnerOnly, matchDir.ReadOnly, matchDir.OwnerOnly) &&
+			importedSourceCovers(d.FromSource, matchDir.FromSource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeImportedSysRules removes the paths, the directories and the patterns
+// allowed by the imported rules
+func removeImportedSysRules(imported []types.KnoxSys, rules types.KnoxSys) types.KnoxSys {
+	results := types.KnoxSys{}
+
+	for _, matchPath := range rules.MatchPaths {
+		covered := false
+		for _, sys := range imported {
+			if importedCoversPath(sys, matchPath) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			results.MatchPaths = append(results.MatchPaths, matchPath)
+		}
+	}
+
+	for _, matchDir := range rules.MatchDirectories {
+		covered := false
+		for _, sys := range imported {
+			if importedCoversDir(sys, matchDir) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			results.MatchDirectories = append(results.MatchDirectories, matchDir)
+		}
+	}
+
+	for _, matchPattern := range rules.MatchPatterns {
+		covered := false
+		for _, sys := range imported {
+			for _, p := range sys.MatchPatterns {
+				if p.Pattern == matchPattern.Pattern && importedAccessCovers(p.ReadOnly, p.OwnerOnly, matchPattern.ReadOnly, matchPattern.OwnerOnly) {
+					covered = true
+					break
+				}
+			}
+		}
+		if !covered {
+			results.MatchPatterns = append(results.MatchPatterns, matchPattern)
+		}
+	}
+
+	return results
+}
+
+// removeImportedProtocols removes the protocols allowed by the imported rules
+func removeImportedProtocols(imported []types.NetworkRule, rules types.NetworkRule) types.NetworkRule {
+	results := types.NetworkRule{}
+
+	for _, matchProtocol := range rules.MatchProtocols {
+		covered := false
+		for _, network := range imported {
+			for _, p := range network.MatchProtocols {
+				if strings.EqualFold(p.Protocol, matchProtocol.Protocol) && importedSourceCovers(p.FromSource, matchProtocol.FromSource) {
+					covered = true
+					break
+				}
+			}
+		}
+		if !covered {
+			results.MatchProtocols = append(results.MatchProtocols, matchProtocol)
+		}
+	}
+
+	return results
+}
+
+// removeImportedRules removes the rules allowed by the imported policies from the
+// discovered policies, so that only the new behaviour is proposed. The policy
+// fully allowed by the imported policies is not proposed.
+func removeImportedRules(importedPolicies, discoveredPolicies []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
+	if len(importedPolicies) == 0 {
+		return discoveredPolicies
+	}
+
+	results := []types.KnoxSystemPolicy{}
+
+	for _, policy := range discoveredPolicies {
+		process, file, network := []types.KnoxSys{}, []types.KnoxSys{}, []types.NetworkRule{}
+		for _, imported := range importedPolicies {
+			if importedSelects(imported, policy) {
+				process = append(process, imported.Spec.Process)
+				file = append(file, imported.Spec.File)
+				network = append(network, imported.Spec.Network)
+			}
+		}
+
+		if len(process) == 0 {
+			results = append(results, policy)
+			continue
+		}
+
+		policy.Spec.Process = removeImportedSysRules(process, policy.Spec.Process)
+		policy.Spec.File = removeImportedSysRules(file, policy.Spec.File)
+		policy.Spec.Network = removeImportedProtocols(network, policy.Spec.Network)
+
+		if isEmptySysRules(policy.Spec.Process) && isEmptySysRules(policy.Spec.File) &&
+			len(policy.Spec.Network.MatchProtocols) == 0 {
+			continue
+		}
+
+		results = append(results, policy)
+	}
+
+	return results
+}
+
+// isEmptySysRules checks if the rules have no path, directory or pattern
+func isEmptySysRules(rules types.KnoxSys) bool {
+	return len(rules.MatchPaths) == 0 && len(rules.MatchDirectories) == 0 && len(rules.MatchPatterns) == 0
+}
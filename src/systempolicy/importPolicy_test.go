@@ -0,0 +1,100 @@
+package systempolicy
+
+import (
+	"testing"
+
+	"github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveImportedRules(t *testing.T) {
+	imported := types.KnoxSystemPolicy{
+		Kind: "KnoxSystemPolicy",
+		Metadata: map[string]string{
+			"name":      "imported",
+			"namespace": "default",
+			"status":    types.PolicyStatusImported,
+		},
+		Spec: types.KnoxSystemSpec{
+			Selector: types.Selector{
+				MatchLabels: map[string]string{"app": "web"},
+			},
+			File: types.KnoxSys{
+				MatchPaths: []types.KnoxMatchPaths{
+					{Path: "/etc/passwd", ReadOnly: true},
+				},
+				MatchDirectories: []types.KnoxMatchDirectories{
+					{Dir: "/usr/lib/", Recursive: true},
+				},
+			},
+			Network: types.NetworkRule{
+				MatchProtocols: []types.KnoxMatchProtocols{
+					{Protocol: "TCP"},
+				},
+			},
+			Action: "Allow",
+		},
+	}
+
+	newPolicy := func(name string, labels map[string]string) types.KnoxSystemPolicy {
+		return types.KnoxSystemPolicy{
+			Kind: "KnoxSystemPolicy",
+			Metadata: map[string]string{
+				"name":      name,
+				"namespace": "default",
+				"status":    "latest",
+			},
+			Spec: types.KnoxSystemSpec{
+				Selector: types.Selector{MatchLabels: labels},
+				Action:   "Allow",
+			},
+		}
+	}
+
+	// partially allowed, the new rules are kept
+	partial := newPolicy("partial", map[string]string{"app": "web", "kubearmor.io/container.name": "web"})
+	partial.Spec.File = types.KnoxSys{
+		MatchPaths: []types.KnoxMatchPaths{
+			{Path: "/etc/passwd", ReadOnly: true},
+			{Path: "/etc/shadow", ReadOnly: true},
+			{Path: "/usr/lib/x86_64/libc.so"},
+		},
+		MatchDirectories: []types.KnoxMatchDirectories{
+			{Dir: "/usr/lib/python3/"},
+		},
+	}
+
+	// read-write is not allowed by the read-only imported rule
+	writable := newPolicy("writable", map[string]string{"app": "web"})
+	writable.Spec.File = types.KnoxSys{
+		MatchPaths: []types.KnoxMatchPaths{
+			{Path: "/etc/passwd"},
+		},
+	}
+
+	// fully allowed, the policy is dropped
+	covered := newPolicy("covered", map[string]string{"app": "web"})
+	covered.Spec.Network = types.NetworkRule{
+		MatchProtocols: []types.KnoxMatchProtocols{
+			{Protocol: "tcp", FromSource: []types.KnoxFromSource{{Path: "/usr/bin/curl"}}},
+		},
+	}
+
+	// another workload, the policy is kept as it is
+	other := newPolicy("other", map[string]string{"app": "db"})
+	other.Spec.File = partial.Spec.File
+
+	results := removeImportedRules([]types.KnoxSystemPolicy{imported},
+		[]types.KnoxSystemPolicy{partial, writable, covered, other})
+
+	assert.Len(t, results, 3)
+
+	assert.Equal(t, "partial", results[0].Metadata["name"])
+	assert.Equal(t, []types.KnoxMatchPaths{{Path: "/etc/shadow", ReadOnly: true}}, results[0].Spec.File.MatchPaths)
+	assert.Empty(t, results[0].Spec.File.MatchDirectories)
+
+	assert.Equal(t, "writable", results[1].Metadata["name"])
+	assert.Equal(t, writable.Spec.File.MatchPaths, results[1].Spec.File.MatchPaths)
+
+	assert.Equal(t, other, results[2])
+}
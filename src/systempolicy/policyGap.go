@@ -0,0 +1,91 @@
+package systempolicy
+
+import (
+	"strings"
+
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+)
+
+// ================= //
+// == Policy Gaps == //
+// ================= //
+
+// isBlockedLog returns true if the event is blocked by the enforced policy
+func isBlockedLog(log types.KnoxSystemLog) bool {
+	return log.Result == "Permission denied"
+}
+
+// splitBlockedLogs separates the events blocked by the enforced policies from
+// the passed ones
+func splitBlockedLogs(logs []types.KnoxSystemLog) ([]types.KnoxSystemLog, []types.KnoxSystemLog) {
+	passedLogs := []types.KnoxSystemLog{}
+	blockedLogs := []types.KnoxSystemLog{}
+
+	for _, log := range logs {
+		if isBlockedLog(log) {
+			blockedLogs = append(blockedLogs, log)
+		} else {
+			passedLogs = append(passedLogs, log)
+		}
+	}
+
+	return passedLogs, blockedLogs
+}
+
+// recordSysPolicyGaps records the resources of the blocked events, i.e. the
+// minimal rules which would have allowed them, per workload for the review.
+// The resources covered by the existing file sets are not recorded.
+func recordSysPolicyGaps(pods []types.Pod, blockedLogs []types.KnoxSystemLog) {
+	gaps := map[types.WorkloadProcessFileSet]map[string]types.RuleStats{}
+
+	for _, slog := range blockedLogs {
+		wpfs, resource, err := getLogWorkloadProcessFileSet(slog, slog.Operation, pods)
+		if err != nil {
+			log.Error().Msgf("could not get pod labels for podname=%s ns=%s", slog.PodName, slog.Namespace)
+			continue
+		}
+
+		if gaps[wpfs] == nil {
+			gaps[wpfs] = map[string]types.RuleStats{}
+		}
+		for _, r := range resource {
+			newStats := libs.NewRuleStats(1)
+			newStats.Pods = []string{slog.PodName}
+			newStats.Evidence = []types.RuleEvidence{systemLogEvidence(slog)}
+			gaps[wpfs][r] = libs.MergeRuleStats(gaps[wpfs][r], newStats)
+		}
+	}
+
+	for wpfs, resources := range gaps {
+		out, _, err := libs.GetWorkloadProcessFileSet(CfgDB, wpfs)
+		if err != nil {
+			log.Error().Msgf("failed processing wpfs=%+v err=%s", wpfs, err.Error())
+			continue
+		}
+
+		for resource, stats := range resources {
+			known := false
+			for _, rule := range out[wpfs] {
+				if ruleCoversResource(rule, resource) {
+					known = true
+					break
+				}
+			}
+			if known {
+				continue
+			}
+
+			key, candidate := sysRuleKey(wpfs, resource)
+			libs.RecordPolicyGap(CfgDB, types.PolicyGap{
+				ClusterName: wpfs.ClusterName,
+				Namespace:   wpfs.Namespace,
+				Labels:      libs.WorkloadLabels(strings.Split(wpfs.Labels, ",")),
+				PolicyType:  types.PolicyTypeSystem,
+				RuleKey:     key,
+				Candidate:   candidate,
+				RuleStats:   stats,
+			})
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package systempolicy
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/accuknox/auto-policy-discovery/src/common"
+	"github.com/accuknox/auto-policy-discovery/src/libs"
+	types "github.com/accuknox/auto-policy-discovery/src/types"
+	"github.com/clarketm/json"
+)
+
+// ruleCoversResource returns true if the rule (path, directory or pattern) of
+// the file set covers the resource
+func ruleCoversResource(rule, resource string) bool {
+	if rule == resource {
+		return true
+	}
+
+	if strings.HasSuffix(rule, "/") {
+		return strings.HasPrefix(resource, rule)
+	}
+
+	if common.IsPathPattern(rule) {
+		ok, _ := path.Match(rule, resource)
+		return ok
+	}
+
+	return false
+}
+
+// buildSysRuleStats builds the statistics of the rules of the file set. The
+// statistics of the previous rules folded into a new one (e.g. paths aggregated
+// into a directory) are carried over, and the statistics of the resources seen
+// in this cycle are added to the covering rule.
+func buildSysRuleStats(fs []string, prevStats map[string]types.RuleStats,
+	observed map[string]types.RuleStats) map[string]types.RuleStats {
+	stats := map[string]types.RuleStats{}
+
+	for prevRule, prev := range prevStats {
+		for _, rule := range fs {
+			if ruleCoversResource(rule, prevRule) {
+				stats[rule] = libs.CombineRuleStats(stats[rule], prev)
+				break
+			}
+		}
+	}
+
+	for resource, newStats := range observed {
+		for _, rule := range fs {
+			if ruleCoversResource(rule, resource) {
+				stats[rule] = libs.MergeRuleStats(stats[rule], newStats)
+				break
+			}
+		}
+	}
+
+	return stats
+}
+
+// systemLogEvidence keeps the raw fields of the system log as the evidence of the rule
+func systemLogEvidence(slog types.KnoxSystemLog) types.RuleEvidence {
+	fields := map[string]string{}
+
+	for k, v := range map[string]string{
+		"host_name":      slog.HostName,
+		"container_name": slog.ContainerName,
+		"operation":      slog.Operation,
+		"source":         slog.SourceOrigin,
+		"resource":       slog.ResourceOrigin,
+		"data":           slog.Data,
+		"result":         slog.Result,
+	} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+
+	return types.RuleEvidence{
+		Timestamp: time.Now().Unix(),
+		Namespace: slog.Namespace,
+		PodName:   slog.PodName,
+		Fields:    fields,
+	}
+}
+
+// updateSysRuleStats updates the statistics of the file set rules in db
+func updateSysRuleStats(wpfs types.WorkloadProcessFileSet, fs []string, observed map[string]types.RuleStats) {
+	prevStats, err := libs.GetWorkloadProcessRuleStats(CfgDB, wpfs)
+	if err != nil {
+		log.Error().Msgf("could not fetch rule stats for wpfs=%+v err=%s", wpfs, err.Error())
+		return
+	}
+
+	stats := buildSysRuleStats(fs, prevStats[wpfs], observed)
+	if err := libs.UpdateWorkloadProcessRuleStats(CfgDB, wpfs, stats); err != nil {
+		log.Error().Msgf("could not update rule stats for wpfs=%+v err=%s", wpfs, err.Error())
+	}
+}
+
+// updateStaleSysRules flags (or prunes) the file set rules not seen within the
+// staleness window. It returns true if any rule is pruned or revived, i.e. the
+// system policies need to be regenerated.
+func updateStaleSysRules() bool {
+	statsMap, err := libs.GetWorkloadProcessRuleStats(CfgDB, types.WorkloadProcessFileSet{})
+	if err != nil {
+		log.Error().Msgf("could not fetch rule stats err=%s", err.Error())
+		return false
+	}
+
+	now := time.Now().Unix()
+	specChanged := false
+
+	for wpfs, stats := range statsMap {
+		changed := false
+
+		for rule, ruleStats := range stats {
+			pruned := ruleStats.Pruned
+			if libs.UpdateRuleStaleness(&ruleStats, now, StaleRuleWindow, StaleRuleAction) {
+				stats[rule] = ruleStats
+				changed = true
+				if pruned != ruleStats.Pruned {
+					specChanged = true
+				}
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := libs.UpdateWorkloadProcessRuleStats(CfgDB, wpfs, stats); err != nil {
+			log.Error().Msgf("could not update rule stats for wpfs=%+v err=%s", wpfs, err.Error())
+		}
+	}
+
+	return specChanged
+}
+
+// sysRuleKey identifies the resource of the file set, and returns the candidate rule as well
+func sysRuleKey(wpfs types.WorkloadProcessFileSet, resource string) (string, string) {
+	candidate := map[string]string{
+		"clusterName":   wpfs.ClusterName,
+		"namespace":     wpfs.Namespace,
+		"containerName": wpfs.ContainerName,
+		"labels":        wpfs.Labels,
+		"fromSource":    wpfs.FromSource,
+		"setType":       wpfs.SetType,
+		"resource":      resource,
+	}
+
+	candidateBytes, err := json.Marshal(candidate)
+	if err != nil {
+		log.Error().Msg(err.Error())
+	}
+
+	return libs.RuleKey(string(candidateBytes)), string(candidateBytes)
+}
+
+// applySysRuleThresholds holds the new resources of the file set observed below
+// the thresholds of its set type (file or process) in the staging table, and returns the resources to be proposed.
+// The resources covered by the existing file set are not held. The statistics of
+// the held resources are dropped from the observed ones, and the statistics of
+// the proposed resources include the staged ones.
+func applySysRuleThresholds(wpfs types.WorkloadProcessFileSet, fs, existFs []string,
+	observed map[string]types.RuleStats) []string {
+	threshold := libs.GetRuleThreshold(RuleThreshold, RuleTypeThresholds, wpfs.SetType)
+	if !libs.IsRuleThresholdEnabled(threshold) {
+		return fs
+	}
+
+	stagedRules, err := libs.GetStagedRules(CfgDB, types.PolicyTypeSystem, wpfs.ClusterName, wpfs.Namespace)
+	if err != nil {
+		log.Error().Msgf("could not fetch staged rules for wpfs=%+v err=%s", wpfs, err.Error())
+		return fs
+	}
+
+	proposed := []string{}
+
+	for _, resource := range fs {
+		known := false
+		for _, rule := range existFs {
+			if ruleCoversResource(rule, resource) {
+				known = true
+				break
+			}
+		}
+		if known {
+			proposed = append(proposed, resource)
+			continue
+		}
+
+		key, candidate := sysRuleKey(wpfs, resource)
+		staged, ok := stagedRules[key]
+		stats := libs.MergeRuleStats(staged.RuleStats, observed[resource])
+
+		if !libs.MeetsRuleThreshold(stats, threshold) {
+			if err := libs.UpdateStagedRule(CfgDB, types.StagedRule{
+				ClusterName: wpfs.ClusterName,
+				Namespace:   wpfs.Namespace,
+				PolicyType:  types.PolicyTypeSystem,
+				RuleKey:     key,
+				Candidate:   candidate,
+				RuleStats:   stats,
+			}); err != nil {
+				log.Error().Msgf("could not stage the resource %s err=%s", resource, err.Error())
+			}
+			delete(observed, resource)
+			continue
+		}
+
+		if ok {
+			if err := libs.DeleteStagedRule(CfgDB, types.PolicyTypeSystem, key); err != nil {
+				log.Error().Msgf("could not remove the staged resource %s err=%s", resource, err.Error())
+			}
+		}
+		observed[resource] = stats
+		proposed = append(proposed, resource)
+	}
+
+	return proposed
+}
+
+// wpfsSelectsPolicy checks if the file set is of the workload selected by the policy
+func wpfsSelectsPolicy(wpfs types.WorkloadProcessFileSet, policy types.KnoxSystemPolicy) bool {
+	if wpfs.Namespace != policy.Metadata["namespace"] {
+		return false
+	}
+
+	labels := map[string]string{}
+	for _, label := range strings.Split(wpfs.Labels, ",") {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 || kv[0] == types.ExecSessionLabel {
+			continue
+		}
+		labels[kv[0]] = kv[1]
+	}
+
+	for k, v := range policy.Spec.Selector.MatchLabels {
+		if k == types.KubeArmorContainerNameLabel {
+			if v != wpfs.ContainerName {
+				return false
+			}
+			continue
+		}
+		if labels[k] != v {
+			return false
+		}
+		delete(labels, k)
+	}
+
+	return len(labels) == 0
+}
+
+// isPrunedSysRule returns true if every resource covered by the rule is pruned
+// in the file sets of the operation and the source
+func isPrunedSysRule(rule string, fromSource []types.KnoxFromSource, setType string, statsMap types.ResourceStatsMap) bool {
+	covered := false
+
+	for wpfs, stats := range statsMap {
+		if wpfs.SetType != setType {
+			continue
+		}
+		if len(fromSource) > 0 && wpfs.FromSource != fromSource[0].Path {
+			continue
+		}
+
+		for resource, ruleStats := range stats {
+			if !ruleCoversResource(rule, resource) {
+				continue
+			}
+			if !ruleStats.Pruned {
+				return false
+			}
+			covered = true
+		}
+	}
+
+	return covered
+}
+
+// pruneSysRules drops the pruned paths and directories of the file/process rules
+func pruneSysRules(rules *types.KnoxSys, setType string, statsMap types.ResourceStatsMap) bool {
+	pruned := false
+
+	matchPaths := []types.KnoxMatchPaths{}
+	for _, matchPath := range rules.MatchPaths {
+		if isPrunedSysRule(matchPath.Path, matchPath.FromSource, setType, statsMap) {
+			pruned = true
+			continue
+		}
+		matchPaths = append(matchPaths, matchPath)
+	}
+
+	matchDirs := []types.KnoxMatchDirectories{}
+	for _, matchDir := range rules.MatchDirectories {
+		if isPrunedSysRule(matchDir.Dir, matchDir.FromSource, setType, statsMap) {
+			pruned = true
+			continue
+		}
+		matchDirs = append(matchDirs, matchDir)
+	}
+
+	if pruned {
+		rules.MatchPaths = matchPaths
+		rules.MatchDirectories = matchDirs
+	}
+
+	return pruned
+}
+
+// pruneStaleSysPolicyRules applies the pruning of the file set rules to the system
+// policies discovered from the logs, which carry no rule statistics of their own.
+// A rule is pruned if every resource it covers is pruned in the file sets of the
+// workload.
+func pruneStaleSysPolicyRules() {
+	statsMap, err := libs.GetWorkloadProcessRuleStats(CfgDB, types.WorkloadProcessFileSet{})
+	if err != nil {
+		log.Error().Msgf("could not fetch rule stats err=%s", err.Error())
+		return
+	}
+
+	for _, policy := range libs.GetSystemPolicies(CfgDB, "", "latest") {
+		workloadStats := types.ResourceStatsMap{}
+		for wpfs, stats := range statsMap {
+			if wpfsSelectsPolicy(wpfs, policy) {
+				workloadStats[wpfs] = stats
+			}
+		}
+		if len(workloadStats) == 0 {
+			continue
+		}
+
+		filePruned := pruneSysRules(&policy.Spec.File, SYS_OP_FILE, workloadStats)
+		procPruned := pruneSysRules(&policy.Spec.Process, SYS_OP_PROCESS, workloadStats)
+		if filePruned || procPruned {
+			libs.UpdateSystemPolicy(CfgDB, policy)
+		}
+	}
+}
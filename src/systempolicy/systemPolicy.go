@@ -8,8 +8,8 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/clarketm/json"
@@ -59,6 +59,10 @@ const (
 	SYS_OP_NETWORK_INT = 4
 
 	SOURCE_ALL = "/ALL" // for fromSource 'off'
+
+	// path aggregation mode
+	PATH_AGGREGATION_DIRECTORY = "directory"
+	PATH_AGGREGATION_PATTERN   = "pattern"
 )
 
 // ====================== //
@@ -70,6 +74,9 @@ var CfgDB types.ConfigDB
 // SystemWorkerStatus global worker
 var SystemWorkerStatus string
 
+// SystemPolicyLock serializes the discovery cycle and the other updates of the policies in db
+var SystemPolicyLock sync.Mutex
+
 // for cron job
 var SystemCronJob *cron.Cron
 
@@ -88,6 +95,33 @@ var SystemLogFilters []types.SystemLogFilter
 var ProcessFromSource bool
 var FileFromSource bool
 
+var PathAggregation string
+var PatternStrictness string
+
+// procPidPath matches the /proc/<pid> prefix of the path
+var procPidPath = regexp.MustCompile(`^/proc/[0-9]+(/|$)`)
+
+var ContainerScoped bool
+var ExcludeContainers []string
+
+var HostPolicyDiscovery bool
+var HostPolicyNodeLabels []string
+var HostNodeLabels map[string]map[string]string // key: node name - val: node labels
+
+var PolicyNameTemplate string
+
+var StaleRuleWindow int64
+var StaleRuleAction string
+
+var RuleThreshold types.RuleThreshold
+var RuleTypeThresholds map[string]types.RuleThreshold
+var RuleStagingTTL int64
+
+var WorkloadStateThreshold types.WorkloadStateThreshold
+var AnomalyMode bool
+
+var GapAnalysis bool
+
 // init Function
 func init() {
 	SystemWorkerStatus = STATUS_IDLE
@@ -265,7 +299,23 @@ func populateKnoxSysPolicyFromWPFSDb(namespace, clustername, labels, fromsource
 		return nil
 	}
 	log.Info().Msgf("found %d WPFS records", len(res))
-	return ConvertWPFSToKnoxSysPolicy(res, pnMap)
+
+	statsMap, err := libs.GetWorkloadProcessRuleStats(CfgDB, wpfs)
+	if err != nil {
+		log.Error().Msgf("could not fetch rule stats err=%s", err.Error())
+	}
+
+	return ConvertWPFSToKnoxSysPolicy(res, pnMap, statsMap)
+}
+
+// GetSysPolicyByName returns the latest system policy stored in the db
+func GetSysPolicyByName(name string) (types.KnoxSystemPolicy, bool) {
+	for _, pol := range libs.GetSystemPolicies(CfgDB, "", "latest") {
+		if pol.Metadata["name"] == name {
+			return pol, true
+		}
+	}
+	return types.KnoxSystemPolicy{}, false
 }
 
 func WriteSystemPoliciesToFile_Ext(namespace, clustername, labels, fromsource string, includeNetwork bool) {
@@ -408,7 +458,10 @@ func extractVMSystemPolicies(namespace, clustername, labels, fromSource string)
 	var frmSrcSlice []string
 	var resFromSrc []string
 
-	if fromSource == "" {
+	if fromSource == "" && HostPolicyDiscovery {
+		// host policy contains all the sources of the node
+		frmSrcSlice = append(frmSrcSlice, "")
+	} else if fromSource == "" {
 		frmSrcSlice = GetWPFSSources()
 	} else {
 		frmSrcSlice = append(frmSrcSlice, fromSource)
@@ -639,6 +692,7 @@ func mergeFromSourceMatchPaths(pmp []types.KnoxMatchPaths, mp *[]types.KnoxMatch
 			rp := &(*mp)[i]
 			if pp.Path == (*rp).Path {
 				(*rp).FromSource = append((*rp).FromSource, pp.FromSource...)
+				(*rp).RuleStats = libs.CombineRuleStats((*rp).RuleStats, pp.RuleStats)
 				//remove dups
 				match = true
 			}
@@ -657,6 +711,7 @@ func mergeFromSourceMatchDirs(pmp []types.KnoxMatchDirectories, mp *[]types.Knox
 			rp := &(*mp)[i]
 			if pp.Dir == (*rp).Dir {
 				(*rp).FromSource = append((*rp).FromSource, pp.FromSource...)
+				(*rp).RuleStats = libs.CombineRuleStats((*rp).RuleStats, pp.RuleStats)
 				//remove dups
 				match = true
 			}
@@ -668,6 +723,23 @@ func mergeFromSourceMatchDirs(pmp []types.KnoxMatchDirectories, mp *[]types.Knox
 	}
 }
 
+func mergeMatchPatterns(pmp []types.KnoxMatchPatterns, mp *[]types.KnoxMatchPatterns) {
+	for _, pp := range pmp {
+		match := false
+		for i := range *mp {
+			rp := &(*mp)[i]
+			if pp.Pattern == rp.Pattern {
+				rp.RuleStats = libs.CombineRuleStats(rp.RuleStats, pp.RuleStats)
+				match = true
+				break
+			}
+		}
+		if !match {
+			*mp = append(*mp, pp)
+		}
+	}
+}
+
 func mergeFromSourceMatchProt(pmp []types.KnoxMatchProtocols, mp *[]types.KnoxMatchProtocols) {
 	for _, pp := range pmp {
 		match := false
@@ -760,9 +832,11 @@ func mergeFromSource(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 
 		mergeFromSourceMatchPaths(pol.Spec.File.MatchPaths, &results[i].Spec.File.MatchPaths)
 		mergeFromSourceMatchDirs(pol.Spec.File.MatchDirectories, &results[i].Spec.File.MatchDirectories)
+		mergeMatchPatterns(pol.Spec.File.MatchPatterns, &results[i].Spec.File.MatchPatterns)
 
 		mergeFromSourceMatchPaths(pol.Spec.Process.MatchPaths, &results[i].Spec.Process.MatchPaths)
 		mergeFromSourceMatchDirs(pol.Spec.Process.MatchDirectories, &results[i].Spec.Process.MatchDirectories)
+		mergeMatchPatterns(pol.Spec.Process.MatchPatterns, &results[i].Spec.Process.MatchPatterns)
 
 		mergeFromSourceMatchProt(pol.Spec.Network.MatchProtocols, &results[i].Spec.Network.MatchProtocols)
 	}
@@ -772,9 +846,6 @@ func mergeFromSource(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 func mergeSysPolicies(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 	var results []types.KnoxSystemPolicy
 	for _, pol := range pols {
-		pol.Metadata["name"] = "autopol-system-" +
-			strconv.FormatUint(uint64(common.HashInt(pol.Metadata["labels"]+
-				pol.Metadata["namespace"]+pol.Metadata["clustername"]+pol.Metadata["containername"])), 10)
 		i := checkIfMetadataMatches(pol, results)
 		if i < 0 {
 			results = append(results, pol)
@@ -789,6 +860,10 @@ func mergeSysPolicies(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 			mp := &results[i].Spec.File.MatchDirectories
 			*mp = append(*mp, pol.Spec.File.MatchDirectories...)
 		}
+		if len(pol.Spec.File.MatchPatterns) > 0 {
+			mp := &results[i].Spec.File.MatchPatterns
+			*mp = append(*mp, pol.Spec.File.MatchPatterns...)
+		}
 		if len(pol.Spec.Process.MatchPaths) > 0 {
 			mp := &results[i].Spec.Process.MatchPaths
 			*mp = append(*mp, pol.Spec.Process.MatchPaths...)
@@ -797,14 +872,18 @@ func mergeSysPolicies(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 			mp := &results[i].Spec.Process.MatchDirectories
 			*mp = append(*mp, pol.Spec.Process.MatchDirectories...)
 		}
+		if len(pol.Spec.Process.MatchPatterns) > 0 {
+			mp := &results[i].Spec.Process.MatchPatterns
+			*mp = append(*mp, pol.Spec.Process.MatchPatterns...)
+		}
 		if len(pol.Spec.Network.MatchProtocols) > 0 {
 			mp := &results[i].Spec.Network.MatchProtocols
 			*mp = append(*mp, pol.Spec.Network.MatchProtocols...)
 		}
-		results[i].Metadata["name"] = pol.Metadata["name"]
 	}
 
 	results = mergeFromSource(results)
+	generateSysPolicyNames(results)
 
 	// merging and sorting all the rules at MatchPaths, MatchDirs, MatchProtocols level
 	// sorting is needed so that the rules are placed consistently in the
@@ -822,6 +901,12 @@ func mergeSysPolicies(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 				return cmpDirs((*mp)[x], (*mp)[y])
 			})
 		}
+		if len(pol.Spec.File.MatchPatterns) > 0 {
+			mp := &pol.Spec.File.MatchPatterns
+			sort.Slice(*mp, func(x, y int) bool {
+				return (*mp)[x].Pattern < (*mp)[y].Pattern
+			})
+		}
 		if len(pol.Spec.Process.MatchPaths) > 0 {
 			mp := &pol.Spec.Process.MatchPaths
 			sort.Slice(*mp, func(x, y int) bool {
@@ -834,6 +919,12 @@ func mergeSysPolicies(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 				return cmpDirs((*mp)[x], (*mp)[y])
 			})
 		}
+		if len(pol.Spec.Process.MatchPatterns) > 0 {
+			mp := &pol.Spec.Process.MatchPatterns
+			sort.Slice(*mp, func(x, y int) bool {
+				return (*mp)[x].Pattern < (*mp)[y].Pattern
+			})
+		}
 		if len(pol.Spec.Network.MatchProtocols) > 0 {
 			mp := &pol.Spec.Network.MatchProtocols
 			sort.Slice(*mp, func(x, y int) bool {
@@ -845,7 +936,57 @@ func mergeSysPolicies(pols []types.KnoxSystemPolicy) []types.KnoxSystemPolicy {
 	return results
 }
 
-func ConvertWPFSToKnoxSysPolicy(wpfsSet types.ResourceSetMap, pnMap types.PolicyNameMap) []types.KnoxSystemPolicy {
+// sysPolicyIdentity returns the fields identifying the merged system policy
+func sysPolicyIdentity(pol types.KnoxSystemPolicy) []string {
+	return []string{
+		pol.Metadata["labels"],
+		pol.Metadata["namespace"],
+		pol.Metadata["clusterName"],
+		pol.Metadata["containername"],
+	}
+}
+
+// generateSysPolicyNames names the merged system policies with the name template.
+// The policies are named in the order of their identity, so that the collision
+// suffixes do not depend on the order of the WPFS records.
+func generateSysPolicyNames(pols []types.KnoxSystemPolicy) {
+	idx := make([]int, len(pols))
+	for i := range pols {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(x, y int) bool {
+		return strings.Join(sysPolicyIdentity(pols[idx[x]]), ",") <
+			strings.Join(sysPolicyIdentity(pols[idx[y]]), ",")
+	})
+
+	policyNamesMap := map[string]bool{}
+	for _, i := range idx {
+		pol := pols[i]
+
+		workload := libs.GetWorkloadNameFromLabels(pol.Spec.Selector.MatchLabels)
+		if container := pol.Metadata["containername"]; container != "" &&
+			pol.Spec.Selector.MatchLabels[types.KubeArmorContainerNameLabel] == "" {
+			workload = workload + "-" + container
+		}
+		if pol.Metadata["debug"] == "true" {
+			workload = workload + "-debug"
+		}
+
+		name := libs.GeneratePolicyNameFromTemplate(PolicyNameTemplate, libs.PolicyNameFields{
+			Type:      "system",
+			Workload:  workload,
+			Namespace: pol.Metadata["namespace"],
+			Cluster:   pol.Metadata["clusterName"],
+			Hash:      libs.PolicyNameHash(sysPolicyIdentity(pol)...),
+		})
+		name = libs.ResolvePolicyNameCollision(policyNamesMap, name)
+		policyNamesMap[name] = true
+
+		pol.Metadata["name"] = name
+	}
+}
+
+func ConvertWPFSToKnoxSysPolicy(wpfsSet types.ResourceSetMap, pnMap types.PolicyNameMap, statsMap types.ResourceStatsMap) []types.KnoxSystemPolicy {
 	var results []types.KnoxSystemPolicy
 	for wpfs, fsset := range wpfsSet {
 		policy := buildSystemPolicy()
@@ -853,8 +994,15 @@ func ConvertWPFSToKnoxSysPolicy(wpfsSet types.ResourceSetMap, pnMap types.Policy
 
 		for _, fpath := range fsset {
 			path := common.SysPath{
-				Path:  fpath,
-				IsDir: strings.HasSuffix(fpath, "/"),
+				Path:      fpath,
+				IsDir:     strings.HasSuffix(fpath, "/"),
+				IsPattern: wpfs.SetType != SYS_OP_NETWORK && common.IsPathPattern(fpath),
+				Stats:     statsMap[wpfs][fpath],
+			}
+			// matchPatterns cannot take fromSource, keep the source with the directory
+			if path.IsPattern && hasFromSource(wpfs) {
+				path.Path = common.PatternBaseDir(fpath)
+				path.IsDir, path.IsPattern = true, false
 			}
 			src := ""
 			if wpfs.SetType == SYS_OP_NETWORK || strings.HasPrefix(wpfs.FromSource, "/") {
@@ -874,10 +1022,21 @@ func ConvertWPFSToKnoxSysPolicy(wpfsSet types.ResourceSetMap, pnMap types.Policy
 			for _, label := range labels {
 				k := strings.Split(label, "=")[0]
 				v := strings.Split(label, "=")[1]
+				if k == types.ExecSessionLabel {
+					policy.Metadata["debug"] = "true"
+					continue
+				}
 				policy.Spec.Selector.MatchLabels[k] = v
 			}
 		}
 
+		// scope the policy to the container so that the sidecars do not widen it
+		if ContainerScoped && wpfs.ContainerName != "" &&
+			wpfs.Namespace != types.PolicyDiscoveryVMNamespace &&
+			wpfs.Namespace != types.PolicyDiscoveryContainerNamespace {
+			policy.Spec.Selector.MatchLabels[types.KubeArmorContainerNameLabel] = wpfs.ContainerName
+		}
+
 		results = append(results, policy)
 	}
 
@@ -928,6 +1087,20 @@ func updateSysPolicySpec(opType string, policy types.KnoxSystemPolicy, src strin
 		policy.Spec.Network.MatchProtocols = append(policy.Spec.Network.MatchProtocols, matchProtocols)
 		return policy
 	}
+	// matchPatterns (KubeArmor does not support fromSource for matchPatterns)
+	if pathSpec.IsPattern {
+		matchPatterns := types.KnoxMatchPatterns{
+			Pattern:   pathSpec.Path,
+			RuleStats: pathSpec.Stats,
+		}
+
+		if opType == SYS_OP_FILE {
+			policy.Spec.File.MatchPatterns = append(policy.Spec.File.MatchPatterns, matchPatterns)
+		} else if opType == SYS_OP_PROCESS {
+			policy.Spec.Process.MatchPatterns = append(policy.Spec.Process.MatchPatterns, matchPatterns)
+		}
+		return policy
+	}
 	// matchDirectories
 	if pathSpec.IsDir {
 		path := pathSpec.Path
@@ -937,6 +1110,7 @@ func updateSysPolicySpec(opType string, policy types.KnoxSystemPolicy, src strin
 		matchDirs := types.KnoxMatchDirectories{
 			Dir:       path,
 			Recursive: true,
+			RuleStats: pathSpec.Stats,
 		}
 
 		if opType == SYS_OP_FILE {
@@ -969,7 +1143,8 @@ func updateSysPolicySpec(opType string, policy types.KnoxSystemPolicy, src strin
 	} else {
 		// matchPaths
 		matchPaths := types.KnoxMatchPaths{
-			Path: pathSpec.Path,
+			Path:      pathSpec.Path,
+			RuleStats: pathSpec.Stats,
 		}
 
 		if opType == SYS_OP_FILE {
@@ -1033,6 +1208,9 @@ func UpdateSysPolicies(wpfsPolicies []types.KnoxSystemPolicy) {
 		wpfsPolicies = populateKnoxSysPolicyFromWPFSDb("", "", "", "")
 	}
 
+	// skip the rules already allowed by the imported policies
+	wpfsPolicies = removeImportedRules(getImportedSysPolicies(), wpfsPolicies)
+
 	InsertSysPoliciesYamlToDB(wpfsPolicies)
 
 	for _, wpfsPolicy := range wpfsPolicies {
@@ -1040,6 +1218,10 @@ func UpdateSysPolicies(wpfsPolicies []types.KnoxSystemPolicy) {
 		sysPoliciesDb := libs.GetSystemPolicies(CfgDB, "", "")
 
 		for _, sysPolicyDb := range sysPoliciesDb {
+			if sysPolicyDb.Metadata["status"] == types.PolicyStatusImported {
+				continue
+			}
+
 			if sysPolicyDb.Metadata["name"] == wpfsPolicy.Metadata["name"] {
 				libs.UpdateSystemPolicy(CfgDB, wpfsPolicy)
 				isPolicyExist = true
@@ -1075,6 +1257,31 @@ func InitSysPolicyDiscoveryConfiguration() {
 
 	ProcessFromSource = cfg.GetCfgSystemProcFromSource()
 	FileFromSource = cfg.GetCfgSystemFileFromSource()
+
+	PathAggregation = cfg.GetCfgSystemPathAggregation()
+	PatternStrictness = cfg.GetCfgSystemPatternStrictness()
+
+	ContainerScoped = cfg.GetCfgSystemContainerScoped()
+	ExcludeContainers = cfg.GetCfgSystemExcludeContainers()
+
+	HostPolicyDiscovery = cfg.GetCfgSystemHostPolicyDiscovery()
+	HostPolicyNodeLabels = cfg.GetCfgSystemHostPolicyNodeLabels()
+
+	PolicyNameTemplate = cfg.GetCfgSystemPolicyNameTemplate()
+
+	StaleRuleWindow = libs.ParseStaleRuleWindow(cfg.GetCfgSystemStaleRuleWindow())
+	StaleRuleAction = cfg.GetCfgSystemStaleRuleAction()
+
+	RuleThreshold = libs.NewRuleThreshold(cfg.GetCfgSystemRuleMinCount(),
+		cfg.GetCfgSystemRuleMinPods(), cfg.GetCfgSystemRuleMinSpan())
+	RuleTypeThresholds = libs.NewRuleTypeThresholds(cfg.GetCfgSystemRuleTypeThresholds())
+	RuleStagingTTL = libs.ParseStaleRuleWindow(cfg.GetCfgSystemRuleStagingTTL())
+
+	WorkloadStateThreshold = libs.NewWorkloadStateThreshold(cfg.GetCfgWorkloadStateWindow(),
+		cfg.GetCfgWorkloadStateStableAfter(), cfg.GetCfgWorkloadStateEnforceReadyAfter())
+	AnomalyMode = cfg.GetCfgWorkloadStateAnomalyMode()
+
+	GapAnalysis = cfg.GetCfgSystemGapAnalysis()
 }
 
 func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types.KnoxSystemPolicy {
@@ -1084,10 +1291,23 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 	// delete duplicate logs
 	sysLogs = systemLogDeduplication(sysLogs)
 
+	// remove the staged paths not seen within the ttl
+	if RuleStagingTTL > 0 {
+		if err := libs.DeleteExpiredStagedRules(CfgDB, types.PolicyTypeSystem, RuleStagingTTL); err != nil {
+			log.Error().Msgf("could not remove the expired staged rules err=%s", err.Error())
+		}
+	}
+
 	// get cluster names, iterate each cluster
 	clusteredLogs := clusteringSystemLogsByCluster(sysLogs)
 
 	existingPolicies := libs.GetSystemPolicies(CfgDB, "", "")
+	importedPolicies := []types.KnoxSystemPolicy{}
+	for _, policy := range existingPolicies {
+		if policy.Metadata["status"] == types.PolicyStatusImported {
+			importedPolicies = append(importedPolicies, policy)
+		}
+	}
 	log.Info().Msgf("len(tot-syslogs):%d len(existingPolicies):%d", len(sysLogs), len(existingPolicies))
 	for clusterName, sysLogs := range clusteredLogs {
 		// get existing system policies in db
@@ -1096,12 +1316,29 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 		// get k8s pods
 		pods := cluster.GetPods(clusterName)
 
+		// get k8s nodes for the host policies
+		if HostPolicyDiscovery && cfg.GetCfgClusterInfoFrom() == "k8sclient" {
+			HostNodeLabels = cluster.GetNodeLabelsFromK8sClient()
+		}
+
 		// filter system logs from configuration
 		cfgFilteredLogs := FilterSystemLogsByConfig(sysLogs, pods)
 
+		// exclude (or keep for the debug policy) the activity of the exec sessions
+		cfgFilteredLogs = filterExecSessionLogs(cfgFilteredLogs)
+
+		// the blocked events are reported as the policy gaps rather than learned
+		if GapAnalysis {
+			var blockedLogs []types.KnoxSystemLog
+			cfgFilteredLogs, blockedLogs = splitBlockedLogs(cfgFilteredLogs)
+			recordSysPolicyGaps(pods, blockedLogs)
+		}
+
 		// iterate sys log key := [namespace + pod_name]
 		nsPodLogs := clusteringSystemLogsByNamespacePod(cfgFilteredLogs)
 
+		workloadChanges := map[types.WorkloadKey]bool{}
+
 		for sysKey, perPodlogs := range nsPodLogs {
 			discoveredSysPolicies := []types.KnoxSystemPolicy{}
 
@@ -1111,6 +1348,11 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 				continue
 			}
 
+			workloadKey := types.WorkloadKey{Namespace: pod.Namespace, Labels: libs.WorkloadLabels(pod.Labels)}
+			if _, ok := workloadChanges[workloadKey]; !ok {
+				workloadChanges[workloadKey] = false
+			}
+
 			polCnt := 0
 			isWpfsDbUpdated := false
 			// 1. discover file operation system policy
@@ -1118,7 +1360,7 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 				fileOpLogs := getOperationLogs(SYS_OP_FILE, perPodlogs)
 				isWpfsDbUpdated = GenFileSetForAllPodsInCluster(clusterName, pods, SYS_OP_FILE, fileOpLogs) || isWpfsDbUpdated
 				if !cfg.CurrentCfg.ConfigSysPolicy.DeprecateOldMode {
-					discoveredSysPolicies = discoverFileOperationPolicy(discoveredSysPolicies, pod, fileOpLogs)
+					discoveredSysPolicies = discoverFileOperationPolicy(discoveredSysPolicies, pod, excludeExecSessionLogs(fileOpLogs))
 					log.Info().Msgf("discovered %d file policies from %d file logs",
 						len(discoveredSysPolicies), len(fileOpLogs))
 				}
@@ -1129,7 +1371,7 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 				procOpLogs := getOperationLogs(SYS_OP_PROCESS, perPodlogs)
 				isWpfsDbUpdated = GenFileSetForAllPodsInCluster(clusterName, pods, SYS_OP_PROCESS, procOpLogs) || isWpfsDbUpdated
 				if !cfg.CurrentCfg.ConfigSysPolicy.DeprecateOldMode {
-					discoveredSysPolicies = discoverProcessOperationPolicy(discoveredSysPolicies, pod, procOpLogs)
+					discoveredSysPolicies = discoverProcessOperationPolicy(discoveredSysPolicies, pod, excludeExecSessionLogs(procOpLogs))
 					polCnt = len(discoveredSysPolicies)
 					log.Info().Msgf("discovered %d process policies from %d process logs",
 						len(discoveredSysPolicies)-polCnt, len(procOpLogs))
@@ -1147,6 +1389,7 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 				// New mode of system policy generation using WPFS table
 				if isWpfsDbUpdated {
 					UpdateSysPolicies([]types.KnoxSystemPolicy{})
+					workloadChanges[workloadKey] = true
 				}
 			}
 
@@ -1155,10 +1398,15 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 				discoveredSysPolicies = updateSysPolicySelector(clusterName, pod, discoveredSysPolicies)
 				discoveredSystemPolicies = append(discoveredSystemPolicies, discoveredSysPolicies...)
 
+				// skip the rules already allowed by the imported policies
+				discoveredSysPolicies = removeImportedRules(importedPolicies, discoveredSysPolicies)
+
 				// 4. update duplicated policy
 				newPolicies := UpdateDuplicatedPolicy(existingPolicies, discoveredSysPolicies, clusterName)
 
 				if len(newPolicies) > 0 {
+					workloadChanges[workloadKey] = true
+
 					// insert discovered policies to db
 					if strings.Contains(SystemPolicyTo, "db") {
 						libs.InsertSystemPolicies(CfgDB, newPolicies)
@@ -1173,6 +1421,9 @@ func PopulateSystemPoliciesFromSystemLogs(sysLogs []types.KnoxSystemLog) []types
 				WriteSystemPoliciesToFile(sysKey.Namespace, "", "", "", true)
 			}
 		}
+
+		// move the workloads through the learning states
+		libs.UpdateWorkloadStates(CfgDB, types.PolicyTypeSystem, clusterName, workloadChanges, WorkloadStateThreshold)
 	}
 
 	return discoveredSystemPolicies
@@ -1187,6 +1438,27 @@ func GetPodLabels(cn string, pn string, ns string, pods []types.Pod) ([]string,
 	return nil, errors.New("pod not found")
 }
 
+// getHostLabels returns the node selector labels for the host. If the host has
+// any of the configured role labels, those are used so that the nodes sharing
+// the same role are merged, otherwise the host is selected by its hostname.
+func getHostLabels(hostName string) []string {
+	labels := []string{}
+
+	nodeLabels := HostNodeLabels[hostName]
+	for _, key := range HostPolicyNodeLabels {
+		if val, ok := nodeLabels[key]; ok {
+			labels = append(labels, key+"="+val)
+		}
+	}
+
+	if len(labels) == 0 {
+		labels = append(labels, "kubernetes.io/hostname="+hostName)
+	}
+
+	sort.Strings(labels)
+	return labels
+}
+
 // Merge, remove duplicates and sort
 func mergeStringSlices(a []string, b []string) []string {
 	check := make(map[string]int)
@@ -1257,7 +1529,7 @@ func getProtocolType(str string) string {
 
 // cleanResource : Certain linux files keep changing always and needs to refed
 // just once. Examples are /proc, /sys.
-func cleanResource(op string, str string) []string {
+func cleanResource(op string, str string, fromSource bool) []string {
 	var arr []string
 	if op == SYS_OP_NETWORK {
 		prot := getProtocolType(str)
@@ -1265,7 +1537,11 @@ func cleanResource(op string, str string) []string {
 			arr = strings.Split(prot, ",")
 		}
 	} else {
-		if strings.HasPrefix(str, "/proc") {
+		if PathAggregation == PATH_AGGREGATION_PATTERN && !fromSource {
+			// the pid of /proc/<pid>/ differs per process, the other variable
+			// segments are generalised later as matchPatterns
+			arr = append(arr, procPidPath.ReplaceAllString(str, "/proc/"+common.PatternDigit+"$1"))
+		} else if strings.HasPrefix(str, "/proc") {
 			arr = append(arr, "/proc/")
 		} else if strings.HasPrefix(str, "/sys") {
 			arr = append(arr, "/sys/")
@@ -1294,42 +1570,36 @@ func removeDuplicates(arr []string) []string {
 // GenFileSetForAllPodsInCluster Generate process specific fileset across all pods in a cluster
 func GenFileSetForAllPodsInCluster(clusterName string, pods []types.Pod, settype string, slogs []types.KnoxSystemLog) bool {
 	res := types.ResourceSetMap{} // key: WorkloadProcess - val: Accesss File Set
-	wpfs := types.WorkloadProcessFileSet{}
+	observed := map[types.WorkloadProcessFileSet]map[string]types.RuleStats{}
 	isNetworkOp := false
 	status := false
 	if settype == SYS_OP_NETWORK {
 		isNetworkOp = true // for network logs, need full ResourceOrigin to do regexp matching in getProtocolType()
 	}
-	var resource []string
 	for _, slog := range slogs {
-		wpfs.ClusterName = slog.ClusterName
-		wpfs.ContainerName = slog.ContainerName
-		wpfs.Namespace = slog.Namespace
-		wpfs.FromSource = slog.Source
-		wpfs.SetType = settype
-		labels, err := GetPodLabels(slog.ClusterName, slog.PodName, slog.Namespace, pods)
+		wpfs, resource, err := getLogWorkloadProcessFileSet(slog, settype, pods)
 		if err != nil {
 			log.Error().Msgf("could not get pod labels for podname=%s ns=%s", slog.PodName, slog.Namespace)
 			continue
 		}
-
-		if slog.Namespace == types.PolicyDiscoveryContainerNamespace {
-			labels = append(labels, "kubearmor.io/container.name="+slog.ContainerName)
-		}
-
-		wpfs.Labels = strings.Join(labels[:], ",")
-
-		if isNetworkOp {
-			resource = cleanResource(settype, slog.ResourceOrigin)
-		} else {
-			resource = cleanResource(settype, slog.Resource)
-		}
 		if len(resource) == 0 {
 			continue
 		}
 		res[wpfs] = append(res[wpfs], resource...)
+
+		if observed[wpfs] == nil {
+			observed[wpfs] = map[string]types.RuleStats{}
+		}
+		for _, r := range resource {
+			newStats := libs.NewRuleStats(1)
+			newStats.Pods = []string{slog.PodName}
+			newStats.Evidence = []types.RuleEvidence{systemLogEvidence(slog)}
+			observed[wpfs][r] = libs.MergeRuleStats(observed[wpfs][r], newStats)
+		}
 	}
 
+	anomalyWorkloads := map[string]map[string]bool{} // key: namespace - val: workloads in anomaly mode
+
 	var mergedfs []string
 	for wpfs, fs := range res {
 		out, _, err := libs.GetWorkloadProcessFileSet(CfgDB, wpfs)
@@ -1341,10 +1611,33 @@ func GenFileSetForAllPodsInCluster(clusterName string, pods []types.Pod, settype
 		if len(out[wpfs]) == 0 {
 			dbEntry = false
 		}
+
+		// record the new resources of the stable workloads as anomalies
+		if _, ok := anomalyWorkloads[wpfs.Namespace]; !ok {
+			anomalyWorkloads[wpfs.Namespace] = libs.GetAnomalyWorkloads(CfgDB, AnomalyMode,
+				types.PolicyTypeSystem, wpfs.ClusterName, wpfs.Namespace)
+		}
+		fs = applySysAnomalyMode(wpfs, removeDuplicates(fs), out[wpfs], observed[wpfs], anomalyWorkloads[wpfs.Namespace])
+		if len(fs) == 0 {
+			continue
+		}
+
+		if !isNetworkOp {
+			// hold the resources observed below the thresholds in the staging table
+			fs = applySysRuleThresholds(wpfs, removeDuplicates(fs), out[wpfs], observed[wpfs])
+			if len(fs) == 0 {
+				continue
+			}
+		}
 		mergedfs = removeDuplicates(append(fs, out[wpfs]...))
 		if !isNetworkOp {
 			// Path aggregation makes sense for file, process operations only
-			mergedfs = common.AggregatePathsExt(mergedfs) // merge and sort the filesets
+			if PathAggregation == PATH_AGGREGATION_PATTERN && !hasFromSource(wpfs) {
+				mergedfs = common.AggregatePathsToPatterns(mergedfs,
+					cfg.GetCfgSystemPatternThreshold(wpfs.Namespace), PatternStrictness)
+			} else {
+				mergedfs = common.AggregatePathsExt(mergedfs) // merge and sort the filesets
+			}
 		}
 
 		sort.SliceStable(mergedfs, func(i, j int) bool {
@@ -1369,30 +1662,94 @@ func GenFileSetForAllPodsInCluster(clusterName string, pods []types.Pod, settype
 			i++
 		}
 		// Add/Update DB Entry
-		if !dbEntry {
-			log.Info().Msgf("adding wpfs db entry for wpfs=%+v", wpfs)
-			err = libs.InsertWorkloadProcessFileSet(CfgDB, wpfs, mergedfs)
+		if !dbEntry || !reflect.DeepEqual(mergedfs, out[wpfs]) {
+			err = saveWorkloadProcessFileSet(wpfs, mergedfs, dbEntry)
 			status = true
-		} else {
-			if !reflect.DeepEqual(mergedfs, out[wpfs]) {
-				log.Info().Msgf("updating wpfs db entry for wpfs=%+v", wpfs)
-				if CfgDB.DBDriver == "mysql" {
-					err = libs.UpdateWorkloadProcessFileSetMySQL(CfgDB, wpfs, mergedfs)
-					status = true
-				} else if CfgDB.DBDriver == "sqlite3" {
-					err = libs.UpdateWorkloadProcessFileSetSQLite(CfgDB, wpfs, mergedfs)
-					status = true
-				}
-			}
 		}
 		if err != nil {
 			log.Error().Msgf("failure add/updt db entry for wpfs=%+v err=%s", wpfs, err.Error())
 		}
+
+		// matchProtocols do not keep the rule statistics
+		if !isNetworkOp {
+			updateSysRuleStats(wpfs, mergedfs, observed[wpfs])
+		}
 	}
 
 	return status
 }
 
+// getLogWorkloadProcessFileSet returns the file set of the workload process
+// the log belongs to, and the resources of the log
+func getLogWorkloadProcessFileSet(slog types.KnoxSystemLog, settype string, pods []types.Pod) (types.WorkloadProcessFileSet, []string, error) {
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName:   slog.ClusterName,
+		ContainerName: slog.ContainerName,
+		Namespace:     slog.Namespace,
+		FromSource:    slog.Source,
+		SetType:       settype,
+	}
+
+	labels, err := GetPodLabels(slog.ClusterName, slog.PodName, slog.Namespace, pods)
+	if err != nil {
+		return wpfs, nil, err
+	}
+
+	if slog.Namespace == types.PolicyDiscoveryContainerNamespace {
+		labels = append(labels, types.KubeArmorContainerNameLabel+"="+slog.ContainerName)
+	}
+
+	if slog.Namespace == types.PolicyDiscoveryVMNamespace && HostPolicyDiscovery {
+		// the nodes having the same role labels share the host policy
+		labels = getHostLabels(slog.HostName)
+		wpfs.ContainerName = ""
+	}
+
+	// the exec session activity is generated into a separate debug policy
+	if slog.ExecSession {
+		labels = append(labels, types.ExecSessionLabel+"=true")
+	}
+
+	wpfs.Labels = strings.Join(labels[:], ",")
+
+	if settype == SYS_OP_NETWORK {
+		return wpfs, cleanResource(settype, slog.ResourceOrigin, hasFromSource(wpfs)), nil
+	}
+	return wpfs, cleanResource(settype, slog.Resource, hasFromSource(wpfs)), nil
+}
+
+// hasFromSource checks if the rules of the file set are restricted to its source
+func hasFromSource(wpfs types.WorkloadProcessFileSet) bool {
+	if !strings.HasPrefix(wpfs.FromSource, "/") {
+		return false
+	}
+
+	switch wpfs.SetType {
+	case SYS_OP_FILE:
+		return FileFromSource
+	case SYS_OP_PROCESS:
+		return ProcessFromSource
+	}
+
+	return false
+}
+
+// saveWorkloadProcessFileSet adds or updates the db entry of the file set
+func saveWorkloadProcessFileSet(wpfs types.WorkloadProcessFileSet, fs []string, dbEntry bool) error {
+	if !dbEntry {
+		log.Info().Msgf("adding wpfs db entry for wpfs=%+v", wpfs)
+		return libs.InsertWorkloadProcessFileSet(CfgDB, wpfs, fs)
+	}
+
+	log.Info().Msgf("updating wpfs db entry for wpfs=%+v", wpfs)
+	if CfgDB.DBDriver == "mysql" {
+		return libs.UpdateWorkloadProcessFileSetMySQL(CfgDB, wpfs, fs)
+	} else if CfgDB.DBDriver == "sqlite3" {
+		return libs.UpdateWorkloadProcessFileSetSQLite(CfgDB, wpfs, fs)
+	}
+	return nil
+}
+
 // InsertSysPoliciesYamlToDB inserts systempolicy to DB
 func InsertSysPoliciesYamlToDB(policies []types.KnoxSystemPolicy) {
 
@@ -1424,6 +1781,9 @@ func InsertSysPoliciesYamlToDB(policies []types.KnoxSystemPolicy) {
 			Labels:      kubearmorPolicy.Spec.Selector.MatchLabels,
 			Yaml:        yamlBytes,
 		}
+		if kubearmorPolicy.Kind == types.KindKubeArmorHostPolicy {
+			policyYaml.Labels = kubearmorPolicy.Spec.NodeSelector.MatchLabels
+		}
 		res = append(res, policyYaml)
 
 		PolicyStore.Publish(&policyYaml)
@@ -1445,15 +1805,28 @@ func DiscoverSystemPolicyMain() {
 		SystemWorkerStatus = STATUS_IDLE
 	}()
 
+	SystemPolicyLock.Lock()
+	defer SystemPolicyLock.Unlock()
+
 	InitSysPolicyDiscoveryConfiguration()
 
 	// get system logs
 	allSystemkLogs := getSystemLogs()
-	if allSystemkLogs == nil {
-		return
+	if allSystemkLogs != nil {
+		PopulateSystemPoliciesFromSystemLogs(allSystemkLogs)
+	} else {
+		// the workloads become stable even if no logs are seen
+		libs.UpdateWorkloadStates(CfgDB, types.PolicyTypeSystem, "", nil, WorkloadStateThreshold)
 	}
 
-	PopulateSystemPoliciesFromSystemLogs(allSystemkLogs)
+	// flag the rules not seen within the staleness window, even if no logs
+	if StaleRuleWindow > 0 && updateStaleSysRules() {
+		if cfg.CurrentCfg.ConfigSysPolicy.DeprecateOldMode {
+			UpdateSysPolicies([]types.KnoxSystemPolicy{})
+		} else {
+			pruneStaleSysPolicyRules()
+		}
+	}
 }
 
 // ==================================== //
@@ -1522,6 +1895,16 @@ func StartSystemWorker() {
 		return
 	}
 
+	if cfg.GetCfgSystemMigratePolicyNames() {
+		InitSysPolicyDiscoveryConfiguration()
+		MigratePolicyNames()
+	}
+
+	if cfg.GetCfgSystemImportPoliciesFrom() != "" {
+		InitSysPolicyDiscoveryConfiguration()
+		ImportSystemPolicies()
+	}
+
 	if cfg.GetCfgSysOperationMode() == OP_MODE_NOOP { // Do not run the operation
 		log.Info().Msg("system operation mode is NOOP ... NO SYSTEM POLICY DISCOVERY")
 	} else if cfg.GetCfgSysOperationMode() == OP_MODE_CRONJOB { // every time intervals
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	cfg "github.com/accuknox/auto-policy-discovery/src/config"
 	types "github.com/accuknox/auto-policy-discovery/src/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -171,3 +172,364 @@ func TestMergeSysPoliciesProcess(t *testing.T) {
 	assert.Equal(t, res.Spec.Process.MatchDirectories[1].FromSource[1].Path, "/bin/stash")
 
 }
+
+func TestConvertWPFSToKnoxSysPolicyPatterns(t *testing.T) {
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName:   "default",
+		ContainerName: "kabuntu",
+		Namespace:     "default",
+		FromSource:    "/bin/cat",
+		Labels:        "xyz=abc",
+		SetType:       SYS_OP_FILE,
+	}
+	wpfs2 := wpfs
+	wpfs2.FromSource = "/bin/ls"
+
+	wpfsSet := types.ResourceSetMap{
+		wpfs:  []string{"/etc/hosts", "/proc/[0-9]*/stat"},
+		wpfs2: []string{"/proc/[0-9]*/stat", "/tmp/*.sock"},
+	}
+
+	results := ConvertWPFSToKnoxSysPolicy(wpfsSet, types.PolicyNameMap{}, nil)
+	assert.Equal(t, len(results), 1)
+
+	res := results[0]
+	assert.Equal(t, len(res.Spec.File.MatchPaths), 1)
+	assert.Equal(t, res.Spec.File.MatchPaths[0].Path, "/etc/hosts")
+	assert.Equal(t, []types.KnoxMatchPatterns{
+		{Pattern: "/proc/[0-9]*/stat"},
+		{Pattern: "/tmp/*.sock"},
+	}, res.Spec.File.MatchPatterns)
+}
+
+func TestConvertWPFSToKnoxSysPolicyPatternsFromSource(t *testing.T) {
+	saved := FileFromSource
+	defer func() { FileFromSource = saved }()
+	FileFromSource = true
+
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName:   "default",
+		ContainerName: "kabuntu",
+		Namespace:     "default",
+		FromSource:    "/bin/cat",
+		Labels:        "xyz=abc",
+		SetType:       SYS_OP_FILE,
+	}
+
+	wpfsSet := types.ResourceSetMap{
+		wpfs: []string{"/etc/hosts", "/proc/[0-9]*/stat"},
+	}
+
+	// the pattern seen from the source is kept as the directory with the source
+	results := ConvertWPFSToKnoxSysPolicy(wpfsSet, types.PolicyNameMap{}, nil)
+	assert.Equal(t, len(results), 1)
+
+	res := results[0]
+	assert.Empty(t, res.Spec.File.MatchPatterns)
+	assert.Equal(t, []types.KnoxMatchDirectories{{
+		Dir:        "/proc/",
+		Recursive:  true,
+		FromSource: []types.KnoxFromSource{{Path: "/bin/cat"}},
+	}}, res.Spec.File.MatchDirectories)
+}
+
+func TestCleanResourceProcPid(t *testing.T) {
+	saved := PathAggregation
+	defer func() { PathAggregation = saved }()
+	PathAggregation = PATH_AGGREGATION_PATTERN
+
+	assert.Equal(t, []string{"/proc/[0-9]*/stat"}, cleanResource(SYS_OP_FILE, "/proc/1234/stat", false))
+	assert.Equal(t, []string{"/proc/[0-9]*"}, cleanResource(SYS_OP_FILE, "/proc/1234", false))
+	assert.Equal(t, []string{"/proc/self/stat"}, cleanResource(SYS_OP_FILE, "/proc/self/stat", false))
+	assert.Equal(t, []string{"/proc/"}, cleanResource(SYS_OP_FILE, "/proc/1234/stat", true))
+}
+
+func TestGetHostLabels(t *testing.T) {
+	HostPolicyNodeLabels = []string{"node-role.kubernetes.io/control-plane", "topology.kubernetes.io/zone"}
+	HostNodeLabels = map[string]map[string]string{
+		"master-1": {"node-role.kubernetes.io/control-plane": "", "kubernetes.io/hostname": "master-1"},
+		"worker-1": {"kubernetes.io/hostname": "worker-1"},
+	}
+	defer func() {
+		HostPolicyNodeLabels = nil
+		HostNodeLabels = nil
+	}()
+
+	assert.Equal(t, []string{"node-role.kubernetes.io/control-plane="}, getHostLabels("master-1"))
+	assert.Equal(t, []string{"kubernetes.io/hostname=worker-1"}, getHostLabels("worker-1"))
+	assert.Equal(t, []string{"kubernetes.io/hostname=vm-1"}, getHostLabels("vm-1"))
+}
+
+func TestConvertWPFSToKnoxSysPolicyContainerScoped(t *testing.T) {
+	ContainerScoped = true
+	defer func() { ContainerScoped = false }()
+
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName:   "default",
+		ContainerName: "server",
+		Namespace:     "default",
+		FromSource:    "/bin/server",
+		Labels:        "app=web",
+		SetType:       SYS_OP_FILE,
+	}
+	sidecar := wpfs
+	sidecar.ContainerName = "log-shipper"
+	sidecar.FromSource = "/bin/fluent-bit"
+
+	wpfsSet := types.ResourceSetMap{
+		wpfs:    []string{"/etc/server.conf"},
+		sidecar: []string{"/var/log/"},
+	}
+
+	results := ConvertWPFSToKnoxSysPolicy(wpfsSet, types.PolicyNameMap{}, nil)
+	assert.Equal(t, len(results), 2)
+
+	for _, res := range results {
+		assert.Equal(t, "web", res.Spec.Selector.MatchLabels["app"])
+		assert.Equal(t, res.Metadata["containername"], res.Spec.Selector.MatchLabels[types.KubeArmorContainerNameLabel])
+		if res.Metadata["containername"] == "server" {
+			assert.Equal(t, len(res.Spec.File.MatchPaths), 1)
+			assert.Equal(t, len(res.Spec.File.MatchDirectories), 0)
+		} else {
+			assert.Equal(t, len(res.Spec.File.MatchPaths), 0)
+			assert.Equal(t, len(res.Spec.File.MatchDirectories), 1)
+		}
+	}
+}
+
+func TestPruneSysRules(t *testing.T) {
+	wpfs := types.WorkloadProcessFileSet{
+		Namespace:     "default",
+		ContainerName: "server",
+		Labels:        "app=web",
+		FromSource:    "/bin/server",
+		SetType:       SYS_OP_FILE,
+	}
+	statsMap := types.ResourceStatsMap{wpfs: {
+		"/etc/server.conf": {LastSeen: 100, Stale: true, Pruned: true},
+		"/var/log/a.log":   {LastSeen: 100, Stale: true, Pruned: true},
+		"/var/log/b.log":   {LastSeen: 300},
+	}}
+
+	policy := buildSystemPolicy()
+	policy.Metadata["namespace"] = "default"
+	policy.Spec.Selector.MatchLabels["app"] = "web"
+	policy.Spec.File = types.KnoxSys{
+		MatchPaths:       []types.KnoxMatchPaths{{Path: "/etc/server.conf"}, {Path: "/etc/hosts"}},
+		MatchDirectories: []types.KnoxMatchDirectories{{Dir: "/var/log/", Recursive: true}},
+	}
+	assert.True(t, wpfsSelectsPolicy(wpfs, policy))
+
+	// the path not seen in the file sets and the directory partly seen are kept
+	assert.True(t, pruneSysRules(&policy.Spec.File, SYS_OP_FILE, statsMap))
+	assert.Equal(t, []types.KnoxMatchPaths{{Path: "/etc/hosts"}}, policy.Spec.File.MatchPaths)
+	assert.Len(t, policy.Spec.File.MatchDirectories, 1)
+
+	// the rule of another source is not pruned
+	rules := types.KnoxSys{MatchPaths: []types.KnoxMatchPaths{
+		{Path: "/etc/server.conf", FromSource: []types.KnoxFromSource{{Path: "/bin/sh"}}},
+	}}
+	assert.False(t, pruneSysRules(&rules, SYS_OP_FILE, statsMap))
+
+	// the file set of another workload
+	policy.Spec.Selector.MatchLabels["app"] = "cart"
+	assert.False(t, wpfsSelectsPolicy(wpfs, policy))
+}
+
+func TestFilterSystemLogsByExcludedContainers(t *testing.T) {
+	ExcludeContainers = []string{"istio-proxy"}
+	defer func() { ExcludeContainers = nil }()
+
+	logs := []types.KnoxSystemLog{
+		{Namespace: "default", PodName: "web", ContainerName: "server", Operation: SYS_OP_FILE,
+			Source: "/bin/server", Resource: "/etc/server.conf", Result: "Passed"},
+		{Namespace: "default", PodName: "web", ContainerName: "istio-proxy", Operation: SYS_OP_FILE,
+			Source: "/usr/local/bin/envoy", Resource: "/etc/istio/proxy/envoy-rev.json", Result: "Passed"},
+	}
+
+	results := FilterSystemLogsByConfig(logs, nil)
+	assert.Equal(t, len(results), 1)
+	assert.Equal(t, "server", results[0].ContainerName)
+}
+
+func TestBuildSysRuleStats(t *testing.T) {
+	prevStats := map[string]types.RuleStats{
+		"/etc/hosts":       {FirstSeen: 100, LastSeen: 200, HitCount: 2},
+		"/usr/lib/libc.so": {FirstSeen: 50, LastSeen: 150, HitCount: 1, Stale: true},
+		"/usr/lib/libm.so": {FirstSeen: 60, LastSeen: 160, HitCount: 1, Stale: true},
+	}
+	observed := map[string]types.RuleStats{
+		"/etc/hosts":      {FirstSeen: 300, LastSeen: 300, HitCount: 3},
+		"/proc/1234/stat": {FirstSeen: 300, LastSeen: 300, HitCount: 2},
+	}
+
+	// the paths in /usr/lib are aggregated into the directory
+	fs := []string{"/etc/hosts", "/proc/[0-9]*/stat", "/usr/lib/"}
+	stats := buildSysRuleStats(fs, prevStats, observed)
+
+	assert.Equal(t, len(stats), 3)
+
+	assert.Equal(t, int64(5), stats["/etc/hosts"].HitCount)
+	assert.Equal(t, int64(100), stats["/etc/hosts"].FirstSeen)
+
+	assert.Equal(t, int64(2), stats["/proc/[0-9]*/stat"].HitCount)
+
+	assert.Equal(t, int64(2), stats["/usr/lib/"].HitCount)
+	assert.Equal(t, int64(50), stats["/usr/lib/"].FirstSeen)
+	assert.Equal(t, int64(160), stats["/usr/lib/"].LastSeen)
+	assert.True(t, stats["/usr/lib/"].Stale)
+}
+
+func TestConvertWPFSToKnoxSysPolicy_RuleStats(t *testing.T) {
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName: "default",
+		Namespace:   "default",
+		Labels:      "app=web",
+		FromSource:  "/bin/server",
+		SetType:     SYS_OP_FILE,
+	}
+	wpfsSet := types.ResourceSetMap{wpfs: {"/etc/hosts", "/var/log/"}}
+	statsMap := types.ResourceStatsMap{wpfs: {
+		"/etc/hosts": {LastSeen: 100, HitCount: 2, Stale: true, Pruned: true},
+	}}
+
+	results := ConvertWPFSToKnoxSysPolicy(wpfsSet, types.PolicyNameMap{}, statsMap)
+
+	assert.Equal(t, len(results), 1)
+	assert.True(t, results[0].Spec.File.MatchPaths[0].Pruned)
+	assert.Equal(t, int64(2), results[0].Spec.File.MatchPaths[0].HitCount)
+	assert.Equal(t, types.RuleStats{}, results[0].Spec.File.MatchDirectories[0].RuleStats)
+}
+
+func TestFilterExecSessionLogs(t *testing.T) {
+	defer func() {
+		processTrees = map[string]map[int32]*procNode{}
+		cfg.CurrentCfg.ConfigSysPolicy.ExecSessionMode = ""
+		cfg.CurrentCfg.ConfigSysPolicy.NsExecSessionModes = nil
+	}()
+	cfg.CurrentCfg.ConfigSysPolicy.ExecSessionMode = types.ExecSessionModeExclude
+	cfg.CurrentCfg.ConfigSysPolicy.NsExecSessionModes = map[string]string{"dev": types.ExecSessionModeDebug}
+
+	newLog := func(ns string, pid, ppid int32, parent, resource string) types.KnoxSystemLog {
+		return types.KnoxSystemLog{Namespace: ns, PodName: "web", ContainerName: "server",
+			PID: pid, PPID: ppid, ParentProcessName: parent, Operation: "Process", Resource: resource}
+	}
+
+	// the entrypoint (1) spawns a worker (7), the exec session shell (20) spawns curl (21)
+	logs := []types.KnoxSystemLog{
+		newLog("default", 1, 0, "/usr/bin/containerd-shim-runc-v2", "/bin/server"),
+		newLog("default", 7, 1, "/bin/server", "/bin/worker"),
+		newLog("default", 20, 0, "/usr/bin/runc", "/bin/bash"),
+		newLog("default", 21, 20, "/bin/bash", "/usr/bin/curl"),
+	}
+
+	filtered := filterExecSessionLogs(logs)
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "/bin/server", filtered[0].Resource)
+	assert.Equal(t, "/bin/worker", filtered[1].Resource)
+
+	// the parent is known from the previous batch
+	filtered = filterExecSessionLogs([]types.KnoxSystemLog{newLog("default", 22, 20, "/bin/bash", "/usr/bin/wget")})
+	assert.Empty(t, filtered)
+
+	// the exec session activity is kept for the debug policy
+	filtered = filterExecSessionLogs([]types.KnoxSystemLog{
+		newLog("dev", 30, 0, "/usr/bin/runc", "/bin/sh"),
+		newLog("dev", 1, 0, "", "/bin/server"),
+	})
+	assert.Len(t, filtered, 2)
+	assert.True(t, filtered[0].ExecSession)
+	assert.False(t, filtered[1].ExecSession)
+	assert.Len(t, excludeExecSessionLogs(filtered), 1)
+}
+
+func TestFilterExecSessionLogs_SharedPIDNamespace(t *testing.T) {
+	defer func() {
+		processTrees = map[string]map[int32]*procNode{}
+		cfg.CurrentCfg.ConfigSysPolicy.ExecSessionMode = ""
+	}()
+	cfg.CurrentCfg.ConfigSysPolicy.ExecSessionMode = types.ExecSessionModeExclude
+
+	newLog := func(container string, pid, ppid int32, parent, resource string) types.KnoxSystemLog {
+		return types.KnoxSystemLog{Namespace: "default", PodName: "web", ContainerName: container,
+			PID: pid, PPID: ppid, ParentProcessName: parent, Operation: "Process", Resource: resource}
+	}
+
+	// pid 1 is the pause process, the entrypoints of the server (7) and of the
+	// proxy (8) have no parent in the pod pid namespace, the exec session shell
+	// (25) is started later in the server container
+	logs := []types.KnoxSystemLog{
+		newLog("server", 25, 0, "/usr/bin/runc", "/bin/bash"),
+		newLog("server", 26, 25, "/bin/bash", "/usr/bin/curl"),
+		newLog("server", 7, 0, "/usr/bin/containerd-shim-runc-v2", "/bin/server"),
+		newLog("server", 9, 7, "/bin/server", "/bin/worker"),
+		newLog("proxy", 8, 0, "/usr/bin/containerd-shim-runc-v2", "/bin/envoy"),
+	}
+
+	filtered := filterExecSessionLogs(logs)
+	assert.Len(t, filtered, 3)
+	assert.Equal(t, "/bin/server", filtered[0].Resource)
+	assert.Equal(t, "/bin/worker", filtered[1].Resource)
+	assert.Equal(t, "/bin/envoy", filtered[2].Resource)
+}
+
+func TestConvertWPFSToKnoxSysPolicy_ExecSessionDebug(t *testing.T) {
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName: "default",
+		Namespace:   "dev",
+		Labels:      "app=web",
+		SetType:     SYS_OP_PROCESS,
+	}
+	debugWpfs := wpfs
+	debugWpfs.Labels = "app=web," + types.ExecSessionLabel + "=true"
+
+	wpfsSet := types.ResourceSetMap{
+		wpfs:      {"/bin/server"},
+		debugWpfs: {"/bin/bash"},
+	}
+
+	policies := ConvertWPFSToKnoxSysPolicy(wpfsSet, nil, nil)
+	assert.Len(t, policies, 2)
+
+	for _, pol := range policies {
+		assert.Equal(t, map[string]string{"app": "web"}, pol.Spec.Selector.MatchLabels)
+		if pol.Metadata["debug"] == "true" {
+			assert.Equal(t, "/bin/bash", pol.Spec.Process.MatchPaths[0].Path)
+			assert.Contains(t, pol.Metadata["name"], "-debug")
+		} else {
+			assert.Equal(t, "/bin/server", pol.Spec.Process.MatchPaths[0].Path)
+		}
+	}
+}
+
+func TestApplySysAnomalyMode(t *testing.T) {
+	wpfs := types.WorkloadProcessFileSet{
+		ClusterName: "default",
+		Namespace:   "wordpress-mysql",
+		Labels:      "tier=db,app=mysql",
+		SetType:     SYS_OP_FILE,
+	}
+	fs := []string{"/etc/mysql/my.cnf", "/tmp/payload"}
+	existFs := []string{"/etc/mysql/"}
+
+	// not in anomaly mode
+	observed := map[string]types.RuleStats{"/tmp/payload": {HitCount: 1}}
+	assert.Equal(t, fs, applySysAnomalyMode(wpfs, fs, existFs, observed, map[string]bool{"app=wordpress": true}))
+	assert.Contains(t, observed, "/tmp/payload")
+
+	// the resource not covered by the existing file set is an anomaly
+	proposed := applySysAnomalyMode(wpfs, fs, existFs, observed, map[string]bool{"app=mysql,tier=db": true})
+	assert.Equal(t, []string{"/etc/mysql/my.cnf"}, proposed)
+	assert.NotContains(t, observed, "/tmp/payload")
+}
+
+func TestSplitBlockedLogs(t *testing.T) {
+	logs := []types.KnoxSystemLog{
+		{Operation: SYS_OP_FILE, Resource: "/etc/passwd", Result: "Passed"},
+		{Operation: SYS_OP_PROCESS, Resource: "/bin/sh", Result: "Permission denied"},
+	}
+
+	passed, blocked := splitBlockedLogs(logs)
+	assert.Equal(t, []types.KnoxSystemLog{logs[0]}, passed)
+	assert.Equal(t, []types.KnoxSystemLog{logs[1]}, blocked)
+}
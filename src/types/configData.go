@@ -48,6 +48,8 @@ type ConfigNetworkPolicy struct {
 	NetPolicyRuleTypes int `json:"network_policy_rule_types,omitempty" bson:"network_policy_rule_types,omitempty"`
 	NetPolicyCIDRBits  int `json:"network_policy_cidrbits,omitempty" bson:"network_policy_cidrbits,omitempty"`
 
+	NetPolicyCIDRBitsV6 int `json:"network_policy_cidrbits_v6,omitempty" bson:"network_policy_cidrbits_v6,omitempty"`
+
 	NetLogFilters []NetworkLogFilter `json:"network_policy_log_filters,omitempty" bson:"network_policy_log_filters,omitempty"`
 
 	NetPolicyL3Level int `json:"network_policy_l3_level,omitempty" bson:"network_policy_l3_level,omitempty"`
@@ -55,6 +57,58 @@ type ConfigNetworkPolicy struct {
 	NetPolicyL7Level int `json:"network_policy_l7_level,omitempty" bson:"network_policy_l7_level,omitempty"`
 
 	NetSkipCertVerification bool `json:"skip_cert_verification,omitempty" bson:"skip_cert_verification,omitempty"`
+
+	PolicyNameTemplate string `json:"network_policy_name_template,omitempty" bson:"network_policy_name_template,omitempty"`
+	MigratePolicyNames bool   `json:"network_policy_migrate_names,omitempty" bson:"network_policy_migrate_names,omitempty"`
+
+	BaselinePolicy   bool `json:"network_policy_baseline,omitempty" bson:"network_policy_baseline,omitempty"`
+	BaselineDNSAllow bool `json:"network_policy_baseline_dns_allow,omitempty" bson:"network_policy_baseline_dns_allow,omitempty"`
+
+	StaleRuleWindow string `json:"network_policy_stale_rule_window,omitempty" bson:"network_policy_stale_rule_window,omitempty"`
+	StaleRuleAction string `json:"network_policy_stale_rule_action,omitempty" bson:"network_policy_stale_rule_action,omitempty"`
+
+	RuleMinCount int    `json:"network_policy_rule_min_count,omitempty" bson:"network_policy_rule_min_count,omitempty"`
+	RuleMinPods  int    `json:"network_policy_rule_min_pods,omitempty" bson:"network_policy_rule_min_pods,omitempty"`
+	RuleMinSpan  string `json:"network_policy_rule_min_span,omitempty" bson:"network_policy_rule_min_span,omitempty"`
+
+	RuleTypeThresholds map[string]RuleThresholdConfig `json:"network_policy_rule_type_thresholds,omitempty" bson:"network_policy_rule_type_thresholds,omitempty"`
+	RuleStagingTTL     string                         `json:"network_policy_rule_staging_ttl,omitempty" bson:"network_policy_rule_staging_ttl,omitempty"`
+
+	GapAnalysis bool `json:"network_policy_gap_analysis,omitempty" bson:"network_policy_gap_analysis,omitempty"`
+
+	ImportPoliciesFrom string `json:"network_policy_import_from,omitempty" bson:"network_policy_import_from,omitempty"`
+	ImportPoliciesDir  string `json:"network_policy_import_dir,omitempty" bson:"network_policy_import_dir,omitempty"`
+
+	FQDNThreshold         int      `json:"network_policy_fqdn_threshold,omitempty" bson:"network_policy_fqdn_threshold,omitempty"`
+	FQDNProtectedSuffixes []string `json:"network_policy_fqdn_protected_suffixes,omitempty" bson:"network_policy_fqdn_protected_suffixes,omitempty"`
+
+	HTTPHost            bool     `json:"network_policy_http_host,omitempty" bson:"network_policy_http_host,omitempty"`
+	HTTPHeaderAllowlist []string `json:"network_policy_http_header_allowlist,omitempty" bson:"network_policy_http_header_allowlist,omitempty"`
+
+	PortRangeDensity      float64 `json:"network_policy_port_range_density,omitempty" bson:"network_policy_port_range_density,omitempty"`
+	PortRangeMinPorts     int     `json:"network_policy_port_range_min_ports,omitempty" bson:"network_policy_port_range_min_ports,omitempty"`
+	PortRangeWellKnownMax int     `json:"network_policy_port_range_well_known_max,omitempty" bson:"network_policy_port_range_well_known_max,omitempty"`
+
+	IngressWorldFrom        string   `json:"network_policy_ingress_world_from,omitempty" bson:"network_policy_ingress_world_from,omitempty"`
+	IngressWorldCIDRBits    int      `json:"network_policy_ingress_world_cidr_bits,omitempty" bson:"network_policy_ingress_world_cidr_bits,omitempty"`
+	IngressWorldCIDRBitsV6  int      `json:"network_policy_ingress_world_cidr_bits_v6,omitempty" bson:"network_policy_ingress_world_cidr_bits_v6,omitempty"`
+	IngressControllerLabels []string `json:"network_policy_ingress_controller_labels,omitempty" bson:"network_policy_ingress_controller_labels,omitempty"`
+
+	ClusterwideMinNamespaces int      `json:"network_policy_clusterwide_min_namespaces,omitempty" bson:"network_policy_clusterwide_min_namespaces,omitempty"`
+	ClusterwideLabelKeys     []string `json:"network_policy_clusterwide_label_keys,omitempty" bson:"network_policy_clusterwide_label_keys,omitempty"`
+
+	HostPolicyDiscovery  bool     `json:"network_host_policy_discovery,omitempty" bson:"network_host_policy_discovery,omitempty"`
+	HostPolicyNodeLabels []string `json:"network_host_policy_node_labels,omitempty" bson:"network_host_policy_node_labels,omitempty"`
+
+	ServiceStrategy     string            `json:"network_policy_service_strategy,omitempty" bson:"network_policy_service_strategy,omitempty"`
+	NsServiceStrategies map[string]string `json:"network_policy_ns_service_strategies,omitempty" bson:"network_policy_ns_service_strategies,omitempty"`
+}
+
+// RuleThresholdConfig - the thresholds of a rule type
+type RuleThresholdConfig struct {
+	MinCount int    `json:"min_count,omitempty" bson:"min_count,omitempty"`
+	MinPods  int    `json:"min_pods,omitempty" bson:"min_pods,omitempty"`
+	MinSpan  string `json:"min_span,omitempty" bson:"min_span,omitempty"`
 }
 
 type SystemLogFilter struct {
@@ -89,6 +143,38 @@ type ConfigSystemPolicy struct {
 
 	ProcessFromSource bool `json:"system_policy_proc_fromsource,omitempty" bson:"system_policy_proc_fromsource,omitempty"`
 	FileFromSource    bool `json:"system_policy_file_fromsource,omitempty" bson:"system_policy_file_fromsource,omitempty"`
+
+	PathAggregation     string         `json:"system_policy_path_aggregation,omitempty" bson:"system_policy_path_aggregation,omitempty"`
+	PatternThreshold    int            `json:"system_policy_pattern_threshold,omitempty" bson:"system_policy_pattern_threshold,omitempty"`
+	PatternStrictness   string         `json:"system_policy_pattern_strictness,omitempty" bson:"system_policy_pattern_strictness,omitempty"`
+	NsPatternThresholds map[string]int `json:"system_policy_ns_pattern_thresholds,omitempty" bson:"system_policy_ns_pattern_thresholds,omitempty"`
+
+	HostPolicyDiscovery  bool     `json:"system_host_policy_discovery,omitempty" bson:"system_host_policy_discovery,omitempty"`
+	HostPolicyNodeLabels []string `json:"system_host_policy_node_labels,omitempty" bson:"system_host_policy_node_labels,omitempty"`
+
+	PolicyNameTemplate string `json:"system_policy_name_template,omitempty" bson:"system_policy_name_template,omitempty"`
+	MigratePolicyNames bool   `json:"system_policy_migrate_names,omitempty" bson:"system_policy_migrate_names,omitempty"`
+
+	ContainerScoped   bool     `json:"system_policy_container_scoped,omitempty" bson:"system_policy_container_scoped,omitempty"`
+	ExcludeContainers []string `json:"system_policy_exclude_containers,omitempty" bson:"system_policy_exclude_containers,omitempty"`
+
+	StaleRuleWindow string `json:"system_policy_stale_rule_window,omitempty" bson:"system_policy_stale_rule_window,omitempty"`
+	StaleRuleAction string `json:"system_policy_stale_rule_action,omitempty" bson:"system_policy_stale_rule_action,omitempty"`
+
+	ExecSessionMode    string            `json:"system_policy_exec_session_mode,omitempty" bson:"system_policy_exec_session_mode,omitempty"`
+	NsExecSessionModes map[string]string `json:"system_policy_ns_exec_session_modes,omitempty" bson:"system_policy_ns_exec_session_modes,omitempty"`
+
+	RuleMinCount int    `json:"system_policy_rule_min_count,omitempty" bson:"system_policy_rule_min_count,omitempty"`
+	RuleMinPods  int    `json:"system_policy_rule_min_pods,omitempty" bson:"system_policy_rule_min_pods,omitempty"`
+	RuleMinSpan  string `json:"system_policy_rule_min_span,omitempty" bson:"system_policy_rule_min_span,omitempty"`
+
+	RuleTypeThresholds map[string]RuleThresholdConfig `json:"system_policy_rule_type_thresholds,omitempty" bson:"system_policy_rule_type_thresholds,omitempty"`
+	RuleStagingTTL     string                         `json:"system_policy_rule_staging_ttl,omitempty" bson:"system_policy_rule_staging_ttl,omitempty"`
+
+	GapAnalysis bool `json:"system_policy_gap_analysis,omitempty" bson:"system_policy_gap_analysis,omitempty"`
+
+	ImportPoliciesFrom string `json:"system_policy_import_from,omitempty" bson:"system_policy_import_from,omitempty"`
+	ImportPoliciesDir  string `json:"system_policy_import_dir,omitempty" bson:"system_policy_import_dir,omitempty"`
 }
 
 type ConfigAdmissionControllerPolicy struct {
@@ -101,6 +187,13 @@ type ConfigClusterMgmt struct {
 	ClusterMgmtURL  string `json:"cluster_mgmt_url,omitempty" bson:"cluster_mgmt_url,omitempty"`
 }
 
+type ConfigWorkloadState struct {
+	Window            string `json:"window,omitempty" bson:"window,omitempty"`
+	StableAfter       string `json:"stable_after,omitempty" bson:"stable_after,omitempty"`
+	EnforceReadyAfter string `json:"enforce_ready_after,omitempty" bson:"enforce_ready_after,omitempty"`
+	AnomalyMode       bool   `json:"anomaly_mode,omitempty" bson:"anomaly_mode,omitempty"`
+}
+
 type ConfigObservability struct {
 	Enable              bool   `json:"enable,omitempty" bson:"enable,omitempty"`
 	CronJobTimeInterval string `json:"cronjob_time_interval,omitempty" bson:"cronjob_time_interval,omitempty"`
@@ -121,6 +214,12 @@ type ConfigPurgeOldDBEntries struct {
 	DBName              []string `json:"db_name,omitempty" bson:"db_name,omitempty"`
 }
 
+type ConfigDriftDetection struct {
+	Enable              bool   `json:"enable,omitempty" bson:"enable,omitempty"`
+	CronJobTimeInterval string `json:"cronjob_time_interval,omitempty" bson:"cronjob_time_interval,omitempty"`
+	MetricsPort         string `json:"metrics_port,omitempty" bson:"metrics_port,omitempty"`
+}
+
 type ConfigRecommendPolicy struct {
 	OperationMode                      int    `json:"operation_mode,omitempty" bson:"operation_mode,omitempty"`
 	CronJobTimeInterval                string `json:"cronjob_time_interval,omitempty" bson:"cronjob_time_interval,omitempty"`
@@ -145,8 +244,10 @@ type Configuration struct {
 	ConfigSysPolicy                 ConfigSystemPolicy              `json:"config_system_policy,omitempty" bson:"config_system_policy,omitempty"`
 	ConfigAdmissionControllerPolicy ConfigAdmissionControllerPolicy `json:"config_admission_controller_policy,omitempty" bson:"config_admission_controller_policy,omitempty"`
 	ConfigClusterMgmt               ConfigClusterMgmt               `json:"config_cluster_mgmt,omitempty" bson:"config_cluster_mgmt,omitempty"`
+	ConfigWorkloadState             ConfigWorkloadState             `json:"config_workload_state,omitempty" bson:"config_workload_state,omitempty"`
 	ConfigObservability             ConfigObservability             `json:"config_observability,omitempty" bson:"config_observability,omitempty"`
 	ConfigPublisher                 ConfigPublisher                 `json:"config_summarizer,omitempty" bson:"config_summarizer,omitempty"`
 	ConfigPurgeOldDBEntries         ConfigPurgeOldDBEntries         `json:"config_purge_old_db_entries,omitempty" bson:"config_purge_old_db_entries,omitempty"`
 	ConfigRecommendPolicy           ConfigRecommendPolicy           `json:"config_recommend_policy,omitempty" bson:"config_recommend_policy,omitempty"`
+	ConfigDriftDetection            ConfigDriftDetection            `json:"config_drift_detection,omitempty" bson:"config_drift_detection,omitempty"`
 }
@@ -11,6 +11,9 @@ const (
 	PolicyDiscoveryContainerNamespace = "container_namespace"
 	PolicyDiscoveryContainerPodName   = "container_podname"
 
+	// KubeArmorContainerNameLabel - selector label for container-scoped policies
+	KubeArmorContainerNameLabel = "kubearmor.io/container.name"
+
 	// KubeArmor k8s
 	PreConfiguredKubearmorRule = "/lib/x86_64-linux-gnu/"
 
@@ -18,13 +21,24 @@ const (
 	RecordSeparator = "^^"
 
 	// Network Policy
-	KindKnoxNetworkPolicy     = "KnoxNetworkPolicy"
-	KindKnoxHostNetworkPolicy = "KnoxHostNetworkPolicy"
+	KindKnoxNetworkPolicy            = "KnoxNetworkPolicy"
+	KindKnoxHostNetworkPolicy        = "KnoxHostNetworkPolicy"
+	KindKnoxClusterwideNetworkPolicy = "KnoxClusterwideNetworkPolicy"
+
+	// Network Policy baseline rules
+	NetworkRuleDefaultDeny = "defaultDeny"
+	NetworkRuleDNSBaseline = "dnsBaseline"
 
 	// Cilium Policy
 	KindCiliumNetworkPolicy            = cu.ResourceTypeCiliumNetworkPolicy
 	KindCiliumClusterwideNetworkPolicy = cu.ResourceTypeCiliumClusterwideNetworkPolicy
 
+	// CiliumNamespaceLabel - endpoint label of the pod namespace
+	CiliumNamespaceLabel = "k8s:io.kubernetes.pod.namespace"
+
+	// CiliumClusterLabel - endpoint label of the cluster in the cluster mesh
+	CiliumClusterLabel = "io.cilium.k8s.policy.cluster"
+
 	// Kubernetes Policy
 	KindK8sNetworkPolicy = "NetworkPolicy"
 
@@ -36,6 +50,50 @@ const (
 	PolicyTypeNetwork             = "network"
 	PolicyTypeAdmissionController = "admission-controller"
 
+	// Cilium drop reasons of the flows dropped by the policies
+	DropReasonPolicyDenied = 133 // not allowed by the policies
+	DropReasonPolicyDeny   = 181 // denied by the deny policy
+
+	// Exec session modes
+	ExecSessionModeInclude = "include"
+	ExecSessionModeExclude = "exclude"
+	ExecSessionModeDebug   = "debug"
+
+	// Service strategies of the egress to the pods
+	ServiceStrategyEndpoint = "endpoint"
+	ServiceStrategyService  = "service"
+	ServiceStrategyBoth     = "both"
+
+	// the label of the debug policy generated from the exec sessions
+	ExecSessionLabel = "kubearmor.io/exec-session"
+
+	// Workload learning states
+	WorkloadStateLearning     = "learning"
+	WorkloadStateStable       = "stable"
+	WorkloadStateEnforceReady = "enforce-ready"
+
+	// the status of the policies imported from the cluster, which are only the
+	// baseline of the deduplication and never proposed
+	PolicyStatusImported = "imported"
+
+	// Anomaly statuses
+	AnomalyStatusPending      = "pending"
+	AnomalyStatusAcknowledged = "acknowledged"
+
+	// Policy coverage statuses
+	CoverageApplied    = "applied"
+	CoverageDiscovered = "discovered"
+	CoverageNone       = "none"
+
+	// Policy drift statuses
+	DriftStatusNotApplied = "not-applied"
+	DriftStatusOutdated   = "outdated"
+	DriftStatusUnknown    = "unknown"
+
+	// Stale rule actions
+	StaleRuleActionFlag = "flag"
+	StaleRuleActionDrop = "drop"
+
 	// Hardening policy
 	HardeningPolicy = "harden"
 
@@ -46,6 +104,9 @@ const (
 	K8sNwPolicyAPIVersion = "networking.k8s.io/v1"
 	K8sNwPolicyKind       = "NetworkPolicy"
 
+	// K8sNamespaceNameLabel - label set by k8s to every namespace
+	K8sNamespaceNameLabel = "kubernetes.io/metadata.name"
+
 	// max no. of tries to connect to kubearmor-relay
 	Maxtries = 6
 )
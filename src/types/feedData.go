@@ -40,10 +40,14 @@ type SystemLogEvent struct {
 	ContainerID   string `json:"containerID,omitempty"`
 	ContainerName string `json:"containerName,omitempty"`
 
-	HostPID int `json:"hostPid,omitempty"`
-	PPID    int `json:"ppid,omitempty"`
-	PID     int `json:"pid,omitempty"`
-	UID     int `json:"uid,omitempty"`
+	HostPPID int `json:"hostPpid,omitempty"`
+	HostPID  int `json:"hostPid,omitempty"`
+	PPID     int `json:"ppid,omitempty"`
+	PID      int `json:"pid,omitempty"`
+	UID      int `json:"uid,omitempty"`
+
+	ParentProcessName string `json:"parentProcessName,omitempty"`
+	ProcessName       string `json:"processName,omitempty"`
 
 	Type      string `json:"type,omitempty"`
 	Source    string `json:"source,omitempty"`
@@ -100,3 +104,6 @@ type WorkloadProcessFileSet struct {
 
 type PolicyNameMap map[WorkloadProcessFileSet]string
 type ResourceSetMap map[WorkloadProcessFileSet][]string
+
+// ResourceStatsMap holds the statistics of each resource (path, directory or pattern) of the file set
+type ResourceStatsMap map[WorkloadProcessFileSet]map[string]RuleStats
@@ -18,6 +18,8 @@ type SystemData struct {
 	ProcessPaths []string `json:"processes,omitempty"`
 	FilePaths    []string `json:"files,omitempty"`
 	NetworkPaths []string `json:"network,omitempty"`
+
+	RuleStats map[string]RuleStats `json:"ruleStats,omitempty"` // key: process/file path
 }
 
 type SysInsightData struct {
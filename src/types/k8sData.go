@@ -28,11 +28,15 @@ type Service struct {
 	Protocol  string `json:"protocol,omitempty" bson:"protocol,omitempty"`
 	ClusterIP string `json:"cluster_ip,omitempty" bson:"cluster_ip,omitempty"`
 
+	ClusterIPs []string `json:"cluster_ips,omitempty" bson:"cluster_ips,omitempty"` // dual-stack
+
 	ServicePort int      `json:"service_port" bson:"service_port"`
 	NodePort    int      `json:"node_port" bson:"node_port"`
 	TargetPort  int      `json:"target_port" bson:"target_port"`
 	ExternalIPs []string `json:"external_ip" bson:"external_ip"`
 
+	ExternalName string `json:"external_name,omitempty" bson:"external_name,omitempty"` // ExternalName service
+
 	Selector map[string]string `json:"selector" bson:"selector"`
 }
 
@@ -42,6 +46,9 @@ type Pod struct {
 	PodName   string   `json:"pod_name" bson:"pod_name"`
 	Labels    []string `json:"labels" bson:"labels"`
 	PodIP     string   `json:"pod_ip" bson:"pod_ip"`
+	PodIPs    []string `json:"pod_ips,omitempty" bson:"pod_ips,omitempty"` // dual-stack
+
+	HostNetwork bool `json:"host_network,omitempty" bson:"host_network,omitempty"`
 }
 
 // Deployment Structure
@@ -5,17 +5,25 @@ type KnoxNetworkLog struct {
 	FlowID int `json:"flow_id,omitempty" bson:"flow_id"`
 
 	ClusterName   string `json:"cluster_name,omitempty" bson:"cluster_name"`
+	NodeName      string `json:"node_name,omitempty" bson:"node_name"`
 	ContainerName string `json:"container_name,omitempty" bson:"container_name"`
 
 	SrcNamespace      string   `json:"src_namespace,omitempty" bson:"src_namespace"`
 	SrcReservedLabels []string `json:"src_reserved_labels,omitempty" bson:"src_reserved_labels"`
 	SrcPodName        string   `json:"src_pod_name,omitempty" bson:"src_pod_name"`
+	SrcCluster        string   `json:"src_cluster,omitempty" bson:"src_cluster"` // for cluster mesh
+	SrcLabels         []string `json:"src_labels,omitempty" bson:"src_labels"`   // for cluster mesh
 
 	DstNamespace      string   `json:"dst_namespace,omitempty" bson:"dst_namespace"`
 	DstReservedLabels []string `json:"dst_reserved_labels,omitempty" bson:"dst_reserved_labels"`
 	DstPodName        string   `json:"dst_pod_name,omitempty" bson:"dst_pod_name"`
+	DstCluster        string   `json:"dst_cluster,omitempty" bson:"dst_cluster"` // for cluster mesh
+	DstLabels         []string `json:"dst_labels,omitempty" bson:"dst_labels"`   // for cluster mesh
 
-	EtherType int `json:"ether_type,omitempty" bson:"ether_type"` // not used, we assume all the ipv4
+	DstServiceName      string `json:"dst_service_name,omitempty" bson:"dst_service_name"`           // the service the dst is reached via
+	DstServiceNamespace string `json:"dst_service_namespace,omitempty" bson:"dst_service_namespace"` // the service the dst is reached via
+
+	EtherType int `json:"ether_type,omitempty" bson:"ether_type"` // 0x0800: ipv4, 0x86DD: ipv6
 
 	Protocol int    `json:"protocol,omitempty" bson:"protocol"`
 	SrcIP    string `json:"src_ip,omitempty" bson:"src_ip"`
@@ -36,9 +44,16 @@ type KnoxNetworkLog struct {
 	HTTPMethod string `json:"http_method,omitempty" bson:"http_method"` // for L7 http
 	HTTPPath   string `json:"http_path,omitempty" bson:"http_path"`     // for L7 http
 
+	HTTPHost    string   `json:"http_host,omitempty" bson:"http_host"`       // for L7 http
+	HTTPHeaders []string `json:"http_headers,omitempty" bson:"http_headers"` // for L7 http, "name: value"
+
+	KafkaAPIKey string `json:"kafka_api_key,omitempty" bson:"kafka_api_key"` // for L7 kafka
+	KafkaTopic  string `json:"kafka_topic,omitempty" bson:"kafka_topic"`     // for L7 kafka
+
 	Direction string `json:"direction,omitempty" bson:"direction"` // ingress or egress
 
-	Action string `json:"action,omitempty" bson:"action"`
+	Action     string `json:"action,omitempty" bson:"action"`
+	DropReason int    `json:"drop_reason,omitempty" bson:"drop_reason"` // the cilium drop reason of the denied flow
 }
 
 // KnoxSystemLog Structure
@@ -55,6 +70,17 @@ type KnoxSystemLog struct {
 	SourceOrigin string `json:"source_origin,omitempty"` // if source origin "/usr/bin/iperf3 -s -p 5101"
 	Source       string `json:"source,omitempty"`        // --> source: "/usr/bin/iperf3"
 
+	// process lineage, the pids in the container pid namespace
+	HostPPID          int32  `json:"host_ppid,omitempty"`
+	HostPID           int32  `json:"host_pid,omitempty"`
+	PPID              int32  `json:"ppid,omitempty"`
+	PID               int32  `json:"pid,omitempty"`
+	ParentProcessName string `json:"parent_process_name,omitempty"`
+	ProcessName       string `json:"process_name,omitempty"`
+
+	// descending from an exec session (e.g. kubectl exec), not from the container entrypoint
+	ExecSession bool `json:"exec_session,omitempty"`
+
 	Operation string `json:"operation,omitempty"`
 
 	ResourceOrigin string `json:"resource_origin,omitempty"`
@@ -1,6 +1,10 @@
 package types
 
-import kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+import (
+	"strconv"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
 
 // LabelMap stores the label of an endpoint
 type LabelMap = map[string]string
@@ -29,10 +33,36 @@ func (x SpecICMP) Equal(y SpecICMP) bool {
 type SpecPort struct {
 	Port     string `json:"port,omitempty" yaml:"port,omitempty" bson:"port,omitempty"`
 	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty" bson:"protocol,omitempty"`
+	EndPort  int    `json:"endPort,omitempty" yaml:"endPort,omitempty" bson:"endPort,omitempty"` // port range, port-endPort
 }
 
 func (x SpecPort) Equal(y SpecPort) bool {
-	return x.Port == y.Port && x.Protocol == y.Protocol
+	return x.Port == y.Port && x.Protocol == y.Protocol && x.EndPort == y.EndPort
+}
+
+// Includes returns true if the port (range) x covers the port (range) y
+func (x SpecPort) Includes(y SpecPort) bool {
+	if x.Equal(y) {
+		return true
+	}
+	if x.EndPort == 0 || x.Protocol != y.Protocol {
+		return false
+	}
+
+	start, err := strconv.Atoi(x.Port)
+	if err != nil {
+		return false
+	}
+	yStart, err := strconv.Atoi(y.Port)
+	if err != nil {
+		return false
+	}
+	yEnd := y.EndPort
+	if yEnd == 0 {
+		yEnd = yStart
+	}
+
+	return start <= yStart && yEnd <= x.EndPort
 }
 
 // SpecService Structure
@@ -43,7 +73,14 @@ type SpecService struct {
 
 // SpecFQDN Structure
 type SpecFQDN struct {
-	MatchNames []string `json:"matchNames,omitempty" yaml:"matchNames,omitempty" bson:"matchNames,omitempty"`
+	MatchNames    []string `json:"matchNames,omitempty" yaml:"matchNames,omitempty" bson:"matchNames,omitempty"`
+	MatchPatterns []string `json:"matchPatterns,omitempty" yaml:"matchPatterns,omitempty" bson:"matchPatterns,omitempty"`
+}
+
+// Names returns the exact names followed by the wildcard patterns
+func (x SpecFQDN) Names() []string {
+	names := append([]string{}, x.MatchNames...)
+	return append(names, x.MatchPatterns...)
 }
 
 // SpecHTTP Structure
@@ -51,6 +88,64 @@ type SpecHTTP struct {
 	Method     string `json:"method,omitempty" yaml:"method,omitempty" bson:"method,omitempty"`
 	Path       string `json:"path,omitempty" yaml:"path,omitempty" bson:"path,omitempty"`
 	Aggregated bool   `json:"aggregated,omitempty" yaml:"aggregated,omitempty" bson:"aggregated,omitempty"`
+	GRPC       bool   `json:"grpc,omitempty" yaml:"grpc,omitempty" bson:"grpc,omitempty"`
+
+	Host    string   `json:"host,omitempty" yaml:"host,omitempty" bson:"host,omitempty"`
+	Headers []string `json:"headers,omitempty" yaml:"headers,omitempty" bson:"headers,omitempty"` // "name: value"
+}
+
+// SpecKafka Structure
+type SpecKafka struct {
+	Role     string `json:"role,omitempty" yaml:"role,omitempty" bson:"role,omitempty"` // produce|consume
+	APIKey   string `json:"apiKey,omitempty" yaml:"apiKey,omitempty" bson:"apiKey,omitempty"`
+	ClientID string `json:"clientID,omitempty" yaml:"clientID,omitempty" bson:"clientID,omitempty"`
+	Topic    string `json:"topic,omitempty" yaml:"topic,omitempty" bson:"topic,omitempty"`
+}
+
+// RuleStats Structure - the observation statistics of a discovered rule
+type RuleStats struct {
+	FirstSeen int64 `json:"firstSeen,omitempty" yaml:"firstSeen,omitempty" bson:"firstSeen,omitempty"`
+	LastSeen  int64 `json:"lastSeen,omitempty" yaml:"lastSeen,omitempty" bson:"lastSeen,omitempty"`
+	HitCount  int64 `json:"hitCount,omitempty" yaml:"hitCount,omitempty" bson:"hitCount,omitempty"`
+
+	// not seen within the staleness window
+	Stale bool `json:"stale,omitempty" yaml:"stale,omitempty" bson:"stale,omitempty"`
+	// stale, and excluded from the generated policy
+	Pruned bool `json:"pruned,omitempty" yaml:"pruned,omitempty" bson:"pruned,omitempty"`
+
+	// the distinct pods exhibiting the rule
+	Pods []string `json:"pods,omitempty" yaml:"pods,omitempty" bson:"pods,omitempty"`
+
+	// the latest samples of the logs which produced the rule
+	Evidence []RuleEvidence `json:"evidence,omitempty" yaml:"evidence,omitempty" bson:"evidence,omitempty"`
+}
+
+// RuleThreshold Structure - the minimum observations before a rule is proposed
+type RuleThreshold struct {
+	MinCount int64
+	MinPods  int
+	MinSpan  int64 // seconds between the first and the last observation
+}
+
+// StagedRule Structure - a candidate rule below the thresholds
+type StagedRule struct {
+	ClusterName string
+	Namespace   string
+	PolicyType  string // network or system
+	RuleKey     string
+	Candidate   string // the candidate rule in json
+	RuleStats   RuleStats
+}
+
+// RuleEvidence Structure - a sample of the network flow or KubeArmor event which produced the rule
+type RuleEvidence struct {
+	FlowID    int    `json:"flowId,omitempty" yaml:"flowId,omitempty" bson:"flowId,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty" yaml:"timestamp,omitempty" bson:"timestamp,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty" bson:"namespace,omitempty"`
+	PodName   string `json:"podName,omitempty" yaml:"podName,omitempty" bson:"podName,omitempty"`
+
+	// raw fields of the log, e.g. dst_port, resource
+	Fields map[string]string `json:"fields,omitempty" yaml:"fields,omitempty" bson:"fields,omitempty"`
 }
 
 // Selector Structure
@@ -64,9 +159,12 @@ type Ingress struct {
 	ICMPs       []SpecICMP        `json:"icmps,omitempty" yaml:"icmps,omitempty" bson:"icmps,omitempty"`
 	ToPorts     []SpecPort        `json:"toPorts,omitempty" yaml:"toPorts,omitempty" bson:"toPorts,omitempty"`
 	ToHTTPs     []SpecHTTP        `json:"toHTTPs,omitempty" yaml:"toHTTPs,omitempty" bson:"toHTTPs,omitempty"`
+	ToKafkas    []SpecKafka       `json:"toKafkas,omitempty" yaml:"toKafkas,omitempty" bson:"toKafkas,omitempty"`
 
 	FromCIDRs    []SpecCIDR `json:"fromCIDRs,omitempty" yaml:"fromCIDRs,omitempty" bson:"fromCIDRs,omitempty"`
 	FromEntities []string   `json:"fromEntities,omitempty" yaml:"fromEntities,omitempty" bson:"fromEntities,omitempty"`
+
+	RuleStats `yaml:",inline" bson:",inline"`
 }
 
 // Egress Structure
@@ -80,12 +178,16 @@ type Egress struct {
 	ToServices []SpecService `json:"toServices,omitempty" yaml:"toServices,omitempty" bson:"toServices,omitempty"`
 	ToFQDNs    []SpecFQDN    `json:"toFQDNs,omitempty" yaml:"toFQDNs,omitempty" bson:"toFQDNs,omitempty"`
 	ToHTTPs    []SpecHTTP    `json:"toHTTPs,omitempty" yaml:"toHTTPs,omitempty" bson:"toHTTPs,omitempty"`
+	ToKafkas   []SpecKafka   `json:"toKafkas,omitempty" yaml:"toKafkas,omitempty" bson:"toKafkas,omitempty"`
+
+	RuleStats `yaml:",inline" bson:",inline"`
 }
 
 type L47Rule interface {
 	GetICMPRules() []SpecICMP
 	GetPortRules() []SpecPort
 	GetHTTPRules() []SpecHTTP
+	GetKafkaRules() []SpecKafka
 }
 
 func (x Ingress) GetICMPRules() []SpecICMP {
@@ -100,6 +202,10 @@ func (x Ingress) GetHTTPRules() []SpecHTTP {
 	return x.ToHTTPs
 }
 
+func (x Ingress) GetKafkaRules() []SpecKafka {
+	return x.ToKafkas
+}
+
 func (x Egress) GetICMPRules() []SpecICMP {
 	return x.ICMPs
 }
@@ -112,6 +218,10 @@ func (x Egress) GetHTTPRules() []SpecHTTP {
 	return x.ToHTTPs
 }
 
+func (x Egress) GetKafkaRules() []SpecKafka {
+	return x.ToKafkas
+}
+
 // Spec Structure
 type Spec struct {
 	Selector Selector `json:"selector,omitempty" yaml:"selector,omitempty" bson:"selector,omitempty"`
@@ -149,11 +259,12 @@ type CiliumCIDRSet struct {
 // CiliumPort Structure
 type CiliumPort struct {
 	Port     string `json:"port,omitempty" yaml:"port,omitempty"`
+	EndPort  int    `json:"endPort,omitempty" yaml:"endPort,omitempty"`
 	Protocol string `json:"protocol" yaml:"protocol"`
 }
 
 // SubRule ...
-type SubRule map[string]string
+type SubRule map[string]interface{}
 
 // CiliumFQDN ...
 type CiliumFQDN map[string]string
@@ -197,6 +308,7 @@ type CiliumEgress struct {
 	ToPorts     []CiliumPortList `json:"toPorts,omitempty" yaml:"toPorts,omitempty"`
 	ICMPs       []CiliumICMP     `json:"icmps,omitempty" yaml:"icmps,omitempty"`
 	ToCIDRs     []string         `json:"toCIDR,omitempty" yaml:"toCIDR,omitempty"`
+	ToCIDRSet   []CiliumCIDRSet  `json:"toCIDRSet,omitempty" yaml:"toCIDRSet,omitempty"`
 	ToEntities  []string         `json:"toEntities,omitempty" yaml:"toEntities,omitempty"`
 	ToServices  []CiliumService  `json:"toServices,omitempty" yaml:"toServices,omitempty"`
 	ToFQDNs     []CiliumFQDN     `json:"toFQDNs,omitempty" yaml:"toFQDNs,omitempty"`
@@ -208,6 +320,7 @@ type CiliumIngress struct {
 	ToPorts       []CiliumPortList `json:"toPorts,omitempty" yaml:"toPorts,omitempty"`
 	ICMPs         []CiliumICMP     `json:"icmps,omitempty" yaml:"icmps,omitempty"`
 	FromCIDRs     []string         `json:"fromCIDR,omitempty" yaml:"fromCIDR,omitempty"`
+	FromCIDRSet   []CiliumCIDRSet  `json:"fromCIDRSet,omitempty" yaml:"fromCIDRSet,omitempty"`
 	FromEntities  []string         `json:"fromEntities,omitempty" yaml:"fromEntities,omitempty"`
 }
 
@@ -244,6 +357,8 @@ type KnoxMatchPaths struct {
 	ReadOnly   bool             `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
 	OwnerOnly  bool             `json:"ownerOnly,omitempty" yaml:"ownerOnly,omitempty"`
 	FromSource []KnoxFromSource `json:"fromSource,omitempty" yaml:"fromSource,omitempty"`
+
+	RuleStats `yaml:",inline"`
 }
 
 // KnoxMatchDirectories Structure
@@ -253,6 +368,17 @@ type KnoxMatchDirectories struct {
 	ReadOnly   bool             `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
 	OwnerOnly  bool             `json:"ownerOnly,omitempty" yaml:"ownerOnly,omitempty"`
 	FromSource []KnoxFromSource `json:"fromSource,omitempty" yaml:"fromSource,omitempty"`
+
+	RuleStats `yaml:",inline"`
+}
+
+// KnoxMatchPatterns Structure
+type KnoxMatchPatterns struct {
+	Pattern   string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	ReadOnly  bool   `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	OwnerOnly bool   `json:"ownerOnly,omitempty" yaml:"ownerOnly,omitempty"`
+
+	RuleStats `yaml:",inline"`
 }
 
 // KnoxMatchProtocols Structure
@@ -265,6 +391,7 @@ type KnoxMatchProtocols struct {
 type KnoxSys struct {
 	MatchPaths       []KnoxMatchPaths       `json:"matchPaths,omitempty" yaml:"matchPaths,omitempty"`
 	MatchDirectories []KnoxMatchDirectories `json:"matchDirectories,omitempty" yaml:"matchDirectories,omitempty"`
+	MatchPatterns    []KnoxMatchPatterns    `json:"matchPatterns,omitempty" yaml:"matchPatterns,omitempty"`
 }
 
 // NetworkRule Structure
@@ -278,7 +405,8 @@ type KnoxSystemSpec struct {
 	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 	Message  string   `json:"message,omitempty" yaml:"message,omitempty"`
 
-	Selector Selector `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Selector     Selector `json:"selector,omitempty" yaml:"selector,omitempty"`
+	NodeSelector Selector `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
 
 	Process KnoxSys     `json:"process,omitempty" yaml:"process,omitempty"`
 	File    KnoxSys     `json:"file,omitempty" yaml:"file,omitempty"`
@@ -335,6 +463,25 @@ type PolicyYaml struct {
 	Yaml        []byte   `json:"yaml,omitempty"`
 }
 
+// AppliedPolicy is a policy object applied in the cluster
+type AppliedPolicy struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Labels    LabelMap
+	Spec      map[string]interface{}
+}
+
+// PolicyDrift is a policy that differs between the discovered and the applied ones
+type PolicyDrift struct {
+	Cluster   string
+	Namespace string
+	Labels    string
+	Kind      string
+	Name      string
+	Status    string
+}
+
 // ============================= //
 // == KubeArmor Recommended Policy == //
 // ============================= //
@@ -0,0 +1,134 @@
+package types
+
+// WorkloadState Structure - the learning state of the policy of a workload
+type WorkloadState struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Labels      string `json:"labels,omitempty"` // sorted comma separated list of the selector labels
+	PolicyType  string `json:"policy_type,omitempty"`
+
+	State      string `json:"state,omitempty"`
+	StateSince int64  `json:"state_since,omitempty"`
+
+	FirstSeen   int64 `json:"first_seen,omitempty"`
+	LastChanged int64 `json:"last_changed,omitempty"`
+
+	// the times of the policy changes within the sliding window
+	Changes []int64 `json:"changes,omitempty"`
+}
+
+// WorkloadKey Structure - the workload within the cluster
+type WorkloadKey struct {
+	Namespace string
+	Labels    string
+}
+
+// WorkloadStateThreshold Structure - the durations (seconds) of the learning states
+type WorkloadStateThreshold struct {
+	Window            int64
+	StableAfter       int64
+	EnforceReadyAfter int64
+}
+
+// Anomaly Structure - the behaviour of a stable workload deviating from its
+// discovered policy, merged into the policy only once acknowledged
+type Anomaly struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Labels      string `json:"labels,omitempty"` // sorted comma separated list of the selector labels
+	PolicyType  string `json:"policy_type,omitempty"`
+
+	// the rule key identifies the anomaly
+	RuleKey   string `json:"rule_key,omitempty"`
+	Candidate string `json:"candidate,omitempty"` // json of the rule to be merged
+
+	// the latest triggering kubearmor event or cilium flow
+	Event     RuleEvidence `json:"event,omitempty"`
+	RuleStats RuleStats    `json:"rule_stats,omitempty"`
+
+	Status string `json:"status,omitempty"`
+}
+
+// PolicyGap Structure - the minimal rule which would have allowed the traffic
+// dropped, or the event blocked, by the existing policy of a workload
+type PolicyGap struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Labels      string `json:"labels,omitempty"` // sorted comma separated list of the selector labels
+	PolicyType  string `json:"policy_type,omitempty"`
+
+	// the rule key identifies the gap
+	RuleKey   string `json:"rule_key,omitempty"`
+	Candidate string `json:"candidate,omitempty"` // json of the minimal rule
+
+	// the latest dropped cilium flow or blocked kubearmor event
+	Event     RuleEvidence `json:"event,omitempty"`
+	RuleStats RuleStats    `json:"rule_stats,omitempty"`
+}
+
+// WorkloadCoverage Structure - how the behaviour of a workload is covered by the policies
+type WorkloadCoverage struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Labels      string `json:"labels,omitempty"`
+
+	// applied, discovered or none
+	NetworkIngress string `json:"networkIngress,omitempty"`
+	NetworkEgress  string `json:"networkEgress,omitempty"`
+	SystemProcess  string `json:"systemProcess,omitempty"`
+	SystemFile     string `json:"systemFile,omitempty"`
+
+	CoverageCount
+}
+
+// CoverageCount Structure - the observed flows/events and the ones under some rule
+type CoverageCount struct {
+	ObservedFlows  int64 `json:"observedFlows"`
+	CoveredFlows   int64 `json:"coveredFlows"`
+	ObservedEvents int64 `json:"observedEvents"`
+	CoveredEvents  int64 `json:"coveredEvents"`
+}
+
+// Add accumulates the counts of the other
+func (c *CoverageCount) Add(other CoverageCount) {
+	c.ObservedFlows += other.ObservedFlows
+	c.CoveredFlows += other.CoveredFlows
+	c.ObservedEvents += other.ObservedEvents
+	c.CoveredEvents += other.CoveredEvents
+}
+
+// FlowCoverage returns the fraction of the observed flows under some rule
+func (c CoverageCount) FlowCoverage() float64 {
+	if c.ObservedFlows == 0 {
+		return 0
+	}
+	return float64(c.CoveredFlows) / float64(c.ObservedFlows)
+}
+
+// EventCoverage returns the fraction of the observed events under some rule
+func (c CoverageCount) EventCoverage() float64 {
+	if c.ObservedEvents == 0 {
+		return 0
+	}
+	return float64(c.CoveredEvents) / float64(c.ObservedEvents)
+}
+
+// NamespaceCoverage Structure - the coverage summary of a namespace
+type NamespaceCoverage struct {
+	ClusterName      string `json:"clusterName,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	Workloads        int    `json:"workloads"`
+	CoveredWorkloads int    `json:"coveredWorkloads"` // covered in all the aspects
+
+	CoverageCount
+}
+
+// CoverageReport Structure - the policy coverage of a cluster
+type CoverageReport struct {
+	ClusterName string              `json:"clusterName,omitempty"`
+	Window      int64               `json:"window"` // seconds
+	Namespaces  []NamespaceCoverage `json:"namespaces"`
+	Workloads   []WorkloadCoverage  `json:"workloads"`
+
+	CoverageCount
+}